@@ -4,3 +4,4 @@ pwd=`pwd`
 GOPATH=$GOPATH:${pwd}
 
 go build test_api.go
+go build mvs_api/cmd/mvscli
@@ -0,0 +1,118 @@
+// Package address decodes and checks MVS addresses offline, so that user
+// input can be rejected before it reaches Send, Sendfrom or Didsend:
+//
+//	a, err := address.Decode(to)
+//	if err != nil || a.Testnet() {
+//		return fmt.Errorf("bad address %q", to)
+//	}
+//
+// An address is Base58Check: a version byte, the hash160 of a public key or
+// of a redeem script, and a checksum.
+package address
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Version bytes of MVS addresses.
+const (
+	MainnetP2PKH byte = 0x32 // "M..."
+	MainnetP2SH  byte = 0x05 // "3..."
+	TestnetP2PKH byte = 0x7f // "t..."
+	TestnetP2SH  byte = 0xc4 // "2..."
+	// BlackholeVersion is the version of the one address nobody holds a
+	// key for, which Burn sends to.
+	BlackholeVersion byte = 0x00
+)
+
+// Blackhole is the burn address: BlackholeVersion and an all-zero hash. It
+// is the only address with that version Decode accepts.
+const Blackhole = "1111111111111111111114oLvT2"
+
+var ErrUnknownVersion = errors.New("address: unknown version")
+
+type Kind int
+
+const (
+	// PubKeyHash addresses pay to one key.
+	PubKeyHash Kind = iota
+	// ScriptHash addresses pay to a script, such as a multisig one.
+	ScriptHash
+)
+
+func (k Kind) String() string {
+	if k == ScriptHash {
+		return "p2sh"
+	}
+	return "p2pkh"
+}
+
+type Address struct {
+	Version byte
+	Hash    [20]byte
+}
+
+// Decode parses s and checks its checksum, length and version.
+func Decode(s string) (*Address, error) {
+	version, payload, err := CheckDecode(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(payload) != 20 {
+		return nil, fmt.Errorf("address: %d byte hash, want 20", len(payload))
+	}
+	a := &Address{Version: version}
+	copy(a.Hash[:], payload)
+	switch version {
+	case MainnetP2PKH, MainnetP2SH, TestnetP2PKH, TestnetP2SH:
+	case BlackholeVersion:
+		if !a.IsBlackhole() {
+			return nil, fmt.Errorf("%w 0x%02x", ErrUnknownVersion, version)
+		}
+	default:
+		return nil, fmt.Errorf("%w 0x%02x", ErrUnknownVersion, version)
+	}
+	return a, nil
+}
+
+// Valid reports whether s is an address of any kind or network.
+func Valid(s string) bool {
+	_, err := Decode(s)
+	return err == nil
+}
+
+func New(kind Kind, testnet bool, hash [20]byte) *Address {
+	a := &Address{Hash: hash}
+	switch {
+	case kind == PubKeyHash && !testnet:
+		a.Version = MainnetP2PKH
+	case kind == ScriptHash && !testnet:
+		a.Version = MainnetP2SH
+	case kind == PubKeyHash:
+		a.Version = TestnetP2PKH
+	default:
+		a.Version = TestnetP2SH
+	}
+	return a
+}
+
+func (a *Address) Kind() Kind {
+	if a.Version == MainnetP2SH || a.Version == TestnetP2SH {
+		return ScriptHash
+	}
+	return PubKeyHash
+}
+
+// IsBlackhole reports whether a is the burn address, see Blackhole.
+func (a *Address) IsBlackhole() bool {
+	return a.Version == BlackholeVersion && a.Hash == [20]byte{}
+}
+
+func (a *Address) Testnet() bool {
+	return a.Version == TestnetP2PKH || a.Version == TestnetP2SH
+}
+
+func (a *Address) String() string {
+	return CheckEncode(a.Version, a.Hash[:])
+}
@@ -0,0 +1,99 @@
+package address
+
+import (
+	"encoding/hex"
+	"errors"
+	"testing"
+)
+
+func TestCheckEncode(t *testing.T) {
+	// The Base58Check example of the Bitcoin wiki.
+	payload, _ := hex.DecodeString("010966776006953d5567439e5e39f86a0d273bee")
+	const want = "16UwLL9Risc3QfPqBUvKofHmBQ7wMtjvM"
+	if got := CheckEncode(0x00, payload); got != want {
+		t.Errorf("CheckEncode = %s, want %s", got, want)
+	}
+	version, got, err := CheckDecode(want)
+	if err != nil || version != 0x00 || hex.EncodeToString(got) != hex.EncodeToString(payload) {
+		t.Errorf("CheckDecode = %x, %x, %v", version, got, err)
+	}
+}
+
+func TestDecode(t *testing.T) {
+	// The mainnet addresses are real ones of test_api.go: the first of the
+	// robot account and the 2 of 3 multisig address of its three keys. The
+	// testnet ones carry the same hashes, encoded outside this package.
+	const (
+		keyHash    = "8b24031888c2896cedb764012677868b5c64ef3b"
+		scriptHash = "25f7c225c6a7111478ceccd1d27a7c03594403e4"
+	)
+	tests := []struct {
+		s       string
+		version byte
+		hash    string
+	}{
+		{"MLasJFxZQnA49XEvhTHmRKi2qstkj9ppjo", MainnetP2PKH, keyHash},
+		{"359mjCL3V8PaxLUzU9mJSNtLSEXHFJmzfA", MainnetP2SH, scriptHash},
+		{"tKcK7cuk5fqX7tybakwJkyfcJijPPQErrL", TestnetP2PKH, keyHash},
+		{"2MvhynwG56atwA87Y9HPB4KsbeajT6nDkS7", TestnetP2SH, scriptHash},
+		{Blackhole, BlackholeVersion, "0000000000000000000000000000000000000000"},
+	}
+	for _, tt := range tests {
+		a, err := Decode(tt.s)
+		if err != nil {
+			t.Errorf("Decode(%s): %v", tt.s, err)
+			continue
+		}
+		if a.Version != tt.version || hex.EncodeToString(a.Hash[:]) != tt.hash {
+			t.Errorf("Decode(%s) = version 0x%02x hash %x, want 0x%02x %s", tt.s, a.Version, a.Hash, tt.version, tt.hash)
+		}
+		if a.String() != tt.s {
+			t.Errorf("Decode(%s).String() = %s", tt.s, a.String())
+		}
+		if tt.version == BlackholeVersion {
+			continue
+		}
+		if got := New(a.Kind(), a.Testnet(), a.Hash).String(); got != tt.s {
+			t.Errorf("New(%v, %v, %x) = %s, want %s", a.Kind(), a.Testnet(), a.Hash, got, tt.s)
+		}
+	}
+}
+
+func TestBlackhole(t *testing.T) {
+	if got := CheckEncode(BlackholeVersion, make([]byte, 20)); got != Blackhole {
+		t.Errorf("blackhole encodes as %s, want %s", got, Blackhole)
+	}
+	a, err := Decode(Blackhole)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !a.IsBlackhole() || !Valid(Blackhole) {
+		t.Error("blackhole address not recognised")
+	}
+	if a, _ := Decode("MLasJFxZQnA49XEvhTHmRKi2qstkj9ppjo"); a.IsBlackhole() {
+		t.Error("p2pkh address taken for the blackhole")
+	}
+	// Other version 0 addresses are Bitcoin ones.
+	if _, err := Decode("16UwLL9Risc3QfPqBUvKofHmBQ7wMtjvM"); !errors.Is(err, ErrUnknownVersion) {
+		t.Errorf("bitcoin address: err = %v, want ErrUnknownVersion", err)
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	tests := []struct {
+		s   string
+		err error
+	}{
+		{"MLasJFxZQnA49XEvhTHmRKi2qstkj9ppjp", ErrChecksum},
+		{"MLasJFxZQnA49XEvhTHmRKi2qstkj9ppj0", ErrBase58},
+		{"", ErrChecksum},
+	}
+	for _, tt := range tests {
+		if _, err := Decode(tt.s); !errors.Is(err, tt.err) {
+			t.Errorf("Decode(%q): err = %v, want %v", tt.s, err, tt.err)
+		}
+	}
+	if _, err := Decode(CheckEncode(MainnetP2PKH, make([]byte, 19))); err == nil {
+		t.Error("19 byte hash accepted")
+	}
+}
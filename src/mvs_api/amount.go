@@ -0,0 +1,230 @@
+package mvs_api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/bits"
+	"strconv"
+	"strings"
+)
+
+// Amount counts the smallest unit of ETP, the bit (10^-8 ETP), or of an
+// asset, one unit of its last decimal. The RPC methods take it for AMOUNT,
+// fee and volume and send it as the integer the node expects.
+type Amount uint64
+
+// ETPDecimals is the number of decimals of ETP.
+const ETPDecimals = 8
+
+const (
+	Bit Amount = 1
+	ETP Amount = 100000000
+)
+
+var (
+	ErrAmountOverflow = errors.New("mvs_api: amount overflow")
+	ErrNegativeAmount = errors.New("mvs_api: negative amount")
+)
+
+// maxDecimals is the most decimals an Amount can hold a unit of.
+const maxDecimals = 19
+
+// ParseAmount reads a decimal string such as "1.5" for an asset with the
+// given decimals. More fractional digits than decimals are an error, not
+// rounded away.
+func ParseAmount(s string, decimals uint32) (Amount, error) {
+	if decimals > maxDecimals {
+		return 0, fmt.Errorf("mvs_api: %d decimals not supported", decimals)
+	}
+	s = strings.TrimSpace(s)
+	if strings.HasPrefix(s, "-") {
+		return 0, ErrNegativeAmount
+	}
+	whole, frac := s, ""
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		whole, frac = s[:i], s[i+1:]
+		if frac == "" {
+			return 0, fmt.Errorf("mvs_api: bad amount %q", s)
+		}
+	}
+	if whole == "" || !isDigits(whole) || !isDigits(frac) {
+		return 0, fmt.Errorf("mvs_api: bad amount %q", s)
+	}
+	frac = strings.TrimRight(frac, "0")
+	if uint32(len(frac)) > decimals {
+		return 0, fmt.Errorf("mvs_api: amount %q has more than %d decimals", s, decimals)
+	}
+
+	w, err := strconv.ParseUint(whole, 10, 64)
+	if err != nil {
+		return 0, ErrAmountOverflow
+	}
+	a, err := Amount(w).Mul(pow10(decimals))
+	if err != nil {
+		return 0, err
+	}
+	if frac != "" {
+		f, _ := strconv.ParseUint(frac, 10, 64)
+		return a.Add(Amount(f * pow10(decimals-uint32(len(frac)))))
+	}
+	return a, nil
+}
+
+// ParseETP reads an ETP amount such as "1.5" or "1.5 ETP".
+func ParseETP(s string) (Amount, error) {
+	s = strings.TrimSpace(s)
+	if fields := strings.Fields(s); len(fields) == 2 {
+		if !strings.EqualFold(fields[1], "ETP") {
+			return 0, fmt.Errorf("mvs_api: %q is not an ETP amount", s)
+		}
+		s = fields[0]
+	}
+	return ParseAmount(s, ETPDecimals)
+}
+
+// Format writes a as a decimal string for the given decimals, without
+// trailing zeros: Amount(150000000).Format(8) is "1.5".
+func (a Amount) Format(decimals uint32) string {
+	digits := strconv.FormatUint(uint64(a), 10)
+	if decimals == 0 {
+		return digits
+	}
+	if n := int(decimals) + 1 - len(digits); n > 0 {
+		digits = strings.Repeat("0", n) + digits
+	}
+	whole, frac := digits[:len(digits)-int(decimals)], strings.TrimRight(digits[len(digits)-int(decimals):], "0")
+	if frac == "" {
+		return whole
+	}
+	return whole + "." + frac
+}
+
+// ETP writes a as ETP, e.g. "1.5 ETP".
+func (a Amount) ETP() string {
+	return a.Format(ETPDecimals) + " ETP"
+}
+
+func (a Amount) Add(b Amount) (Amount, error) {
+	sum, carry := bits.Add64(uint64(a), uint64(b), 0)
+	if carry != 0 {
+		return 0, ErrAmountOverflow
+	}
+	return Amount(sum), nil
+}
+
+func (a Amount) Sub(b Amount) (Amount, error) {
+	if b > a {
+		return 0, ErrNegativeAmount
+	}
+	return a - b, nil
+}
+
+func (a Amount) Mul(n uint64) (Amount, error) {
+	hi, lo := bits.Mul64(uint64(a), n)
+	if hi != 0 {
+		return 0, ErrAmountOverflow
+	}
+	return Amount(lo), nil
+}
+
+// SumAmounts adds up amounts, failing on overflow.
+func SumAmounts(amounts ...Amount) (Amount, error) {
+	var total Amount
+	for _, a := range amounts {
+		var err error
+		if total, err = total.Add(a); err != nil {
+			return 0, err
+		}
+	}
+	return total, nil
+}
+
+// UnmarshalJSON takes the integer the node sends, also when quoted.
+func (a *Amount) UnmarshalJSON(data []byte) error {
+	s := strings.Trim(string(data), `"`)
+	if s == "null" {
+		return nil
+	}
+	v, err := strconv.ParseUint(s, 10, 64)
+	if err != nil {
+		return fmt.Errorf("mvs_api: bad amount %s", data)
+	}
+	*a = Amount(v)
+	return nil
+}
+
+// Decimals returns the decimal_number of symbol, 8 for ETP. Asset decimals
+// are fetched once with Getasset and cached.
+func (r *RPCClient) Decimals(ctx context.Context, symbol string) (uint32, error) {
+	symbol = strings.ToUpper(symbol)
+	if symbol == "ETP" {
+		return ETPDecimals, nil
+	}
+	r.RLock()
+	decimals, ok := r.decimals[symbol]
+	r.RUnlock()
+	if ok {
+		return decimals, nil
+	}
+
+	assets, err := r.GetassetContext(ctx, symbol, false)
+	if err != nil {
+		return 0, err
+	}
+	for _, asset := range assets {
+		if strings.EqualFold(asset.Symbol, symbol) {
+			r.Lock()
+			if r.decimals == nil {
+				r.decimals = map[string]uint32{}
+			}
+			r.decimals[symbol] = asset.DecimalNumber
+			r.Unlock()
+			return asset.DecimalNumber, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: %s", ErrUnknownSymbol, symbol)
+}
+
+// ParseAmount reads an amount followed by its unit, "1.5 ETP" or
+// "12.25 MVS.ZGC", and returns it with the upper-cased symbol.
+func (r *RPCClient) ParseAmount(ctx context.Context, s string) (Amount, string, error) {
+	fields := strings.Fields(s)
+	if len(fields) != 2 {
+		return 0, "", fmt.Errorf("mvs_api: amount %q needs a unit", s)
+	}
+	symbol := strings.ToUpper(fields[1])
+	decimals, err := r.Decimals(ctx, symbol)
+	if err != nil {
+		return 0, "", err
+	}
+	a, err := ParseAmount(fields[0], decimals)
+	return a, symbol, err
+}
+
+// FormatAmount writes a of symbol with its unit, e.g. "12.25 MVS.ZGC".
+func (r *RPCClient) FormatAmount(ctx context.Context, a Amount, symbol string) (string, error) {
+	decimals, err := r.Decimals(ctx, symbol)
+	if err != nil {
+		return "", err
+	}
+	return a.Format(decimals) + " " + strings.ToUpper(symbol), nil
+}
+
+func isDigits(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// pow10 is 10^n for n up to maxDecimals.
+func pow10(n uint32) uint64 {
+	p := uint64(1)
+	for i := uint32(0); i < n; i++ {
+		p *= 10
+	}
+	return p
+}
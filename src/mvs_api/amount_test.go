@@ -0,0 +1,155 @@
+package mvs_api_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"mvs_api"
+	"mvs_api/mvstest"
+)
+
+func TestParseAmount(t *testing.T) {
+	tests := []struct {
+		s        string
+		decimals uint32
+		want     mvs_api.Amount
+		err      bool
+	}{
+		{"1", 8, mvs_api.ETP, false},
+		{"1.5", 8, 150000000, false},
+		{" 0.00000001 ", 8, 1, false},
+		{"1.50000000000", 8, 150000000, false},
+		{"12.25", 4, 122500, false},
+		{"7", 0, 7, false},
+		{"0", 8, 0, false},
+		{"18446744073709551615", 0, 18446744073709551615, false},
+		{"1.8446744073709551615", 19, 18446744073709551615, false},
+		{"0.000000001", 8, 0, true},
+		{"1.5", 0, 0, true},
+		{"1.", 8, 0, true},
+		{".5", 8, 0, true},
+		{"", 8, 0, true},
+		{"1e8", 8, 0, true},
+		{"+1", 8, 0, true},
+		{"1,5", 8, 0, true},
+		{"1", 20, 0, true},
+	}
+	for _, tt := range tests {
+		got, err := mvs_api.ParseAmount(tt.s, tt.decimals)
+		if (err != nil) != tt.err || got != tt.want {
+			t.Errorf("ParseAmount(%q, %d) = %d, %v", tt.s, tt.decimals, got, err)
+		}
+	}
+}
+
+func TestParseAmountRange(t *testing.T) {
+	if _, err := mvs_api.ParseAmount("-1", 8); !errors.Is(err, mvs_api.ErrNegativeAmount) {
+		t.Errorf("negative: err = %v", err)
+	}
+	for _, s := range []string{"184467440737.09551616", "18446744073709551616", "200000000000"} {
+		if _, err := mvs_api.ParseAmount(s, 8); !errors.Is(err, mvs_api.ErrAmountOverflow) {
+			t.Errorf("ParseAmount(%q): err = %v, want overflow", s, err)
+		}
+	}
+}
+
+func TestParseETP(t *testing.T) {
+	for _, s := range []string{"1.5", "1.5 ETP", "1.5 etp", " 1.5  ETP "} {
+		if got, err := mvs_api.ParseETP(s); err != nil || got != 150000000 {
+			t.Errorf("ParseETP(%q) = %d, %v", s, got, err)
+		}
+	}
+	if _, err := mvs_api.ParseETP("1.5 MVS.ZGC"); err == nil {
+		t.Error("ParseETP took an asset amount")
+	}
+}
+
+func TestAmountFormat(t *testing.T) {
+	tests := []struct {
+		a        mvs_api.Amount
+		decimals uint32
+		want     string
+	}{
+		{150000000, 8, "1.5"},
+		{mvs_api.ETP, 8, "1"},
+		{1, 8, "0.00000001"},
+		{0, 8, "0"},
+		{122500, 4, "12.25"},
+		{7, 0, "7"},
+		{18446744073709551615, 19, "1.8446744073709551615"},
+	}
+	for _, tt := range tests {
+		if got := tt.a.Format(tt.decimals); got != tt.want {
+			t.Errorf("%d.Format(%d) = %q, want %q", tt.a, tt.decimals, got, tt.want)
+		}
+		back, err := mvs_api.ParseAmount(tt.want, tt.decimals)
+		if err != nil || back != tt.a {
+			t.Errorf("ParseAmount(%q, %d) = %d, %v; want %d", tt.want, tt.decimals, back, err, tt.a)
+		}
+	}
+	if got := mvs_api.Amount(250000000).ETP(); got != "2.5 ETP" {
+		t.Errorf("ETP() = %q", got)
+	}
+}
+
+func TestAmountArithmetic(t *testing.T) {
+	max := mvs_api.Amount(18446744073709551615)
+	if _, err := max.Add(1); !errors.Is(err, mvs_api.ErrAmountOverflow) {
+		t.Errorf("Add: err = %v", err)
+	}
+	if _, err := mvs_api.Amount(1).Sub(2); !errors.Is(err, mvs_api.ErrNegativeAmount) {
+		t.Errorf("Sub: err = %v", err)
+	}
+	if _, err := max.Mul(2); !errors.Is(err, mvs_api.ErrAmountOverflow) {
+		t.Errorf("Mul: err = %v", err)
+	}
+	if sum, err := mvs_api.SumAmounts(1, 2, 3); err != nil || sum != 6 {
+		t.Errorf("SumAmounts = %d, %v", sum, err)
+	}
+	if _, err := mvs_api.SumAmounts(max, 1); !errors.Is(err, mvs_api.ErrAmountOverflow) {
+		t.Errorf("SumAmounts: err = %v", err)
+	}
+}
+
+func TestAmountUnmarshalJSON(t *testing.T) {
+	var v struct{ A, B, C mvs_api.Amount }
+	if err := json.Unmarshal([]byte(`{"A": 900000000, "B": "12", "C": null}`), &v); err != nil {
+		t.Fatal(err)
+	}
+	if v.A != 900000000 || v.B != 12 || v.C != 0 {
+		t.Errorf("decoded %+v", v)
+	}
+	if err := json.Unmarshal([]byte(`{"A": -1}`), &v); err == nil {
+		t.Error("decoded a negative amount")
+	}
+}
+
+func TestClientParseAmount(t *testing.T) {
+	s := mvstest.NewServer()
+	defer s.Close()
+	c := s.Client()
+	ctx := context.Background()
+
+	// The fixture asset MVS.ZGC has 4 decimals.
+	a, symbol, err := c.ParseAmount(ctx, "12.25 mvs.zgc")
+	if err != nil || a != 122500 || symbol != "MVS.ZGC" {
+		t.Errorf("ParseAmount = %d, %q, %v", a, symbol, err)
+	}
+	if _, _, err := c.ParseAmount(ctx, "1.5 ETP"); err != nil {
+		t.Error(err)
+	}
+	if _, _, err := c.ParseAmount(ctx, "1.5"); err == nil {
+		t.Error("ParseAmount took an amount without unit")
+	}
+	if got, err := c.FormatAmount(ctx, 122500, "MVS.ZGC"); err != nil || got != "12.25 MVS.ZGC" {
+		t.Errorf("FormatAmount = %q, %v", got, err)
+	}
+	if n := len(s.Calls("getasset")); n != 1 {
+		t.Errorf("%d getasset calls, want the decimals cached after one", n)
+	}
+	if _, err := c.Decimals(ctx, "NOPE"); !errors.Is(err, mvs_api.ErrUnknownSymbol) {
+		t.Errorf("unknown symbol: err = %v", err)
+	}
+}
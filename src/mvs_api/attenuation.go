@@ -0,0 +1,246 @@
+package mvs_api
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Attenuation model types.
+const (
+	// FixedQuantity unlocks LQ in UN equal parts over LP blocks.
+	FixedQuantity = 1
+	// CustomQuantity unlocks UQ[i] after UC[i] more blocks.
+	CustomQuantity = 2
+)
+
+var ErrBadModel = errors.New("mvs_api: bad attenuation model")
+
+// AttenuationModel is the model string of Issue, Sendasset, Sendassetfrom,
+// Secondaryissue and their DID variants, which locks a quantity of an asset
+// and unlocks it by block height:
+//
+//	m := mvs_api.FixedModel(9000, 60000, 3)
+//	m = new(mvs_api.AttenuationModel).Unlock(20000, 3000).Unlock(40000, 6000)
+//	tx, err := r.Sendasset("Alice", "A123456", to, "ZGC", 9000, m.String(), 0)
+//
+// Quantities are in the smallest unit of the asset.
+type AttenuationModel struct {
+	Type          int
+	LockQuantity  Amount   // LQ
+	LockPeriod    uint64   // LP, in blocks
+	UnlockNumber  uint64   // UN
+	UnlockCycles  []uint64 // UC, blocks of each period; CustomQuantity only
+	UnlockAmounts []Amount // UQ, quantity of each period; CustomQuantity only
+	// PN and LH are kept up to date by the node in the outputs it creates
+	// and are not given when sending.
+	CurrentPeriod    uint64 // PN
+	LatestLockHeight uint64 // LH
+}
+
+// FixedModel unlocks quantity in unlocks equal parts over period blocks.
+func FixedModel(quantity Amount, period, unlocks uint64) *AttenuationModel {
+	return &AttenuationModel{Type: FixedQuantity, LockQuantity: quantity, LockPeriod: period, UnlockNumber: unlocks}
+}
+
+// Unlock appends a period of cycle blocks that unlocks quantity at its end.
+// Called on a zero AttenuationModel, it builds a CustomQuantity model whose
+// LQ, LP and UN are the totals of the periods.
+func (m *AttenuationModel) Unlock(cycle uint64, quantity Amount) *AttenuationModel {
+	m.Type = CustomQuantity
+	m.UnlockCycles = append(m.UnlockCycles, cycle)
+	m.UnlockAmounts = append(m.UnlockAmounts, quantity)
+	m.LockQuantity += quantity
+	m.LockPeriod += cycle
+	m.UnlockNumber++
+	return m
+}
+
+// ParseAttenuationModel reads a model string such as
+// "TYPE=2;LQ=9000;LP=60000;UN=3;UC=20000,20000,20000;UQ=3000,3000,3000" and
+// validates it.
+func ParseAttenuationModel(s string) (*AttenuationModel, error) {
+	m := &AttenuationModel{}
+	seen := map[string]bool{}
+	for _, field := range strings.Split(strings.TrimSpace(s), ";") {
+		if field == "" {
+			continue
+		}
+		kv := strings.SplitN(field, "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("%w: %q is not KEY=VALUE", ErrBadModel, field)
+		}
+		key, value := strings.ToUpper(strings.TrimSpace(kv[0])), strings.TrimSpace(kv[1])
+		if seen[key] {
+			return nil, fmt.Errorf("%w: %s given twice", ErrBadModel, key)
+		}
+		seen[key] = true
+
+		var err error
+		switch key {
+		case "TYPE":
+			var t uint64
+			t, err = strconv.ParseUint(value, 10, 8)
+			m.Type = int(t)
+		case "LQ":
+			m.LockQuantity, err = parseModelAmount(value)
+		case "LP":
+			m.LockPeriod, err = strconv.ParseUint(value, 10, 64)
+		case "UN":
+			m.UnlockNumber, err = strconv.ParseUint(value, 10, 64)
+		case "UC":
+			for _, v := range strings.Split(value, ",") {
+				var cycle uint64
+				if cycle, err = strconv.ParseUint(strings.TrimSpace(v), 10, 64); err != nil {
+					break
+				}
+				m.UnlockCycles = append(m.UnlockCycles, cycle)
+			}
+		case "UQ":
+			for _, v := range strings.Split(value, ",") {
+				var quantity Amount
+				if quantity, err = parseModelAmount(strings.TrimSpace(v)); err != nil {
+					break
+				}
+				m.UnlockAmounts = append(m.UnlockAmounts, quantity)
+			}
+		case "PN":
+			m.CurrentPeriod, err = strconv.ParseUint(value, 10, 64)
+		case "LH":
+			m.LatestLockHeight, err = strconv.ParseUint(value, 10, 64)
+		default:
+			return nil, fmt.Errorf("%w: unknown key %s", ErrBadModel, key)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: bad %s %q", ErrBadModel, key, value)
+		}
+	}
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func parseModelAmount(s string) (Amount, error) {
+	v, err := strconv.ParseUint(s, 10, 64)
+	return Amount(v), err
+}
+
+// Validate checks the rules the node applies to a model: LQ, LP and UN
+// positive, UN at most LP and LQ, and for CustomQuantity one UC and UQ per
+// period, none zero, adding up to LP and LQ.
+func (m *AttenuationModel) Validate() error {
+	if m.Type != FixedQuantity && m.Type != CustomQuantity {
+		return fmt.Errorf("%w: unknown TYPE %d", ErrBadModel, m.Type)
+	}
+	if m.LockQuantity == 0 || m.LockPeriod == 0 || m.UnlockNumber == 0 {
+		return fmt.Errorf("%w: LQ, LP and UN must be positive", ErrBadModel)
+	}
+	if m.UnlockNumber > m.LockPeriod {
+		return fmt.Errorf("%w: UN %d is more than LP %d", ErrBadModel, m.UnlockNumber, m.LockPeriod)
+	}
+	if m.UnlockNumber > uint64(m.LockQuantity) {
+		return fmt.Errorf("%w: UN %d is more than LQ %d", ErrBadModel, m.UnlockNumber, m.LockQuantity)
+	}
+	if m.CurrentPeriod >= m.UnlockNumber {
+		return fmt.Errorf("%w: PN %d is not less than UN %d", ErrBadModel, m.CurrentPeriod, m.UnlockNumber)
+	}
+
+	if m.Type == FixedQuantity {
+		if len(m.UnlockCycles) != 0 || len(m.UnlockAmounts) != 0 {
+			return fmt.Errorf("%w: UC and UQ are only for TYPE=%d", ErrBadModel, CustomQuantity)
+		}
+		return nil
+	}
+	if uint64(len(m.UnlockCycles)) != m.UnlockNumber || uint64(len(m.UnlockAmounts)) != m.UnlockNumber {
+		return fmt.Errorf("%w: UN is %d but UC has %d and UQ %d values", ErrBadModel, m.UnlockNumber, len(m.UnlockCycles), len(m.UnlockAmounts))
+	}
+	var period uint64
+	for _, cycle := range m.UnlockCycles {
+		if cycle == 0 || period+cycle < period {
+			return fmt.Errorf("%w: bad UC %d", ErrBadModel, cycle)
+		}
+		period += cycle
+	}
+	if period != m.LockPeriod {
+		return fmt.Errorf("%w: UC adds up to %d, not LP %d", ErrBadModel, period, m.LockPeriod)
+	}
+	for _, quantity := range m.UnlockAmounts {
+		if quantity == 0 {
+			return fmt.Errorf("%w: UQ has a zero quantity", ErrBadModel)
+		}
+	}
+	quantity, err := SumAmounts(m.UnlockAmounts...)
+	if err != nil || quantity != m.LockQuantity {
+		return fmt.Errorf("%w: UQ adds up to %d, not LQ %d", ErrBadModel, quantity, m.LockQuantity)
+	}
+	return nil
+}
+
+// String writes m in the form the RPC methods take. It does not validate.
+func (m *AttenuationModel) String() string {
+	fields := []string{
+		"TYPE=" + strconv.Itoa(m.Type),
+		"LQ=" + strconv.FormatUint(uint64(m.LockQuantity), 10),
+		"LP=" + strconv.FormatUint(m.LockPeriod, 10),
+		"UN=" + strconv.FormatUint(m.UnlockNumber, 10),
+	}
+	if len(m.UnlockCycles) > 0 || len(m.UnlockAmounts) > 0 {
+		cycles := make([]string, len(m.UnlockCycles))
+		for i, cycle := range m.UnlockCycles {
+			cycles[i] = strconv.FormatUint(cycle, 10)
+		}
+		quantities := make([]string, len(m.UnlockAmounts))
+		for i, quantity := range m.UnlockAmounts {
+			quantities[i] = strconv.FormatUint(uint64(quantity), 10)
+		}
+		fields = append(fields, "UC="+strings.Join(cycles, ","), "UQ="+strings.Join(quantities, ","))
+	}
+	if m.CurrentPeriod != 0 {
+		fields = append(fields, "PN="+strconv.FormatUint(m.CurrentPeriod, 10))
+	}
+	if m.LatestLockHeight != 0 {
+		fields = append(fields, "LH="+strconv.FormatUint(m.LatestLockHeight, 10))
+	}
+	return strings.Join(fields, ";")
+}
+
+// UnlockEvent is one period of an unlock schedule: Amount becomes spendable
+// at Height.
+type UnlockEvent struct {
+	Height uint64
+	Amount Amount
+}
+
+// Schedule lists the unlocks of a model applied at height start. A
+// FixedQuantity model unlocks LQ/UN every LP/UN blocks, the last period
+// taking the remainders of both divisions. PN and LH are ignored.
+func (m *AttenuationModel) Schedule(start uint64) ([]UnlockEvent, error) {
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+	cycles, quantities := m.UnlockCycles, m.UnlockAmounts
+	if m.Type == FixedQuantity {
+		cycles = make([]uint64, m.UnlockNumber)
+		quantities = make([]Amount, m.UnlockNumber)
+		cycle, quantity := m.LockPeriod/m.UnlockNumber, m.LockQuantity/Amount(m.UnlockNumber)
+		for i := range cycles {
+			cycles[i], quantities[i] = cycle, quantity
+		}
+		last := m.UnlockNumber - 1
+		cycles[last] += m.LockPeriod % m.UnlockNumber
+		quantities[last] += m.LockQuantity % Amount(m.UnlockNumber)
+	}
+
+	events := make([]UnlockEvent, len(cycles))
+	height := start
+	for i, cycle := range cycles {
+		if height+cycle < height {
+			return nil, fmt.Errorf("%w: unlock height overflows", ErrBadModel)
+		}
+		height += cycle
+		events[i] = UnlockEvent{Height: height, Amount: quantities[i]}
+	}
+	return events, nil
+}
@@ -0,0 +1,99 @@
+package mvs_api_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"mvs_api"
+)
+
+func TestAttenuationModelString(t *testing.T) {
+	fixed := mvs_api.FixedModel(9000, 60000, 3)
+	if got, want := fixed.String(), "TYPE=1;LQ=9000;LP=60000;UN=3"; got != want {
+		t.Errorf("fixed model = %q, want %q", got, want)
+	}
+	custom := new(mvs_api.AttenuationModel).Unlock(20000, 3000).Unlock(40000, 6000)
+	if got, want := custom.String(), "TYPE=2;LQ=9000;LP=60000;UN=2;UC=20000,40000;UQ=3000,6000"; got != want {
+		t.Errorf("custom model = %q, want %q", got, want)
+	}
+	for _, m := range []*mvs_api.AttenuationModel{fixed, custom} {
+		parsed, err := mvs_api.ParseAttenuationModel(m.String())
+		if err != nil {
+			t.Errorf("ParseAttenuationModel(%q): %v", m, err)
+		} else if !reflect.DeepEqual(parsed, m) {
+			t.Errorf("ParseAttenuationModel(%q) = %+v, want %+v", m, parsed, m)
+		}
+	}
+}
+
+func TestParseAttenuationModel(t *testing.T) {
+	m, err := mvs_api.ParseAttenuationModel(" type=2; LQ=9000; LP=60000; UN=3; UC=20000, 20000, 20000; UQ=3000,3000,3000; PN=1; LH=1200000;")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &mvs_api.AttenuationModel{
+		Type:             mvs_api.CustomQuantity,
+		LockQuantity:     9000,
+		LockPeriod:       60000,
+		UnlockNumber:     3,
+		UnlockCycles:     []uint64{20000, 20000, 20000},
+		UnlockAmounts:    []mvs_api.Amount{3000, 3000, 3000},
+		CurrentPeriod:    1,
+		LatestLockHeight: 1200000,
+	}
+	if !reflect.DeepEqual(m, want) {
+		t.Errorf("parsed %+v", m)
+	}
+
+	bad := []string{
+		"",
+		"TYPE=3;LQ=9000;LP=60000;UN=3",
+		"TYPE=1;LQ=0;LP=60000;UN=3",
+		"TYPE=1;LQ=9000;LP=2;UN=3",
+		"TYPE=1;LQ=2;LP=60000;UN=3",
+		"TYPE=1;LQ=9000;LP=60000;UN=3;PN=3",
+		"TYPE=1;LQ=9000;LP=60000;UN=3;UC=20000,20000,20000;UQ=3000,3000,3000",
+		"TYPE=2;LQ=9000;LP=60000;UN=3;UC=20000,40000;UQ=3000,6000",
+		"TYPE=2;LQ=9000;LP=60000;UN=2;UC=20000,30000;UQ=3000,6000",
+		"TYPE=2;LQ=9000;LP=60000;UN=2;UC=20000,40000;UQ=3000,5000",
+		"TYPE=2;LQ=9000;LP=60000;UN=2;UC=0,60000;UQ=3000,6000",
+		"TYPE=2;LQ=9000;LP=60000;UN=2;UC=20000,40000;UQ=0,9000",
+		"TYPE=1;LQ=9000;LP=60000;UN=3;LQ=9000",
+		"TYPE=1;LQ=9000;LP=60000;UN=3;XX=1",
+		"TYPE=1;LQ=-1;LP=60000;UN=3",
+		"TYPE=1;LQ=9000;LP=60000;UN",
+		"TYPE=2;LQ=9000;LP=60000;UN=2;UC=20000,x;UQ=3000,6000",
+	}
+	for _, s := range bad {
+		if _, err := mvs_api.ParseAttenuationModel(s); !errors.Is(err, mvs_api.ErrBadModel) {
+			t.Errorf("ParseAttenuationModel(%q): err = %v, want ErrBadModel", s, err)
+		}
+	}
+}
+
+func TestAttenuationSchedule(t *testing.T) {
+	tests := []struct {
+		m    *mvs_api.AttenuationModel
+		want []mvs_api.UnlockEvent
+	}{
+		{mvs_api.FixedModel(9000, 60000, 3), []mvs_api.UnlockEvent{{1020000, 3000}, {1040000, 3000}, {1060000, 3000}}},
+		// The last period takes the remainders.
+		{mvs_api.FixedModel(10, 7, 3), []mvs_api.UnlockEvent{{1000002, 3}, {1000004, 3}, {1000007, 4}}},
+		{new(mvs_api.AttenuationModel).Unlock(20000, 3000).Unlock(40000, 6000), []mvs_api.UnlockEvent{{1020000, 3000}, {1060000, 6000}}},
+	}
+	for _, tt := range tests {
+		got, err := tt.m.Schedule(1000000)
+		if err != nil {
+			t.Errorf("%s: %v", tt.m, err)
+		} else if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: schedule = %v, want %v", tt.m, got, tt.want)
+		}
+	}
+	if _, err := mvs_api.FixedModel(9000, 60000, 3).Schedule(^uint64(0) - 10); !errors.Is(err, mvs_api.ErrBadModel) {
+		t.Errorf("overflowing schedule: err = %v", err)
+	}
+	if _, err := mvs_api.FixedModel(0, 60000, 3).Schedule(0); !errors.Is(err, mvs_api.ErrBadModel) {
+		t.Errorf("invalid model: err = %v", err)
+	}
+}
@@ -0,0 +1,229 @@
+package mvs_api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+var ErrBatchCallUsed = errors.New("mvs_api: a batched function may make only one call")
+
+// Batch sends many calls as one JSON-RPC 2.0 array. Calls are queued as
+// functions of an RPCClient, so the typed methods are used as usual;
+// nothing is sent until Send:
+//
+//	b := client.NewBatch()
+//	blocks := make([]*Block, 100)
+//	for i := range blocks {
+//		i := i
+//		b.Queue(func(c *RPCClient) (err error) {
+//			blocks[i], err = c.Getblock(strconv.Itoa(start+i), true, true)
+//			return err
+//		})
+//	}
+//	errs, err := b.Send(ctx)
+//
+// Each queued function makes one call on the client it is given. The whole
+// batch goes to a single node, also when it is created on a pool, and every
+// call gets its own request id from that node's client.
+type Batch struct {
+	client *RPCClient
+	calls  []func(c *RPCClient) error
+}
+
+type batchRequest struct {
+	index  int
+	method string
+	params interface{}
+	reply  chan batchReply
+}
+
+type batchReply struct {
+	resp *JSONRpcResp
+	err  error
+}
+
+// batchSink stands in for the network in the clients given to queued
+// functions: it hands the call over to Send and waits for its response.
+type batchSink struct {
+	index    int
+	requests chan<- *batchRequest
+	used     bool
+}
+
+func (r *RPCClient) NewBatch() *Batch {
+	return &Batch{client: r}
+}
+
+func (b *Batch) Queue(fn func(c *RPCClient) error) {
+	b.calls = append(b.calls, fn)
+}
+
+func (b *Batch) Len() int {
+	return len(b.calls)
+}
+
+// Send runs the queued functions, posts their calls in one request and
+// returns the error of each function in queue order. The second result is
+// set when the batch as a whole failed; every call then fails with it.
+func (b *Batch) Send(ctx context.Context) ([]error, error) {
+	n := len(b.calls)
+	errs := make([]error, n)
+	requests := make(chan *batchRequest)
+	done := make(chan struct{})
+	for i, fn := range b.calls {
+		c := &RPCClient{Url: b.client.Url, batch: &batchSink{index: i, requests: requests}}
+		go func(i int, fn func(*RPCClient) error, c *RPCClient) {
+			errs[i] = fn(c)
+			done <- struct{}{}
+		}(i, fn, c)
+	}
+
+	// Wait until every function has either made its call or returned
+	// without one.
+	var queued []*batchRequest
+	finished := 0
+	for waiting := n; waiting > 0; waiting-- {
+		select {
+		case req := <-requests:
+			queued = append(queued, req)
+		case <-done:
+			finished++
+		}
+	}
+	err := b.client.sendBatch(ctx, queued)
+	for ; finished < n; finished++ {
+		<-done
+	}
+	return errs, err
+}
+
+func (s *batchSink) doPost(ctx context.Context, method string, params interface{}) (*JSONRpcResp, error) {
+	if s.used {
+		return nil, ErrBatchCallUsed
+	}
+	s.used = true
+	req := &batchRequest{index: s.index, method: method, params: params, reply: make(chan batchReply, 1)}
+	s.requests <- req
+	reply := <-req.reply
+	return reply.resp, reply.err
+}
+
+func (r *RPCClient) sendBatch(ctx context.Context, queued []*batchRequest) error {
+	if len(queued) == 0 {
+		return nil
+	}
+	fail := func(err error) error {
+		for _, q := range queued {
+			q.reply <- batchReply{err: err}
+		}
+		return err
+	}
+
+	target := r
+	if r.pool != nil {
+		candidates := r.pool.candidates()
+		if len(candidates) == 0 {
+			return fail(ErrNoHealthyNode)
+		}
+		target = candidates[0].client
+	}
+	trial, err := target.admit()
+	if err != nil {
+		return fail(err)
+	}
+	defer target.done(trial)
+
+	ids := make([]uint64, len(queued))
+	jsonReqs := make([]map[string]interface{}, len(queued))
+	for k, q := range queued {
+		ids[k] = target.nextId(context.Background())
+		jsonReqs[k] = map[string]interface{}{"jsonrpc": "2.0", "method": q.method, "params": q.params, "id": ids[k]}
+	}
+	data, err := json.Marshal(jsonReqs)
+	if err != nil {
+		return fail(err)
+	}
+
+	start := time.Now()
+	status, body, err := target.post(ctx, target.Url, data)
+	if err != nil {
+		if ctx.Err() == nil {
+			err = &TransportError{Method: "batch", Err: err}
+			target.markSick(err)
+		}
+		return fail(err)
+	}
+
+	var rpcResps []*JSONRpcResp
+	err = json.Unmarshal(body, &rpcResps)
+	if err != nil {
+		// A node that rejects the batch as a whole answers with a single
+		// error object.
+		var rpcResp *JSONRpcResp
+		if json.Unmarshal(body, &rpcResp) == nil && rpcResp != nil && rpcResp.Error != nil {
+			target.markSuccess()
+			rpcResp.Error.Method = "batch"
+			return fail(rpcResp.Error)
+		}
+	} else if status/100 != 2 {
+		err = errors.New(http.StatusText(status))
+	}
+	if err != nil {
+		err = &TransportError{Method: "batch", StatusCode: status, Err: err}
+		target.markSick(err)
+		return fail(err)
+	}
+	target.markSuccess()
+
+	byId := map[string]*JSONRpcResp{}
+	duplicate := map[string]bool{}
+	for _, rpcResp := range rpcResps {
+		if rpcResp != nil {
+			key := idKey(rpcResp.Id)
+			if byId[key] != nil {
+				duplicate[key] = true
+			}
+			byId[key] = rpcResp
+		}
+	}
+	for k, q := range queued {
+		var err error
+		key := strconv.FormatUint(ids[k], 10)
+		rpcResp, ok := byId[key]
+		switch {
+		case !ok:
+			err = &TransportError{Method: q.method, Id: ids[k], StatusCode: status, Err: errors.New("no response in batch")}
+		case duplicate[key]:
+			err = &TransportError{Method: q.method, Id: ids[k], StatusCode: status, Err: errors.New("several responses in batch")}
+		case rpcResp.Error != nil:
+			rpcResp.Error.Method = q.method
+			rpcResp.Error.Id = ids[k]
+			err = rpcResp.Error
+		}
+		target.logCall(ids[k], q.method, start, err)
+		if err != nil {
+			q.reply <- batchReply{err: err}
+		} else {
+			q.reply <- batchReply{resp: rpcResp}
+		}
+	}
+	return nil
+}
+
+// idKey normalises a response id so that 7 and "7" match.
+func idKey(id *json.RawMessage) string {
+	if id == nil {
+		return ""
+	}
+	raw := bytes.TrimSpace(*id)
+	var s string
+	if isJSONString(raw) && json.Unmarshal(raw, &s) == nil {
+		return s
+	}
+	return string(raw)
+}
@@ -0,0 +1,203 @@
+package mvs_api_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+
+	"mvs_api"
+	"mvs_api/mvstest"
+)
+
+func TestBatch(t *testing.T) {
+	s := mvstest.NewServer()
+	defer s.Close()
+	s.Respond("getheight", 1270000)
+	s.Fail("getdid", &mvs_api.RPCError{Code: 7001, Message: "did symbol already exist"})
+	r := s.Client()
+
+	var height uint64
+	var balance *mvs_api.Balance
+	b := r.NewBatch()
+	b.Queue(func(c *mvs_api.RPCClient) (err error) {
+		height, err = c.Getheight("", "")
+		return err
+	})
+	b.Queue(func(c *mvs_api.RPCClient) error {
+		_, err := c.Getdid("BIAM")
+		return err
+	})
+	b.Queue(func(c *mvs_api.RPCClient) (err error) {
+		balance, err = c.Getbalance("Alice", "A123456")
+		return err
+	})
+	b.Queue(func(c *mvs_api.RPCClient) error {
+		return nil
+	})
+	errs, err := b.Send(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if errs[0] != nil || height != 1270000 {
+		t.Errorf("getheight = %d, %v", height, errs[0])
+	}
+	if !errors.Is(errs[1], mvs_api.ErrDIDRegistered) {
+		t.Errorf("getdid: err = %v, want ErrDIDRegistered", errs[1])
+	}
+	var rpcErr *mvs_api.RPCError
+	if !errors.As(errs[1], &rpcErr) || rpcErr.Method != "getdid" {
+		t.Errorf("getdid: err = %#v", errs[1])
+	}
+	if errs[2] != nil || balance == nil {
+		t.Errorf("getbalance = %v, %v", balance, errs[2])
+	}
+	if errs[3] != nil {
+		t.Errorf("no call: %v", errs[3])
+	}
+	if n := len(s.Calls("")); n != 3 {
+		t.Errorf("%d calls, want 3", n)
+	}
+}
+
+// batchNode answers a batch with the responses built by answer. A request
+// is given as the index of its call in the batch, see sendHeights, and the
+// id to answer it with.
+func batchNode(status int, answer func(index int, id uint64) []string) *mvs_api.RPCClient {
+	r, err := mvs_api.New("http://node/rpc/v2", mvs_api.WithTransport(mvs_api.TransportFunc(
+		func(ctx context.Context, url string, body []byte) (int, []byte, error) {
+			var reqs []struct {
+				Id     uint64
+				Params []interface{}
+			}
+			if err := json.Unmarshal(body, &reqs); err != nil {
+				return 0, nil, err
+			}
+			// Answer in reverse order.
+			var resps []string
+			for i := len(reqs) - 1; i >= 0; i-- {
+				index, _ := strconv.Atoi(reqs[i].Params[0].(string))
+				resps = append(resps, answer(index, reqs[i].Id)...)
+			}
+			return status, []byte("[" + strings.Join(resps, ",") + "]"), nil
+		})))
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+func result(id uint64, v interface{}) string {
+	return fmt.Sprintf(`{"jsonrpc":"2.0","id":%d,"result":%v}`, id, v)
+}
+
+// sendHeights batches n Getheight calls, passing each its index as
+// ADMINNAME.
+func sendHeights(t *testing.T, r *mvs_api.RPCClient, n int) ([]uint64, []error, error) {
+	t.Helper()
+	heights := make([]uint64, n)
+	b := r.NewBatch()
+	for i := range heights {
+		i := i
+		b.Queue(func(c *mvs_api.RPCClient) (err error) {
+			heights[i], err = c.Getheight(strconv.Itoa(i), "")
+			return err
+		})
+	}
+	errs, err := b.Send(context.Background())
+	return heights, errs, err
+}
+
+func TestBatchOutOfOrder(t *testing.T) {
+	r := batchNode(200, func(index int, id uint64) []string {
+		return []string{result(id, 100+index)}
+	})
+	heights, errs, err := sendHeights(t, r, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, h := range heights {
+		if errs[i] != nil || h != uint64(100+i) {
+			t.Errorf("call %d = %d, %v; want %d", i, h, errs[i], 100+i)
+		}
+	}
+}
+
+func TestBatchMissingAndDuplicateIds(t *testing.T) {
+	r := batchNode(200, func(index int, id uint64) []string {
+		switch index {
+		case 0:
+			return nil
+		case 1:
+			return []string{result(id, 1), result(id, 2)}
+		}
+		return []string{result(id, 3), result(999, 4)}
+	})
+	heights, errs, err := sendHeights(t, r, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(fmt.Sprint(errs[0]), "no response") {
+		t.Errorf("missing id: err = %v", errs[0])
+	}
+	if !strings.Contains(fmt.Sprint(errs[1]), "several responses") || heights[1] != 0 {
+		t.Errorf("duplicate id: %d, %v", heights[1], errs[1])
+	}
+	if errs[2] != nil || heights[2] != 3 {
+		t.Errorf("call 2 = %d, %v", heights[2], errs[2])
+	}
+	for _, err := range errs[:2] {
+		if mvs_api.Classify(err) != mvs_api.ErrorClassServer {
+			t.Errorf("class of %v = %v, want server", err, mvs_api.Classify(err))
+		}
+	}
+}
+
+func TestBatchHTTPError(t *testing.T) {
+	r := batchNode(500, func(index int, id uint64) []string {
+		return []string{result(id, 1)}
+	})
+	_, errs, err := sendHeights(t, r, 2)
+	var transportErr *mvs_api.TransportError
+	if !errors.As(err, &transportErr) || transportErr.StatusCode != 500 {
+		t.Fatalf("err = %v, want a status 500 TransportError", err)
+	}
+	for i, e := range errs {
+		if e != err {
+			t.Errorf("call %d: err = %v", i, e)
+		}
+	}
+}
+
+func TestBatchRejected(t *testing.T) {
+	r, _ := mvs_api.New("http://node/rpc/v2", mvs_api.WithTransport(mvs_api.TransportFunc(
+		func(ctx context.Context, url string, body []byte) (int, []byte, error) {
+			return 200, []byte(`{"jsonrpc":"2.0","id":null,"error":{"code":-32600,"message":"batch not supported"}}`), nil
+		})))
+	_, errs, err := sendHeights(t, r, 2)
+	var rpcErr *mvs_api.RPCError
+	if !errors.As(err, &rpcErr) || rpcErr.Code != -32600 || errs[0] != err {
+		t.Errorf("err = %v, errs = %v", err, errs)
+	}
+	if !r.Healthy() {
+		t.Error("a rejected batch counted against the node")
+	}
+}
+
+func TestBatchOneCallPerFunction(t *testing.T) {
+	s := mvstest.NewServer()
+	defer s.Close()
+	b := s.Client().NewBatch()
+	b.Queue(func(c *mvs_api.RPCClient) error {
+		c.Getheight("", "")
+		_, err := c.Getheight("", "")
+		return err
+	})
+	errs, err := b.Send(context.Background())
+	if err != nil || !errors.Is(errs[0], mvs_api.ErrBatchCallUsed) {
+		t.Errorf("second call: %v, %v", errs, err)
+	}
+}
@@ -0,0 +1,345 @@
+package mvs_api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ErrNotRecorded is returned by ReplayTransport for a request the cassette
+// has no answer for.
+var ErrNotRecorded = errors.New("mvs_api: request not recorded")
+
+// Redacted replaces passwords, mnemonic words and key files in a cassette.
+const Redacted = "REDACTED"
+
+// adminMethods take ADMINNAME and ADMINAUTH; the value is the position of
+// ADMINAUTH.
+var adminMethods = map[string]int{
+	"addnode":       2,
+	"getheight":     1,
+	"getinfo":       1,
+	"getmemorypool": 1,
+	"getmininginfo": 1,
+	"getpeerinfo":   1,
+	"getwork":       1,
+	"shutdown":      1,
+	"stopmining":    1,
+}
+
+// lastWordMethods take the last mnemonic word after ACCOUNTAUTH.
+var lastWordMethods = map[string]bool{
+	"deleteaccount": true,
+	"dumpkeyfile":   true,
+	"getaccount":    true,
+}
+
+// Interaction is one recorded exchange. Request and Response are the JSON
+// bodies with secrets redacted; Error is set instead of Response when the
+// transport failed.
+type Interaction struct {
+	Request  json.RawMessage `json:"request"`
+	Status   int             `json:"status,omitempty"`
+	Response json.RawMessage `json:"response,omitempty"`
+	Error    string          `json:"error,omitempty"`
+}
+
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+func LoadCassette(path string) (*Cassette, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	c := &Cassette{}
+	if err := json.Unmarshal(data, c); err != nil {
+		return nil, fmt.Errorf("mvs_api: cassette %s: %v", path, err)
+	}
+	return c, nil
+}
+
+func (c *Cassette) Save(path string) error {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, append(data, '\n'), 0644)
+}
+
+// RecordingTransport passes requests on to Transport and keeps every
+// exchange in Cassette, with ACCOUNTAUTH, ADMINAUTH, passwords, mnemonic
+// words and key file contents redacted:
+//
+//	rec := &RecordingTransport{Transport: &HTTPTransport{}}
+//	client, _ := New("http://127.0.0.1:8820/rpc/v2", WithTransport(rec))
+//	...
+//	rec.Cassette.Save("testdata/session.json")
+type RecordingTransport struct {
+	Transport Transport
+	mu        sync.Mutex
+	Cassette  Cassette
+}
+
+func (t *RecordingTransport) Post(ctx context.Context, url string, body []byte) (int, []byte, error) {
+	status, resp, err := t.Transport.Post(ctx, url, body)
+	interaction := Interaction{Request: redactRequest(body), Status: status}
+	if err != nil {
+		interaction.Error = err.Error()
+	} else {
+		interaction.Response = redactResponse(body, resp)
+	}
+	t.mu.Lock()
+	t.Cassette.Interactions = append(t.Cassette.Interactions, interaction)
+	t.mu.Unlock()
+	return status, resp, err
+}
+
+// ReplayTransport answers from a cassette. A request matches an interaction
+// by method and params, secrets redacted on both sides, so the passwords
+// used in replay do not matter. Identical requests get the recorded answers
+// in turn, the last one repeating; the response ids are rewritten to those
+// of the request.
+type ReplayTransport struct {
+	mu           sync.Mutex
+	interactions []Interaction
+	keys         []string
+	used         []bool
+}
+
+func NewReplayTransport(c *Cassette) *ReplayTransport {
+	t := &ReplayTransport{
+		interactions: c.Interactions,
+		keys:         make([]string, len(c.Interactions)),
+		used:         make([]bool, len(c.Interactions)),
+	}
+	for i, interaction := range c.Interactions {
+		t.keys[i] = matchKey(interaction.Request)
+	}
+	return t
+}
+
+func (t *ReplayTransport) Post(ctx context.Context, url string, body []byte) (int, []byte, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, nil, err
+	}
+	key := matchKey(redactRequest(body))
+	t.mu.Lock()
+	found := -1
+	for i := range t.interactions {
+		if t.keys[i] != key {
+			continue
+		}
+		found = i
+		if !t.used[i] {
+			break
+		}
+	}
+	if found >= 0 {
+		t.used[found] = true
+	}
+	t.mu.Unlock()
+
+	if found < 0 {
+		return 0, nil, fmt.Errorf("%w: %s", ErrNotRecorded, key)
+	}
+	interaction := t.interactions[found]
+	if interaction.Error != "" {
+		return interaction.Status, nil, errors.New(interaction.Error)
+	}
+	return interaction.Status, replaceIds(interaction.Request, body, interaction.Response), nil
+}
+
+// rpcMessage is a request or response object of a body, single or batch.
+type rpcMessage map[string]interface{}
+
+func splitBody(body []byte) (messages []rpcMessage, batch bool, err error) {
+	d := json.NewDecoder(bytes.NewReader(body))
+	d.UseNumber()
+	body = bytes.TrimSpace(body)
+	if len(body) > 0 && body[0] == '[' {
+		err = d.Decode(&messages)
+		return messages, true, err
+	}
+	var m rpcMessage
+	err = d.Decode(&m)
+	return []rpcMessage{m}, false, err
+}
+
+func joinBody(messages []rpcMessage, batch bool) []byte {
+	var data []byte
+	if batch {
+		data, _ = json.Marshal(messages)
+	} else {
+		data, _ = json.Marshal(messages[0])
+	}
+	return data
+}
+
+func redactRequest(body []byte) json.RawMessage {
+	messages, batch, err := splitBody(body)
+	if err != nil {
+		return append(json.RawMessage(nil), body...)
+	}
+	for _, m := range messages {
+		method, _ := m["method"].(string)
+		params, _ := m["params"].([]interface{})
+		redactParams(method, params)
+	}
+	return joinBody(messages, batch)
+}
+
+func redactParams(method string, params []interface{}) {
+	hide := func(i int) {
+		if i < len(params) {
+			if _, ok := params[i].(string); ok {
+				params[i] = Redacted
+			}
+		}
+	}
+	if accountMethods[method] {
+		hide(1)
+	}
+	if i, ok := adminMethods[method]; ok {
+		hide(i)
+	}
+	if lastWordMethods[method] {
+		hide(2)
+	}
+	if method == "importaccount" {
+		hide(0)
+	}
+	if method == "importkeyfile" {
+		hide(3)
+	}
+	if len(params) > 0 {
+		if optional, ok := params[len(params)-1].(map[string]interface{}); ok {
+			if _, ok := optional["password"]; ok {
+				optional["password"] = Redacted
+			}
+		}
+	}
+}
+
+// redactResponse redacts the mnemonic words in the results of body and
+// the key files dumpkeyfile returned for request.
+func redactResponse(request, body []byte) json.RawMessage {
+	resps, batch, err := splitBody(body)
+	if err != nil {
+		return append(json.RawMessage(nil), body...)
+	}
+	methods := map[string]string{}
+	if reqs, _, err := splitBody(request); err == nil {
+		for _, req := range reqs {
+			method, _ := req["method"].(string)
+			methods[fmt.Sprint(req["id"])] = method
+		}
+	}
+	for _, resp := range resps {
+		if methods[fmt.Sprint(resp["id"])] == "dumpkeyfile" {
+			// With data=true the result is the key file itself rather
+			// than the path it was written to.
+			if _, ok := resp["result"].(string); !ok {
+				redactStrings(resp["result"])
+			}
+		}
+		redactMnemonic(map[string]interface{}(resp))
+	}
+	return joinBody(resps, batch)
+}
+
+// redactStrings replaces every string in v, keeping its shape.
+func redactStrings(v interface{}) {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			if _, ok := value.(string); ok {
+				v[key] = Redacted
+			} else {
+				redactStrings(value)
+			}
+		}
+	case []interface{}:
+		for i, value := range v {
+			if _, ok := value.(string); ok {
+				v[i] = Redacted
+			} else {
+				redactStrings(value)
+			}
+		}
+	}
+}
+
+func redactMnemonic(v interface{}) {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			if _, ok := value.(string); ok && key == "mnemonic" {
+				v[key] = Redacted
+			} else {
+				redactMnemonic(value)
+			}
+		}
+	case []interface{}:
+		for _, value := range v {
+			redactMnemonic(value)
+		}
+	}
+}
+
+// matchKey identifies a redacted request by the methods and params it
+// carries, leaving out ids. The calls of a batch may come in any order.
+func matchKey(request []byte) string {
+	messages, _, err := splitBody(request)
+	if err != nil {
+		return string(request)
+	}
+	calls := make([]string, len(messages))
+	for i, m := range messages {
+		calls[i] = callKey(m)
+	}
+	sort.Strings(calls)
+	return strings.Join(calls, ",")
+}
+
+func callKey(m rpcMessage) string {
+	data, _ := json.Marshal([]interface{}{m["method"], m["params"]})
+	return string(data)
+}
+
+// replaceIds gives the recorded response the ids of the live request,
+// pairing the recorded and live requests by their calls.
+func replaceIds(recorded, live []byte, response []byte) []byte {
+	recordedReqs, _, err1 := splitBody(recorded)
+	liveReqs, _, err2 := splitBody(redactRequest(live))
+	resps, batch, err3 := splitBody(response)
+	if err1 != nil || err2 != nil || err3 != nil {
+		return response
+	}
+	liveIds := map[string][]interface{}{}
+	for _, req := range liveReqs {
+		key := callKey(req)
+		liveIds[key] = append(liveIds[key], req["id"])
+	}
+	ids := map[string]interface{}{}
+	for _, req := range recordedReqs {
+		key := callKey(req)
+		if len(liveIds[key]) > 0 {
+			ids[fmt.Sprint(req["id"])] = liveIds[key][0]
+			liveIds[key] = liveIds[key][1:]
+		}
+	}
+	for _, resp := range resps {
+		if id, ok := ids[fmt.Sprint(resp["id"])]; ok && resp["id"] != nil {
+			resp["id"] = id
+		}
+	}
+	return joinBody(resps, batch)
+}
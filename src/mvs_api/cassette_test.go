@@ -0,0 +1,82 @@
+package mvs_api_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"mvs_api"
+	"mvs_api/mvstest"
+)
+
+const (
+	password = "A123456"
+	lastWord = "robot"
+	mnemonic = "notice judge certain company novel quality plunge list blind library ride uncover fold wink biology original aim whale stand coach hire clinic fame robot"
+	keyFile  = `{"algo":"aes","index":1,"mnemonic":"c2VjcmV0IGtleSBmaWxlIGNvbnRlbnQ=","multisigs":[],"name":"Alice"}`
+)
+
+func TestCassetteRedacts(t *testing.T) {
+	s := mvstest.NewServer()
+	defer s.Close()
+	s.Respond("dumpkeyfile", json.RawMessage(keyFile))
+	rec := &mvs_api.RecordingTransport{Transport: &mvs_api.HTTPTransport{}}
+	c := s.Client(mvs_api.WithTransport(rec))
+
+	if _, err := c.Getaccount("Alice", password, lastWord); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Importaccount(strings.Fields(mnemonic), "", "Alice", password, 1); err != nil {
+		t.Fatal(err)
+	}
+	kf, err := c.Dumpkeyfile("Alice", password, lastWord, "", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(kf.Content) == 0 {
+		t.Fatal("dumpkeyfile returned no key file")
+	}
+	if _, err := c.Importkeyfile("Alice", password, "", keyFile); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Getinfo("admin", "secret-admin"); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := json.Marshal(rec.Cassette)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range []string{password, "robot", "notice", "c2VjcmV0", "secret-admin"} {
+		if strings.Contains(string(data), secret) {
+			t.Errorf("cassette contains %q:\n%s", secret, data)
+		}
+	}
+
+	// The redacted cassette still answers a replay with other passwords.
+	replay := s.Client(mvs_api.WithTransport(mvs_api.NewReplayTransport(&rec.Cassette)))
+	s.Close()
+	kf, err = replay.Dumpkeyfile("Alice", "other", "word", "", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(kf.Content) == 0 || kf.Path != "" {
+		t.Errorf("replayed key file = %+v", kf)
+	}
+	if _, err := replay.Importkeyfile("Alice", "other", "", "{}"); err != nil {
+		t.Errorf("replay importkeyfile: %v", err)
+	}
+}
+
+func TestCassetteKeepsKeyFilePath(t *testing.T) {
+	s := mvstest.NewServer()
+	defer s.Close()
+	rec := &mvs_api.RecordingTransport{Transport: &mvs_api.HTTPTransport{}}
+	if _, err := s.Client(mvs_api.WithTransport(rec)).Dumpkeyfile("Alice", password, lastWord, "", false); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := json.Marshal(rec.Cassette)
+	if !strings.Contains(string(data), "mvs_keystore_Alice.json") {
+		t.Errorf("key file path was redacted:\n%s", data)
+	}
+}
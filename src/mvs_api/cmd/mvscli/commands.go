@@ -0,0 +1,865 @@
+// Code generated by mvsgen from commands.json; DO NOT EDIT.
+
+package main
+
+import (
+	"context"
+
+	"mvs_api"
+)
+
+var commands = []*command{
+	{
+		name: "didchangeaddress",
+		params: []param{
+			{name: "ACCOUNTNAME", wire: "ACCOUNTNAME", typ: "string", kind: "positional", doc: "Account name required."},
+			{name: "ACCOUNTAUTH", wire: "ACCOUNTAUTH", typ: "string", kind: "positional", doc: "Account password(authorization) required."},
+			{name: "TOADDRESS", wire: "TOADDRESS", typ: "string", kind: "positional", doc: "Target address"},
+			{name: "DIDSYMBOL", wire: "DIDSYMBOL", typ: "string", kind: "positional", doc: "Did symbol"},
+			{name: "fee", wire: "fee", typ: "Amount", kind: "optional", doc: "Transaction fee. defaults to 10000 ETP bits"},
+		},
+		run: func(ctx context.Context, r *mvs_api.RPCClient, v values) (interface{}, error) {
+			return r.DidchangeaddressContext(ctx, v["ACCOUNTNAME"].(string), v["ACCOUNTAUTH"].(string), v["TOADDRESS"].(string), v["DIDSYMBOL"].(string), v["fee"].(mvs_api.Amount))
+		},
+	},
+	{
+		name: "signmultisigtx",
+		params: []param{
+			{name: "ACCOUNTNAME", wire: "ACCOUNTNAME", typ: "string", kind: "positional", doc: "Account name required."},
+			{name: "ACCOUNTAUTH", wire: "ACCOUNTAUTH", typ: "string", kind: "positional", doc: "Account password(authorization) required."},
+			{name: "TRANSACTION", wire: "TRANSACTION", typ: "string", kind: "positional", doc: "The input Base16 transaction to sign."},
+			{name: "selfpublickey", wire: "selfpublickey", typ: "string", kind: "optional", doc: "The private key of this public key will be used to sign."},
+			{name: "broadcast", wire: "broadcast", typ: "bool", kind: "flag", doc: "Broadcast the tx if it is fullly signed, disabled by default."},
+		},
+		run: func(ctx context.Context, r *mvs_api.RPCClient, v values) (interface{}, error) {
+			return r.SignmultisigtxContext(ctx, v["ACCOUNTNAME"].(string), v["ACCOUNTAUTH"].(string), v["TRANSACTION"].(string), v["selfpublickey"].(string), v["broadcast"].(bool))
+		},
+	},
+	{
+		name: "registerdid",
+		params: []param{
+			{name: "ACCOUNTNAME", wire: "ACCOUNTNAME", typ: "string", kind: "positional", doc: "Account name required."},
+			{name: "ACCOUNTAUTH", wire: "ACCOUNTAUTH", typ: "string", kind: "positional", doc: "Account password(authorization) required."},
+			{name: "ADDRESS", wire: "ADDRESS", typ: "string", kind: "positional", doc: "The address will be bound to, can change to other addresses later."},
+			{name: "SYMBOL", wire: "SYMBOL", typ: "string", kind: "positional", doc: "The symbol of global unique MVS Digital Identity Destination/Index, supports alphabets/numbers/(“@”, “.”, “_”, “-“), case-sensitive, maximum length is 64."},
+			{name: "fee", wire: "fee", typ: "Amount", kind: "optional", doc: "The fee of tx. defaults to 1 etp."},
+		},
+		run: func(ctx context.Context, r *mvs_api.RPCClient, v values) (interface{}, error) {
+			return r.RegisterdidContext(ctx, v["ACCOUNTNAME"].(string), v["ACCOUNTAUTH"].(string), v["ADDRESS"].(string), v["SYMBOL"].(string), v["fee"].(mvs_api.Amount))
+		},
+	},
+	{
+		name: "issue",
+		params: []param{
+			{name: "ACCOUNTNAME", wire: "ACCOUNTNAME", typ: "string", kind: "positional", doc: "Account name required."},
+			{name: "ACCOUNTAUTH", wire: "ACCOUNTAUTH", typ: "string", kind: "positional", doc: "Account password(authorization) required."},
+			{name: "SYMBOL", wire: "SYMBOL", typ: "string", kind: "positional", doc: "The asset symbol, global uniqueness, only supports UPPER-CASE alphabet and dot(.)"},
+			{name: "model", wire: "model", typ: "string", kind: "optional", doc: "The token offering model by block height."},
+			{name: "fee", wire: "fee", typ: "Amount", kind: "optional", doc: "The fee of tx. minimum is 10 etp."},
+		},
+		run: func(ctx context.Context, r *mvs_api.RPCClient, v values) (interface{}, error) {
+			return r.IssueContext(ctx, v["ACCOUNTNAME"].(string), v["ACCOUNTAUTH"].(string), v["SYMBOL"].(string), v["model"].(string), v["fee"].(mvs_api.Amount))
+		},
+	},
+	{
+		name: "importaccount",
+		params: []param{
+			{name: "WORD", wire: "WORD", typ: "[]string", kind: "positional", doc: "The set of words that that make up the mnemonic. If not specified the words are read from STDIN."},
+			{name: "language", wire: "language", typ: "string", kind: "optional", doc: "The language identifier of the dictionary of the mnemonic. Options are 'en', 'es', 'ja', 'zh_Hans', 'zh_Hant' and 'any', defaults to 'any'."},
+			{name: "accountname", wire: "accountname", typ: "string", kind: "required", doc: "Account name required."},
+			{name: "password", wire: "password", typ: "string", kind: "required", doc: "Account password(authorization) required."},
+			{name: "hd_index", wire: "hd_index", typ: "uint32", kind: "optional", doc: "The HD index for the account."},
+		},
+		run: func(ctx context.Context, r *mvs_api.RPCClient, v values) (interface{}, error) {
+			return r.ImportaccountContext(ctx, v["WORD"].([]string), v["language"].(string), v["accountname"].(string), v["password"].(string), v["hd_index"].(uint32))
+		},
+	},
+	{
+		name: "stopmining",
+		params: []param{
+			{name: "ADMINNAME", wire: "ADMINNAME", typ: "string", kind: "positional", doc: "Administrator required.(when administrator_required in mvs.conf is set true)"},
+			{name: "ADMINAUTH", wire: "ADMINAUTH", typ: "string", kind: "positional", doc: "Administrator password required."},
+		},
+		run: func(ctx context.Context, r *mvs_api.RPCClient, v values) (interface{}, error) {
+			return r.StopminingContext(ctx, v["ADMINNAME"].(string), v["ADMINAUTH"].(string))
+		},
+	},
+	{
+		name: "createmultisigtx",
+		params: []param{
+			{name: "ACCOUNTNAME", wire: "ACCOUNTNAME", typ: "string", kind: "positional", doc: "Account name required."},
+			{name: "ACCOUNTAUTH", wire: "ACCOUNTAUTH", typ: "string", kind: "positional", doc: "Account password(authorization) required."},
+			{name: "FROMADDRESS", wire: "FROMADDRESS", typ: "string", kind: "positional", doc: "Send from this address, must be a multi-signature script address."},
+			{name: "TOADDRESS", wire: "TOADDRESS", typ: "string", kind: "positional", doc: "Send to this address"},
+			{name: "AMOUNT", wire: "AMOUNT", typ: "Amount", kind: "positional", doc: "ETP integer bits."},
+			{name: "symbol", wire: "symbol", typ: "string", kind: "optional", doc: "asset name, not specify this option for etp tx"},
+			{name: "type_", wire: "type", typ: "uint16", kind: "optional", doc: "Transaction type, defaults to 0. 0 -- transfer etp, 3 -- transfer asset"},
+			{name: "fee", wire: "fee", typ: "Amount", kind: "optional", doc: "Transaction fee. defaults to 10000 ETP bits"},
+		},
+		run: func(ctx context.Context, r *mvs_api.RPCClient, v values) (interface{}, error) {
+			return r.CreatemultisigtxContext(ctx, v["ACCOUNTNAME"].(string), v["ACCOUNTAUTH"].(string), v["FROMADDRESS"].(string), v["TOADDRESS"].(string), v["AMOUNT"].(mvs_api.Amount), v["symbol"].(string), v["type_"].(uint16), v["fee"].(mvs_api.Amount))
+		},
+	},
+	{
+		name: "getpublickey",
+		params: []param{
+			{name: "ACCOUNTNAME", wire: "ACCOUNTNAME", typ: "string", kind: "positional", doc: "Account name required."},
+			{name: "ACCOUNTAUTH", wire: "ACCOUNTAUTH", typ: "string", kind: "positional", doc: "Account password(authorization) required."},
+			{name: "ADDRESS", wire: "ADDRESS", typ: "string", kind: "positional", doc: "Address."},
+		},
+		run: func(ctx context.Context, r *mvs_api.RPCClient, v values) (interface{}, error) {
+			return r.GetpublickeyContext(ctx, v["ACCOUNTNAME"].(string), v["ACCOUNTAUTH"].(string), v["ADDRESS"].(string))
+		},
+	},
+	{
+		name: "deposit",
+		params: []param{
+			{name: "ACCOUNTNAME", wire: "ACCOUNTNAME", typ: "string", kind: "positional", doc: "Account name required."},
+			{name: "ACCOUNTAUTH", wire: "ACCOUNTAUTH", typ: "string", kind: "positional", doc: "Account password(authorization) required."},
+			{name: "AMOUNT", wire: "AMOUNT", typ: "Amount", kind: "positional", doc: "ETP integer bits."},
+			{name: "address", wire: "address", typ: "string", kind: "optional", doc: "The deposit target address."},
+			{name: "deposit", wire: "deposit", typ: "uint16", kind: "optional", doc: "Deposits support [7, 30, 90, 182, 365] days. defaluts to 7 days"},
+			{name: "fee", wire: "fee", typ: "Amount", kind: "optional", doc: "Transaction fee. defaults to 10000 ETP bits"},
+		},
+		run: func(ctx context.Context, r *mvs_api.RPCClient, v values) (interface{}, error) {
+			return r.DepositContext(ctx, v["ACCOUNTNAME"].(string), v["ACCOUNTAUTH"].(string), v["AMOUNT"].(mvs_api.Amount), v["address"].(string), v["deposit"].(uint16), v["fee"].(mvs_api.Amount))
+		},
+	},
+	{
+		name: "getaccountasset",
+		params: []param{
+			{name: "ACCOUNTNAME", wire: "ACCOUNTNAME", typ: "string", kind: "positional", doc: "Account name required."},
+			{name: "ACCOUNTAUTH", wire: "ACCOUNTAUTH", typ: "string", kind: "positional", doc: "Account password(authorization) required."},
+			{name: "SYMBOL", wire: "SYMBOL", typ: "string", kind: "positional", doc: "Asset symbol."},
+			{name: "cert", wire: "cert", typ: "bool", kind: "flag", doc: "If specified, then only get related asset cert. Default is not specified."},
+		},
+		run: func(ctx context.Context, r *mvs_api.RPCClient, v values) (interface{}, error) {
+			return r.GetaccountassetContext(ctx, v["ACCOUNTNAME"].(string), v["ACCOUNTAUTH"].(string), v["SYMBOL"].(string), v["cert"].(bool))
+		},
+	},
+	{
+		name: "didsendasset",
+		params: []param{
+			{name: "ACCOUNTNAME", wire: "ACCOUNTNAME", typ: "string", kind: "positional", doc: "Account name required."},
+			{name: "ACCOUNTAUTH", wire: "ACCOUNTAUTH", typ: "string", kind: "positional", doc: "Account password(authorization) required."},
+			{name: "TO_", wire: "TO_", typ: "string", kind: "positional", doc: "Asset receiver did/address."},
+			{name: "ASSET", wire: "ASSET", typ: "string", kind: "positional", doc: "Asset MST symbol."},
+			{name: "AMOUNT", wire: "AMOUNT", typ: "Amount", kind: "positional", doc: "Asset integer bits. see asset <decimal_number>."},
+			{name: "model", wire: "model", typ: "string", kind: "optional", doc: "The token offering model by block height."},
+			{name: "fee", wire: "fee", typ: "Amount", kind: "optional", doc: "Transaction fee. defaults to 10000 ETP bits"},
+		},
+		run: func(ctx context.Context, r *mvs_api.RPCClient, v values) (interface{}, error) {
+			return r.DidsendassetContext(ctx, v["ACCOUNTNAME"].(string), v["ACCOUNTAUTH"].(string), v["TO_"].(string), v["ASSET"].(string), v["AMOUNT"].(mvs_api.Amount), v["model"].(string), v["fee"].(mvs_api.Amount))
+		},
+	},
+	{
+		name: "burn",
+		params: []param{
+			{name: "ACCOUNTNAME", wire: "ACCOUNTNAME", typ: "string", kind: "positional", doc: "Account name required."},
+			{name: "ACCOUNTAUTH", wire: "ACCOUNTAUTH", typ: "string", kind: "positional", doc: "Account password(authorization) required."},
+			{name: "SYMBOL", wire: "SYMBOL", typ: "string", kind: "positional", doc: "The asset will be burned."},
+			{name: "AMOUNT", wire: "AMOUNT", typ: "Amount", kind: "positional", doc: "Asset integer bits. see asset <decimal_number>."},
+		},
+		run: func(ctx context.Context, r *mvs_api.RPCClient, v values) (interface{}, error) {
+			return r.BurnContext(ctx, v["ACCOUNTNAME"].(string), v["ACCOUNTAUTH"].(string), v["SYMBOL"].(string), v["AMOUNT"].(mvs_api.Amount))
+		},
+	},
+	{
+		name: "popblock",
+		params: []param{
+			{name: "height", wire: "height", typ: "uint32", kind: "positional", doc: "specify the starting point to pop out blocks. eg, if specified 1000000, then all blocks with height greater than or equal to 1000000 will be poped out."},
+		},
+		run: func(ctx context.Context, r *mvs_api.RPCClient, v values) (interface{}, error) {
+			return r.PopblockContext(ctx, v["height"].(uint32))
+		},
+	},
+	{
+		name: "listbalances",
+		params: []param{
+			{name: "nozero", wire: "nozero", typ: "bool", kind: "flag", doc: "Defaults to false."},
+			{name: "greater_equal", wire: "greater_equal", typ: "Amount", kind: "optional", doc: "Greater than ETP bits."},
+			{name: "lesser_equal", wire: "lesser_equal", typ: "Amount", kind: "optional", doc: "Lesser than ETP bits."},
+			{name: "ACCOUNTNAME", wire: "ACCOUNTNAME", typ: "string", kind: "positional", doc: "Account name required."},
+			{name: "ACCOUNTAUTH", wire: "ACCOUNTAUTH", typ: "string", kind: "positional", doc: "Account password(authorization) required."},
+		},
+		run: func(ctx context.Context, r *mvs_api.RPCClient, v values) (interface{}, error) {
+			return r.ListbalancesContext(ctx, v["nozero"].(bool), v["greater_equal"].(mvs_api.Amount), v["lesser_equal"].(mvs_api.Amount), v["ACCOUNTNAME"].(string), v["ACCOUNTAUTH"].(string))
+		},
+	},
+	{
+		name: "createasset",
+		params: []param{
+			{name: "ACCOUNTNAME", wire: "ACCOUNTNAME", typ: "string", kind: "positional", doc: "Account name required."},
+			{name: "ACCOUNTAUTH", wire: "ACCOUNTAUTH", typ: "string", kind: "positional", doc: "Account password(authorization) required."},
+			{name: "rate", wire: "rate", typ: "int32", kind: "optional", doc: "The percent threshold value when you secondary issue.              0,  not allowed to secondary issue;              -1,  the asset can be secondary issue freely;             [1, 100], the asset can be secondary issue when own percentage greater than or equal to this value.             Defaults to 0."},
+			{name: "symbol", wire: "symbol", typ: "string", kind: "required", doc: "The asset symbol, global uniqueness, only supports UPPER-CASE alphabet and dot(.), eg: CHENHAO.LAPTOP, dot separates prefix 'CHENHAO', It's impossible to create any asset named with 'CHENHAO' prefix, but this issuer."},
+			{name: "issuer", wire: "issuer", typ: "string", kind: "required", doc: "Issue must be specified as a DID symbol."},
+			{name: "volume", wire: "volume", typ: "Amount", kind: "required", doc: "The asset maximum supply volume, with unit of integer bits."},
+			{name: "decimalnumber", wire: "decimalnumber", typ: "uint32", kind: "optional", doc: "The asset amount decimal number, defaults to 0."},
+			{name: "description", wire: "description", typ: "string", kind: "optional", doc: "The asset data chuck, defaults to empty string."},
+		},
+		run: func(ctx context.Context, r *mvs_api.RPCClient, v values) (interface{}, error) {
+			return r.CreateassetContext(ctx, v["ACCOUNTNAME"].(string), v["ACCOUNTAUTH"].(string), v["rate"].(int32), v["symbol"].(string), v["issuer"].(string), v["volume"].(mvs_api.Amount), v["decimalnumber"].(uint32), v["description"].(string))
+		},
+	},
+	{
+		name: "send",
+		params: []param{
+			{name: "ACCOUNTNAME", wire: "ACCOUNTNAME", typ: "string", kind: "positional", doc: "Account name required."},
+			{name: "ACCOUNTAUTH", wire: "ACCOUNTAUTH", typ: "string", kind: "positional", doc: "Account password(authorization) required."},
+			{name: "TOADDRESS", wire: "TOADDRESS", typ: "string", kind: "positional", doc: "Send to this address"},
+			{name: "AMOUNT", wire: "AMOUNT", typ: "Amount", kind: "positional", doc: "ETP integer bits."},
+			{name: "memo", wire: "memo", typ: "string", kind: "optional", doc: "Attached memo for this transaction."},
+			{name: "fee", wire: "fee", typ: "Amount", kind: "optional", doc: "Transaction fee. defaults to 10000 etp bits"},
+		},
+		run: func(ctx context.Context, r *mvs_api.RPCClient, v values) (interface{}, error) {
+			return r.SendContext(ctx, v["ACCOUNTNAME"].(string), v["ACCOUNTAUTH"].(string), v["TOADDRESS"].(string), v["AMOUNT"].(mvs_api.Amount), v["memo"].(string), v["fee"].(mvs_api.Amount))
+		},
+	},
+	{
+		name: "changepasswd",
+		params: []param{
+			{name: "ACCOUNTNAME", wire: "ACCOUNTNAME", typ: "string", kind: "positional", doc: "Account name required."},
+			{name: "ACCOUNTAUTH", wire: "ACCOUNTAUTH", typ: "string", kind: "positional", doc: "Account password(authorization) required."},
+			{name: "password", wire: "password", typ: "string", kind: "required", doc: "The new password."},
+		},
+		run: func(ctx context.Context, r *mvs_api.RPCClient, v values) (interface{}, error) {
+			return r.ChangepasswdContext(ctx, v["ACCOUNTNAME"].(string), v["ACCOUNTAUTH"].(string), v["password"].(string))
+		},
+	},
+	{
+		name: "createrawtx",
+		params: []param{
+			{name: "type_", wire: "type", typ: "uint16", kind: "required", doc: "Transaction type. 0 -- transfer etp, 1 -- deposit etp, 3 -- transfer asset"},
+			{name: "senders", wire: "senders", typ: "[]string", kind: "required", doc: "Send from addresses"},
+			{name: "receivers", wire: "receivers", typ: "Receivers", kind: "required", doc: "Send to [address:amount]. amount is asset number if sybol option specified"},
+			{name: "symbol", wire: "symbol", typ: "string", kind: "optional", doc: "asset name, not specify this option for etp tx"},
+			{name: "deposit", wire: "deposit", typ: "uint16", kind: "optional", doc: "Deposits support [7, 30, 90, 182, 365] days. defaluts to 7 days"},
+			{name: "mychange", wire: "mychange", typ: "string", kind: "optional", doc: "Mychange to this address, includes etp and asset change"},
+			{name: "message", wire: "message", typ: "string", kind: "optional", doc: "Message/Information attached to this transaction"},
+			{name: "fee", wire: "fee", typ: "Amount", kind: "optional", doc: "Transaction fee. defaults to 10000 ETP bits"},
+		},
+		run: func(ctx context.Context, r *mvs_api.RPCClient, v values) (interface{}, error) {
+			return r.CreaterawtxContext(ctx, v["type_"].(uint16), v["senders"].([]string), v["receivers"].(mvs_api.Receivers), v["symbol"].(string), v["deposit"].(uint16), v["mychange"].(string), v["message"].(string), v["fee"].(mvs_api.Amount))
+		},
+	},
+	{
+		name: "validateaddress",
+		params: []param{
+			{name: "PAYMENT_ADDRESS", wire: "PAYMENT_ADDRESS", typ: "string", kind: "positional", doc: "Valid payment address. If not specified the address is read from STDIN."},
+		},
+		run: func(ctx context.Context, r *mvs_api.RPCClient, v values) (interface{}, error) {
+			return r.ValidateaddressContext(ctx, v["PAYMENT_ADDRESS"].(string))
+		},
+	},
+	{
+		name: "sendfrom",
+		params: []param{
+			{name: "ACCOUNTNAME", wire: "ACCOUNTNAME", typ: "string", kind: "positional", doc: "Account name required."},
+			{name: "ACCOUNTAUTH", wire: "ACCOUNTAUTH", typ: "string", kind: "positional", doc: "Account password(authorization) required."},
+			{name: "FROMADDRESS", wire: "FROMADDRESS", typ: "string", kind: "positional", doc: "Send from this address"},
+			{name: "TOADDRESS", wire: "TOADDRESS", typ: "string", kind: "positional", doc: "Send to this address"},
+			{name: "AMOUNT", wire: "AMOUNT", typ: "Amount", kind: "positional", doc: "ETP integer bits."},
+			{name: "memo", wire: "memo", typ: "string", kind: "optional", doc: "The memo to descript transaction"},
+			{name: "fee", wire: "fee", typ: "Amount", kind: "optional", doc: "Transaction fee. defaults to 10000 ETP bits"},
+		},
+		run: func(ctx context.Context, r *mvs_api.RPCClient, v values) (interface{}, error) {
+			return r.SendfromContext(ctx, v["ACCOUNTNAME"].(string), v["ACCOUNTAUTH"].(string), v["FROMADDRESS"].(string), v["TOADDRESS"].(string), v["AMOUNT"].(mvs_api.Amount), v["memo"].(string), v["fee"].(mvs_api.Amount))
+		},
+	},
+	{
+		name: "deletemultisig",
+		params: []param{
+			{name: "ACCOUNTNAME", wire: "ACCOUNTNAME", typ: "string", kind: "positional", doc: "Account name required."},
+			{name: "ACCOUNTAUTH", wire: "ACCOUNTAUTH", typ: "string", kind: "positional", doc: "Account password(authorization) required."},
+			{name: "ADDRESS", wire: "ADDRESS", typ: "string", kind: "positional", doc: "The multisig script corresponding address."},
+		},
+		run: func(ctx context.Context, r *mvs_api.RPCClient, v values) (interface{}, error) {
+			return r.DeletemultisigContext(ctx, v["ACCOUNTNAME"].(string), v["ACCOUNTAUTH"].(string), v["ADDRESS"].(string))
+		},
+	},
+	{
+		name: "listdids",
+		params: []param{
+			{name: "ACCOUNTNAME", wire: "ACCOUNTNAME", typ: "string", kind: "positional", doc: "Account name required."},
+			{name: "ACCOUNTAUTH", wire: "ACCOUNTAUTH", typ: "string", kind: "positional", doc: "Account password(authorization) required."},
+		},
+		run: func(ctx context.Context, r *mvs_api.RPCClient, v values) (interface{}, error) {
+			return r.ListdidsContext(ctx, v["ACCOUNTNAME"].(string), v["ACCOUNTAUTH"].(string))
+		},
+	},
+	{
+		name: "getheight",
+		params: []param{
+			{name: "ADMINNAME", wire: "ADMINNAME", typ: "string", kind: "positional", doc: "Administrator required.(when administrator_required in mvs.conf is set true)"},
+			{name: "ADMINAUTH", wire: "ADMINAUTH", typ: "string", kind: "positional", doc: "Administrator password required."},
+		},
+		run: func(ctx context.Context, r *mvs_api.RPCClient, v values) (interface{}, error) {
+			return r.GetheightContext(ctx, v["ADMINNAME"].(string), v["ADMINAUTH"].(string))
+		},
+	},
+	{
+		name: "didsend",
+		params: []param{
+			{name: "ACCOUNTNAME", wire: "ACCOUNTNAME", typ: "string", kind: "positional", doc: "Account name required."},
+			{name: "ACCOUNTAUTH", wire: "ACCOUNTAUTH", typ: "string", kind: "positional", doc: "Account password(authorization) required."},
+			{name: "TO_", wire: "TO_", typ: "string", kind: "positional", doc: "Send to this did/address"},
+			{name: "AMOUNT", wire: "AMOUNT", typ: "Amount", kind: "positional", doc: "ETP integer bits."},
+			{name: "memo", wire: "memo", typ: "string", kind: "optional", doc: "Attached memo for this transaction."},
+			{name: "fee", wire: "fee", typ: "Amount", kind: "optional", doc: "Transaction fee. defaults to 10000 etp bits"},
+		},
+		run: func(ctx context.Context, r *mvs_api.RPCClient, v values) (interface{}, error) {
+			return r.DidsendContext(ctx, v["ACCOUNTNAME"].(string), v["ACCOUNTAUTH"].(string), v["TO_"].(string), v["AMOUNT"].(mvs_api.Amount), v["memo"].(string), v["fee"].(mvs_api.Amount))
+		},
+	},
+	{
+		name: "transfercert",
+		params: []param{
+			{name: "ACCOUNTNAME", wire: "ACCOUNTNAME", typ: "string", kind: "positional", doc: "Account name required."},
+			{name: "ACCOUNTAUTH", wire: "ACCOUNTAUTH", typ: "string", kind: "positional", doc: "Account password(authorization) required."},
+			{name: "TODID", wire: "TODID", typ: "string", kind: "positional", doc: "Target did"},
+			{name: "SYMBOL", wire: "SYMBOL", typ: "string", kind: "positional", doc: "Asset cert symbol"},
+			{name: "CERT", wire: "CERT", typ: "string", kind: "positional", doc: "Asset cert type name. eg. ISSUE, DOMAIN or NAMING"},
+			{name: "fee", wire: "fee", typ: "Amount", kind: "optional", doc: "Transaction fee. defaults to 10000 ETP bits"},
+		},
+		run: func(ctx context.Context, r *mvs_api.RPCClient, v values) (interface{}, error) {
+			return r.TransfercertContext(ctx, v["ACCOUNTNAME"].(string), v["ACCOUNTAUTH"].(string), v["TODID"].(string), v["SYMBOL"].(string), v["CERT"].(string), v["fee"].(mvs_api.Amount))
+		},
+	},
+	{
+		name: "sendrawtx",
+		params: []param{
+			{name: "TRANSACTION", wire: "TRANSACTION", typ: "string", kind: "positional", doc: "The input Base16 transaction to broadcast."},
+			{name: "fee", wire: "fee", typ: "Amount", kind: "optional", doc: "The max tx fee. default_value 10 etp"},
+		},
+		run: func(ctx context.Context, r *mvs_api.RPCClient, v values) (interface{}, error) {
+			return r.SendrawtxContext(ctx, v["TRANSACTION"].(string), v["fee"].(mvs_api.Amount))
+		},
+	},
+	{
+		name: "issuecert",
+		params: []param{
+			{name: "ACCOUNTNAME", wire: "ACCOUNTNAME", typ: "string", kind: "positional", doc: "Account name required."},
+			{name: "ACCOUNTAUTH", wire: "ACCOUNTAUTH", typ: "string", kind: "positional", doc: "Account password(authorization) required."},
+			{name: "TODID", wire: "TODID", typ: "string", kind: "positional", doc: "The DID will own this cert."},
+			{name: "SYMBOL", wire: "SYMBOL", typ: "string", kind: "positional", doc: "Asset Cert Symbol/Name."},
+			{name: "CERT", wire: "CERT", typ: "string", kind: "positional", doc: "Asset cert type name can be: ISSUE: cert of issuing asset, generated by issuing asset and used in secondaryissue asset.  DOMAIN: cert of domain, generated by issuing asset, the symbol is same as asset symbol(if it does not contain dot) or the prefix part(that before the first dot) of asset symbol. NAMING: cert of naming right of domain. The owner of domain cert can issue this type of cert by issuecert with symbol like “domain.XYZ”(domain is the symbol of domain cert)."},
+			{name: "fee", wire: "fee", typ: "Amount", kind: "optional", doc: "Transaction fee. defaults to 10000 ETP bits"},
+		},
+		run: func(ctx context.Context, r *mvs_api.RPCClient, v values) (interface{}, error) {
+			return r.IssuecertContext(ctx, v["ACCOUNTNAME"].(string), v["ACCOUNTAUTH"].(string), v["TODID"].(string), v["SYMBOL"].(string), v["CERT"].(string), v["fee"].(mvs_api.Amount))
+		},
+	},
+	{
+		name: "fetchheaderext",
+		params: []param{
+			{name: "ACCOUNTNAME", wire: "ACCOUNTNAME", typ: "string", kind: "positional", doc: "Account name required."},
+			{name: "ACCOUNTAUTH", wire: "ACCOUNTAUTH", typ: "string", kind: "positional", doc: "Account password(authorization) required."},
+			{name: "NUMBER", wire: "NUMBER", typ: "string", kind: "positional", doc: "Block number, or earliest, latest or pending"},
+		},
+		run: func(ctx context.Context, r *mvs_api.RPCClient, v values) (interface{}, error) {
+			return r.FetchheaderextContext(ctx, v["ACCOUNTNAME"].(string), v["ACCOUNTAUTH"].(string), v["NUMBER"].(string))
+		},
+	},
+	{
+		name: "didsendassetfrom",
+		params: []param{
+			{name: "ACCOUNTNAME", wire: "ACCOUNTNAME", typ: "string", kind: "positional", doc: "Account name required."},
+			{name: "ACCOUNTAUTH", wire: "ACCOUNTAUTH", typ: "string", kind: "positional", doc: "Account password(authorization) required."},
+			{name: "FROM_", wire: "FROM_", typ: "string", kind: "positional", doc: "From did/address"},
+			{name: "TO_", wire: "TO_", typ: "string", kind: "positional", doc: "Target did/address"},
+			{name: "SYMBOL", wire: "SYMBOL", typ: "string", kind: "positional", doc: "Asset symbol"},
+			{name: "AMOUNT", wire: "AMOUNT", typ: "Amount", kind: "positional", doc: "Asset integer bits. see asset <decimal_number>."},
+			{name: "model", wire: "model", typ: "string", kind: "optional", doc: "The token offering model by block height."},
+			{name: "fee", wire: "fee", typ: "Amount", kind: "optional", doc: "Transaction fee. defaults to 10000 ETP bits"},
+		},
+		run: func(ctx context.Context, r *mvs_api.RPCClient, v values) (interface{}, error) {
+			return r.DidsendassetfromContext(ctx, v["ACCOUNTNAME"].(string), v["ACCOUNTAUTH"].(string), v["FROM_"].(string), v["TO_"].(string), v["SYMBOL"].(string), v["AMOUNT"].(mvs_api.Amount), v["model"].(string), v["fee"].(mvs_api.Amount))
+		},
+	},
+	{
+		name: "didsendmore",
+		params: []param{
+			{name: "ACCOUNTNAME", wire: "ACCOUNTNAME", typ: "string", kind: "positional", doc: "Account name required."},
+			{name: "ACCOUNTAUTH", wire: "ACCOUNTAUTH", typ: "string", kind: "positional", doc: "Account password(authorization) required."},
+			{name: "receivers", wire: "receivers", typ: "Receivers", kind: "required", doc: "Send to [did/address:etp_bits]."},
+			{name: "mychange", wire: "mychange", typ: "string", kind: "optional", doc: "Mychange to this did/address"},
+			{name: "fee", wire: "fee", typ: "Amount", kind: "optional", doc: "Transaction fee. defaults to 10000 ETP bits"},
+		},
+		run: func(ctx context.Context, r *mvs_api.RPCClient, v values) (interface{}, error) {
+			return r.DidsendmoreContext(ctx, v["ACCOUNTNAME"].(string), v["ACCOUNTAUTH"].(string), v["receivers"].(mvs_api.Receivers), v["mychange"].(string), v["fee"].(mvs_api.Amount))
+		},
+	},
+	{
+		name: "sendmore",
+		params: []param{
+			{name: "ACCOUNTNAME", wire: "ACCOUNTNAME", typ: "string", kind: "positional", doc: "Account name required."},
+			{name: "ACCOUNTAUTH", wire: "ACCOUNTAUTH", typ: "string", kind: "positional", doc: "Account password(authorization) required."},
+			{name: "receivers", wire: "receivers", typ: "Receivers", kind: "required", doc: "Send to [address:etp_bits]."},
+			{name: "mychange", wire: "mychange", typ: "string", kind: "optional", doc: "Mychange to this address"},
+			{name: "fee", wire: "fee", typ: "Amount", kind: "optional", doc: "Transaction fee. defaults to 10000 ETP bits"},
+		},
+		run: func(ctx context.Context, r *mvs_api.RPCClient, v values) (interface{}, error) {
+			return r.SendmoreContext(ctx, v["ACCOUNTNAME"].(string), v["ACCOUNTAUTH"].(string), v["receivers"].(mvs_api.Receivers), v["mychange"].(string), v["fee"].(mvs_api.Amount))
+		},
+	},
+	{
+		name: "deletelocalasset",
+		params: []param{
+			{name: "ACCOUNTNAME", wire: "ACCOUNTNAME", typ: "string", kind: "positional", doc: "Account name required."},
+			{name: "ACCOUNTAUTH", wire: "ACCOUNTAUTH", typ: "string", kind: "positional", doc: "Account password(authorization) required."},
+			{name: "symbol", wire: "symbol", typ: "string", kind: "required", doc: "The asset symbol/name. Global unique."},
+		},
+		run: func(ctx context.Context, r *mvs_api.RPCClient, v values) (interface{}, error) {
+			return r.DeletelocalassetContext(ctx, v["ACCOUNTNAME"].(string), v["ACCOUNTAUTH"].(string), v["symbol"].(string))
+		},
+	},
+	{
+		name: "listtxs",
+		params: []param{
+			{name: "ACCOUNTNAME", wire: "ACCOUNTNAME", typ: "string", kind: "positional", doc: "Account name required."},
+			{name: "ACCOUNTAUTH", wire: "ACCOUNTAUTH", typ: "string", kind: "positional", doc: "Account password(authorization) required."},
+			{name: "address", wire: "address", typ: "string", kind: "optional", doc: "Address."},
+			{name: "height", wire: "height", typ: "[2]uint64", kind: "optional", doc: "Get tx according height eg: -e start-height:end-height will return tx between [start-height, end-height)"},
+			{name: "symbol", wire: "symbol", typ: "string", kind: "optional", doc: "Asset symbol."},
+			{name: "limit", wire: "limit", typ: "uint64", kind: "optional", doc: "Transaction count per page."},
+			{name: "index", wire: "index", typ: "uint64", kind: "optional", doc: "Page index."},
+		},
+		run: func(ctx context.Context, r *mvs_api.RPCClient, v values) (interface{}, error) {
+			return r.ListtxsContext(ctx, v["ACCOUNTNAME"].(string), v["ACCOUNTAUTH"].(string), v["address"].(string), v["height"].([2]uint64), v["symbol"].(string), v["limit"].(uint64), v["index"].(uint64))
+		},
+	},
+	{
+		name: "getmit",
+		params: []param{
+			{name: "SYMBOL", wire: "SYMBOL", typ: "string", kind: "positional", doc: "Asset symbol. If not specified then show whole network MIT symbols."},
+			{name: "trace", wire: "trace", typ: "bool", kind: "flag", doc: "If specified then trace the history. Default is not specified."},
+			{name: "limit", wire: "limit", typ: "uint32", kind: "optional", doc: "MIT count per page."},
+			{name: "index", wire: "index", typ: "uint32", kind: "optional", doc: "Page index."},
+			{name: "current", wire: "current", typ: "bool", kind: "flag", doc: "If specified then show the lastest information of specified MIT. Default is not specified."},
+		},
+		run: func(ctx context.Context, r *mvs_api.RPCClient, v values) (interface{}, error) {
+			return r.GetmitContext(ctx, v["SYMBOL"].(string), v["trace"].(bool), v["limit"].(uint32), v["index"].(uint32), v["current"].(bool))
+		},
+	},
+	{
+		name: "getnewaccount",
+		params: []param{
+			{name: "language", wire: "language", typ: "string", kind: "optional", doc: "Options are 'en', 'es', 'ja', 'zh_Hans', 'zh_Hant' and 'any', defaults to 'en'."},
+			{name: "ACCOUNTNAME", wire: "ACCOUNTNAME", typ: "string", kind: "positional", doc: "Account name required."},
+			{name: "ACCOUNTAUTH", wire: "ACCOUNTAUTH", typ: "string", kind: "positional", doc: "Account password(authorization) required."},
+		},
+		run: func(ctx context.Context, r *mvs_api.RPCClient, v values) (interface{}, error) {
+			return r.GetnewaccountContext(ctx, v["language"].(string), v["ACCOUNTNAME"].(string), v["ACCOUNTAUTH"].(string))
+		},
+	},
+	{
+		name: "listmits",
+		params: []param{
+			{name: "ACCOUNTNAME", wire: "ACCOUNTNAME", typ: "string", kind: "positional", doc: "Account name required."},
+			{name: "ACCOUNTAUTH", wire: "ACCOUNTAUTH", typ: "string", kind: "positional", doc: "Account password(authorization) required."},
+		},
+		run: func(ctx context.Context, r *mvs_api.RPCClient, v values) (interface{}, error) {
+			return r.ListmitsContext(ctx, v["ACCOUNTNAME"].(string), v["ACCOUNTAUTH"].(string))
+		},
+	},
+	{
+		name: "shutdown",
+		params: []param{
+			{name: "ADMINNAME", wire: "ADMINNAME", typ: "string", kind: "positional", doc: "admin name."},
+			{name: "ADMINAUTH", wire: "ADMINAUTH", typ: "string", kind: "positional", doc: "admin password/authorization."},
+		},
+		run: func(ctx context.Context, r *mvs_api.RPCClient, v values) (interface{}, error) {
+			return r.ShutdownContext(ctx, v["ADMINNAME"].(string), v["ADMINAUTH"].(string))
+		},
+	},
+	{
+		name: "signrawtx",
+		params: []param{
+			{name: "ACCOUNTNAME", wire: "ACCOUNTNAME", typ: "string", kind: "positional", doc: "Account name required."},
+			{name: "ACCOUNTAUTH", wire: "ACCOUNTAUTH", typ: "string", kind: "positional", doc: "Account password(authorization) required."},
+			{name: "TRANSACTION", wire: "TRANSACTION", typ: "string", kind: "positional", doc: "The input Base16 transaction to sign."},
+		},
+		run: func(ctx context.Context, r *mvs_api.RPCClient, v values) (interface{}, error) {
+			return r.SignrawtxContext(ctx, v["ACCOUNTNAME"].(string), v["ACCOUNTAUTH"].(string), v["TRANSACTION"].(string))
+		},
+	},
+	{
+		name: "getmemorypool",
+		params: []param{
+			{name: "json", wire: "json", typ: "bool", kind: "optional", doc: "Json format or Raw format, default is Json(true)."},
+			{name: "ADMINNAME", wire: "ADMINNAME", typ: "string", kind: "positional", doc: "Administrator required.(when administrator_required in mvs.conf is set true)"},
+			{name: "ADMINAUTH", wire: "ADMINAUTH", typ: "string", kind: "positional", doc: "Administrator password required."},
+		},
+		run: func(ctx context.Context, r *mvs_api.RPCClient, v values) (interface{}, error) {
+			return r.GetmemorypoolContext(ctx, v["json"].(bool), v["ADMINNAME"].(string), v["ADMINAUTH"].(string))
+		},
+	},
+	{
+		name: "getblockheader",
+		params: []param{
+			{name: "hash", wire: "hash", typ: "string", kind: "optional", doc: "The Base16 block hash."},
+			{name: "height", wire: "height", typ: "uint32", kind: "optional", doc: "The block height."},
+		},
+		run: func(ctx context.Context, r *mvs_api.RPCClient, v values) (interface{}, error) {
+			return r.GetblockheaderContext(ctx, v["hash"].(string), v["height"].(uint32))
+		},
+	},
+	{
+		name: "listassets",
+		params: []param{
+			{name: "ACCOUNTNAME", wire: "ACCOUNTNAME", typ: "string", kind: "positional", doc: "Account name required."},
+			{name: "ACCOUNTAUTH", wire: "ACCOUNTAUTH", typ: "string", kind: "positional", doc: "Account password(authorization) required."},
+			{name: "cert", wire: "cert", typ: "bool", kind: "flag", doc: "If specified, then only get related asset cert. Default is not specified."},
+		},
+		run: func(ctx context.Context, r *mvs_api.RPCClient, v values) (interface{}, error) {
+			return r.ListassetsContext(ctx, v["ACCOUNTNAME"].(string), v["ACCOUNTAUTH"].(string), v["cert"].(bool))
+		},
+	},
+	{
+		name: "sendassetfrom",
+		params: []param{
+			{name: "ACCOUNTNAME", wire: "ACCOUNTNAME", typ: "string", kind: "positional", doc: "Account name required."},
+			{name: "ACCOUNTAUTH", wire: "ACCOUNTAUTH", typ: "string", kind: "positional", doc: "Account password(authorization) required."},
+			{name: "FROMADDRESS", wire: "FROMADDRESS", typ: "string", kind: "positional", doc: "From address"},
+			{name: "TOADDRESS", wire: "TOADDRESS", typ: "string", kind: "positional", doc: "Target address"},
+			{name: "SYMBOL", wire: "SYMBOL", typ: "string", kind: "positional", doc: "Asset symbol"},
+			{name: "AMOUNT", wire: "AMOUNT", typ: "Amount", kind: "positional", doc: "Asset integer bits. see asset <decimal_number>."},
+			{name: "model", wire: "model", typ: "string", kind: "optional", doc: "The token offering model by block height."},
+			{name: "fee", wire: "fee", typ: "Amount", kind: "optional", doc: "Transaction fee. defaults to 10000 ETP bits"},
+		},
+		run: func(ctx context.Context, r *mvs_api.RPCClient, v values) (interface{}, error) {
+			return r.SendassetfromContext(ctx, v["ACCOUNTNAME"].(string), v["ACCOUNTAUTH"].(string), v["FROMADDRESS"].(string), v["TOADDRESS"].(string), v["SYMBOL"].(string), v["AMOUNT"].(mvs_api.Amount), v["model"].(string), v["fee"].(mvs_api.Amount))
+		},
+	},
+	{
+		name: "getasset",
+		params: []param{
+			{name: "SYMBOL", wire: "SYMBOL", typ: "string", kind: "positional", doc: "Asset symbol. If not specified, will show whole network asset symbols."},
+			{name: "cert", wire: "cert", typ: "bool", kind: "flag", doc: "If specified, then only get related asset cert. Default is not specified."},
+		},
+		run: func(ctx context.Context, r *mvs_api.RPCClient, v values) (interface{}, error) {
+			return r.GetassetContext(ctx, v["SYMBOL"].(string), v["cert"].(bool))
+		},
+	},
+	{
+		name: "getinfo",
+		params: []param{
+			{name: "ADMINNAME", wire: "ADMINNAME", typ: "string", kind: "positional", doc: "Administrator required.(when administrator_required in mvs.conf is set true)"},
+			{name: "ADMINAUTH", wire: "ADMINAUTH", typ: "string", kind: "positional", doc: "Administrator password required."},
+		},
+		run: func(ctx context.Context, r *mvs_api.RPCClient, v values) (interface{}, error) {
+			return r.GetinfoContext(ctx, v["ADMINNAME"].(string), v["ADMINAUTH"].(string))
+		},
+	},
+	{
+		name: "secondaryissue",
+		params: []param{
+			{name: "ACCOUNTNAME", wire: "ACCOUNTNAME", typ: "string", kind: "positional", doc: "Account name required."},
+			{name: "ACCOUNTAUTH", wire: "ACCOUNTAUTH", typ: "string", kind: "positional", doc: "Account password(authorization) required."},
+			{name: "TODID", wire: "TODID", typ: "string", kind: "positional", doc: "target did to check and issue asset, fee from and mychange to the address of this did too."},
+			{name: "SYMBOL", wire: "SYMBOL", typ: "string", kind: "positional", doc: "issued asset symbol"},
+			{name: "VOLUME", wire: "VOLUME", typ: "Amount", kind: "positional", doc: "The volume of asset, with unit of integer bits."},
+			{name: "model", wire: "model", typ: "string", kind: "optional", doc: "The token offering model by block height."},
+			{name: "fee", wire: "fee", typ: "Amount", kind: "optional", doc: "The fee of tx. default_value 10000 ETP bits"},
+		},
+		run: func(ctx context.Context, r *mvs_api.RPCClient, v values) (interface{}, error) {
+			return r.SecondaryissueContext(ctx, v["ACCOUNTNAME"].(string), v["ACCOUNTAUTH"].(string), v["TODID"].(string), v["SYMBOL"].(string), v["VOLUME"].(mvs_api.Amount), v["model"].(string), v["fee"].(mvs_api.Amount))
+		},
+	},
+	{
+		name: "getaddressasset",
+		params: []param{
+			{name: "ADDRESS", wire: "ADDRESS", typ: "string", kind: "positional", doc: "address"},
+			{name: "cert", wire: "cert", typ: "bool", kind: "flag", doc: "If specified, then only get related asset cert. Default is not specified."},
+		},
+		run: func(ctx context.Context, r *mvs_api.RPCClient, v values) (interface{}, error) {
+			return r.GetaddressassetContext(ctx, v["ADDRESS"].(string), v["cert"].(bool))
+		},
+	},
+	{
+		name: "getnewaddress",
+		params: []param{
+			{name: "ACCOUNTNAME", wire: "ACCOUNTNAME", typ: "string", kind: "positional", doc: "Account name required."},
+			{name: "ACCOUNTAUTH", wire: "ACCOUNTAUTH", typ: "string", kind: "positional", doc: "Account password(authorization) required."},
+			{name: "number", wire: "number", typ: "uint32", kind: "optional", doc: "The number of addresses to be generated, defaults to 1."},
+		},
+		run: func(ctx context.Context, r *mvs_api.RPCClient, v values) (interface{}, error) {
+			return r.GetnewaddressContext(ctx, v["ACCOUNTNAME"].(string), v["ACCOUNTAUTH"].(string), v["number"].(uint32))
+		},
+	},
+	{
+		name: "getbalance",
+		params: []param{
+			{name: "ACCOUNTNAME", wire: "ACCOUNTNAME", typ: "string", kind: "positional", doc: "Account name required."},
+			{name: "ACCOUNTAUTH", wire: "ACCOUNTAUTH", typ: "string", kind: "positional", doc: "Account password(authorization) required."},
+		},
+		run: func(ctx context.Context, r *mvs_api.RPCClient, v values) (interface{}, error) {
+			return r.GetbalanceContext(ctx, v["ACCOUNTNAME"].(string), v["ACCOUNTAUTH"].(string))
+		},
+	},
+	{
+		name: "getnewmultisig",
+		params: []param{
+			{name: "ACCOUNTNAME", wire: "ACCOUNTNAME", typ: "string", kind: "positional", doc: "Account name required."},
+			{name: "ACCOUNTAUTH", wire: "ACCOUNTAUTH", typ: "string", kind: "positional", doc: "Account password(authorization) required."},
+			{name: "signaturenum", wire: "signaturenum", typ: "uint16", kind: "required", doc: "Account multisig signature number."},
+			{name: "publickeynum", wire: "publickeynum", typ: "uint16", kind: "required", doc: "Account multisig public key number."},
+			{name: "selfpublickey", wire: "selfpublickey", typ: "string", kind: "required", doc: "the public key belongs to this account."},
+			{name: "publickey", wire: "publickey", typ: "[]string", kind: "optional", doc: "cosigner public key used for multisig"},
+			{name: "description", wire: "description", typ: "string", kind: "optional", doc: "multisig record description."},
+		},
+		run: func(ctx context.Context, r *mvs_api.RPCClient, v values) (interface{}, error) {
+			return r.GetnewmultisigContext(ctx, v["ACCOUNTNAME"].(string), v["ACCOUNTAUTH"].(string), v["signaturenum"].(uint16), v["publickeynum"].(uint16), v["selfpublickey"].(string), v["publickey"].([]string), v["description"].(string))
+		},
+	},
+	{
+		name: "transfermit",
+		params: []param{
+			{name: "ACCOUNTNAME", wire: "ACCOUNTNAME", typ: "string", kind: "positional", doc: "Account name required."},
+			{name: "ACCOUNTAUTH", wire: "ACCOUNTAUTH", typ: "string", kind: "positional", doc: "Account password(authorization) required."},
+			{name: "TODID", wire: "TODID", typ: "string", kind: "positional", doc: "Target did"},
+			{name: "SYMBOL", wire: "SYMBOL", typ: "string", kind: "positional", doc: "Asset MIT symbol"},
+			{name: "fee", wire: "fee", typ: "Amount", kind: "optional", doc: "Transaction fee. defaults to 10000 ETP bits"},
+		},
+		run: func(ctx context.Context, r *mvs_api.RPCClient, v values) (interface{}, error) {
+			return r.TransfermitContext(ctx, v["ACCOUNTNAME"].(string), v["ACCOUNTAUTH"].(string), v["TODID"].(string), v["SYMBOL"].(string), v["fee"].(mvs_api.Amount))
+		},
+	},
+	{
+		name: "deleteaccount",
+		params: []param{
+			{name: "ACCOUNTNAME", wire: "ACCOUNTNAME", typ: "string", kind: "positional", doc: "Account name required."},
+			{name: "ACCOUNTAUTH", wire: "ACCOUNTAUTH", typ: "string", kind: "positional", doc: "Account password(authorization) required."},
+			{name: "LASTWORD", wire: "LASTWORD", typ: "string", kind: "positional", doc: "The last word of your private-key phrase."},
+		},
+		run: func(ctx context.Context, r *mvs_api.RPCClient, v values) (interface{}, error) {
+			return r.DeleteaccountContext(ctx, v["ACCOUNTNAME"].(string), v["ACCOUNTAUTH"].(string), v["LASTWORD"].(string))
+		},
+	},
+	{
+		name: "listmultisig",
+		params: []param{
+			{name: "ACCOUNTNAME", wire: "ACCOUNTNAME", typ: "string", kind: "positional", doc: "Account name required."},
+			{name: "ACCOUNTAUTH", wire: "ACCOUNTAUTH", typ: "string", kind: "positional", doc: "Account password(authorization) required."},
+		},
+		run: func(ctx context.Context, r *mvs_api.RPCClient, v values) (interface{}, error) {
+			return r.ListmultisigContext(ctx, v["ACCOUNTNAME"].(string), v["ACCOUNTAUTH"].(string))
+		},
+	},
+	{
+		name: "getdid",
+		params: []param{
+			{name: "DidOrAddress", wire: "DidOrAddress", typ: "string", kind: "positional", doc: "Did symbol or standard address; If no input parameters, then display whole network DIDs."},
+		},
+		run: func(ctx context.Context, r *mvs_api.RPCClient, v values) (interface{}, error) {
+			return r.GetdidContext(ctx, v["DidOrAddress"].(string))
+		},
+	},
+	{
+		name: "startmining",
+		params: []param{
+			{name: "ACCOUNTNAME", wire: "ACCOUNTNAME", typ: "string", kind: "positional", doc: "Account name required."},
+			{name: "ACCOUNTAUTH", wire: "ACCOUNTAUTH", typ: "string", kind: "positional", doc: "Account password(authorization) required."},
+			{name: "address", wire: "address", typ: "string", kind: "optional", doc: "The mining target address. Defaults to empty, means a new address will be generated."},
+			{name: "number", wire: "number", typ: "uint16", kind: "optional", doc: "The number of mining blocks, useful for testing. Defaults to 0, means no limit."},
+		},
+		run: func(ctx context.Context, r *mvs_api.RPCClient, v values) (interface{}, error) {
+			return r.StartminingContext(ctx, v["ACCOUNTNAME"].(string), v["ACCOUNTAUTH"].(string), v["address"].(string), v["number"].(uint16))
+		},
+	},
+	{
+		name: "getwork",
+		params: []param{
+			{name: "ADMINNAME", wire: "ADMINNAME", typ: "string", kind: "positional", doc: "Administrator required.(when administrator_required in mvs.conf is set true)"},
+			{name: "ADMINAUTH", wire: "ADMINAUTH", typ: "string", kind: "positional", doc: "Administrator password required."},
+		},
+		run: func(ctx context.Context, r *mvs_api.RPCClient, v values) (interface{}, error) {
+			return r.GetworkContext(ctx, v["ADMINNAME"].(string), v["ADMINAUTH"].(string))
+		},
+	},
+	{
+		name: "importkeyfile",
+		params: []param{
+			{name: "ACCOUNTNAME", wire: "ACCOUNTNAME", typ: "string", kind: "positional", doc: "Account name required."},
+			{name: "ACCOUNTAUTH", wire: "ACCOUNTAUTH", typ: "string", kind: "positional", doc: "Account password(authorization) required."},
+			{name: "FILE", wire: "FILE", typ: "string", kind: "positional", doc: "key file path."},
+			{name: "FILECONTENT", wire: "FILECONTENT", typ: "string", kind: "positional", doc: "key file content. this will omit the FILE argument if specified."},
+		},
+		run: func(ctx context.Context, r *mvs_api.RPCClient, v values) (interface{}, error) {
+			return r.ImportkeyfileContext(ctx, v["ACCOUNTNAME"].(string), v["ACCOUNTAUTH"].(string), v["FILE"].(string), v["FILECONTENT"].(string))
+		},
+	},
+	{
+		name: "decoderawtx",
+		params: []param{
+			{name: "TRANSACTION", wire: "TRANSACTION", typ: "string", kind: "positional", doc: "The input Base16 transaction to sign."},
+		},
+		run: func(ctx context.Context, r *mvs_api.RPCClient, v values) (interface{}, error) {
+			return r.DecoderawtxContext(ctx, v["TRANSACTION"].(string))
+		},
+	},
+	{
+		name: "sendasset",
+		params: []param{
+			{name: "ACCOUNTNAME", wire: "ACCOUNTNAME", typ: "string", kind: "positional", doc: "Account name required."},
+			{name: "ACCOUNTAUTH", wire: "ACCOUNTAUTH", typ: "string", kind: "positional", doc: "Account password(authorization) required."},
+			{name: "ADDRESS", wire: "ADDRESS", typ: "string", kind: "positional", doc: "Asset receiver."},
+			{name: "SYMBOL", wire: "SYMBOL", typ: "string", kind: "positional", doc: "Asset symbol/name."},
+			{name: "AMOUNT", wire: "AMOUNT", typ: "Amount", kind: "positional", doc: "Asset integer bits. see asset <decimal_number>."},
+			{name: "model", wire: "model", typ: "string", kind: "optional", doc: "The token offering model by block height."},
+			{name: "fee", wire: "fee", typ: "Amount", kind: "optional", doc: "Transaction fee. defaults to 10000 ETP bits"},
+		},
+		run: func(ctx context.Context, r *mvs_api.RPCClient, v values) (interface{}, error) {
+			return r.SendassetContext(ctx, v["ACCOUNTNAME"].(string), v["ACCOUNTAUTH"].(string), v["ADDRESS"].(string), v["SYMBOL"].(string), v["AMOUNT"].(mvs_api.Amount), v["model"].(string), v["fee"].(mvs_api.Amount))
+		},
+	},
+	{
+		name: "submitwork",
+		params: []param{
+			{name: "NONCE", wire: "NONCE", typ: "string", kind: "positional", doc: "nonce. without leading 0x"},
+			{name: "HEADERHASH", wire: "HEADERHASH", typ: "string", kind: "positional", doc: "header hash. with leading 0x"},
+			{name: "MIXHASH", wire: "MIXHASH", typ: "string", kind: "positional", doc: "mix hash. with leading 0x"},
+		},
+		run: func(ctx context.Context, r *mvs_api.RPCClient, v values) (interface{}, error) {
+			return r.SubmitworkContext(ctx, v["NONCE"].(string), v["HEADERHASH"].(string), v["MIXHASH"].(string))
+		},
+	},
+	{
+		name: "getaddressetp",
+		params: []param{
+			{name: "PAYMENT_ADDRESS", wire: "PAYMENT_ADDRESS", typ: "string", kind: "positional", doc: "The payment address. If not specified the address is read from STDIN."},
+		},
+		run: func(ctx context.Context, r *mvs_api.RPCClient, v values) (interface{}, error) {
+			return r.GetaddressetpContext(ctx, v["PAYMENT_ADDRESS"].(string))
+		},
+	},
+	{
+		name: "gettx",
+		params: []param{
+			{name: "json", wire: "json", typ: "bool", kind: "positional", doc: "Json/Raw format, default is '--json=true'."},
+			{name: "HASH", wire: "HASH", typ: "string", kind: "positional", doc: "The Base16 transaction hash of the transaction to get. If not specified the transaction hash is read from STDIN."},
+		},
+		run: func(ctx context.Context, r *mvs_api.RPCClient, v values) (interface{}, error) {
+			return r.GettxContext(ctx, v["json"].(bool), v["HASH"].(string))
+		},
+	},
+	{
+		name: "getmininginfo",
+		params: []param{
+			{name: "ADMINNAME", wire: "ADMINNAME", typ: "string", kind: "positional", doc: "Administrator required.(when administrator_required in mvs.conf is set true)"},
+			{name: "ADMINAUTH", wire: "ADMINAUTH", typ: "string", kind: "positional", doc: "Administrator password required."},
+		},
+		run: func(ctx context.Context, r *mvs_api.RPCClient, v values) (interface{}, error) {
+			return r.GetmininginfoContext(ctx, v["ADMINNAME"].(string), v["ADMINAUTH"].(string))
+		},
+	},
+	{
+		name: "registermit",
+		params: []param{
+			{name: "ACCOUNTNAME", wire: "ACCOUNTNAME", typ: "string", kind: "positional", doc: "Account name required."},
+			{name: "ACCOUNTAUTH", wire: "ACCOUNTAUTH", typ: "string", kind: "positional", doc: "Account password(authorization) required."},
+			{name: "TODID", wire: "TODID", typ: "string", kind: "positional", doc: "Target did"},
+			{name: "SYMBOL", wire: "SYMBOL", typ: "string", kind: "positional_optional", doc: "MIT symbol"},
+			{name: "content", wire: "content", typ: "string", kind: "optional", doc: "Content of MIT"},
+			{name: "mits", wire: "mits", typ: "[]string", kind: "optional", doc: "List of symbol and content pair. Symbol and content are separated by a ':'"},
+			{name: "fee", wire: "fee", typ: "Amount", kind: "optional", doc: "Transaction fee. defaults to 10000 ETP bits"},
+		},
+		run: func(ctx context.Context, r *mvs_api.RPCClient, v values) (interface{}, error) {
+			return r.RegistermitContext(ctx, v["ACCOUNTNAME"].(string), v["ACCOUNTAUTH"].(string), v["TODID"].(string), v["SYMBOL"].(string), v["content"].(string), v["mits"].([]string), v["fee"].(mvs_api.Amount))
+		},
+	},
+	{
+		name: "setminingaccount",
+		params: []param{
+			{name: "ACCOUNTNAME", wire: "ACCOUNTNAME", typ: "string", kind: "positional", doc: "Account name required."},
+			{name: "ACCOUNTAUTH", wire: "ACCOUNTAUTH", typ: "string", kind: "positional", doc: "Account password(authorization) required."},
+			{name: "PAYMENT_ADDRESS", wire: "PAYMENT_ADDRESS", typ: "string", kind: "positional", doc: "the payment address of this account."},
+		},
+		run: func(ctx context.Context, r *mvs_api.RPCClient, v values) (interface{}, error) {
+			return r.SetminingaccountContext(ctx, v["ACCOUNTNAME"].(string), v["ACCOUNTAUTH"].(string), v["PAYMENT_ADDRESS"].(string))
+		},
+	},
+	{
+		name: "listaddresses",
+		params: []param{
+			{name: "ACCOUNTNAME", wire: "ACCOUNTNAME", typ: "string", kind: "positional", doc: "Account name required."},
+			{name: "ACCOUNTAUTH", wire: "ACCOUNTAUTH", typ: "string", kind: "positional", doc: "Account password(authorization) required."},
+		},
+		run: func(ctx context.Context, r *mvs_api.RPCClient, v values) (interface{}, error) {
+			return r.ListaddressesContext(ctx, v["ACCOUNTNAME"].(string), v["ACCOUNTAUTH"].(string))
+		},
+	},
+	{
+		name: "dumpkeyfile",
+		params: []param{
+			{name: "ACCOUNTNAME", wire: "ACCOUNTNAME", typ: "string", kind: "positional", doc: "Account name required."},
+			{name: "ACCOUNTAUTH", wire: "ACCOUNTAUTH", typ: "string", kind: "positional", doc: "Account password(authorization) required."},
+			{name: "LASTWORD", wire: "LASTWORD", typ: "string", kind: "positional", doc: "The last word of your master private-key phrase."},
+			{name: "DESTINATION", wire: "DESTINATION", typ: "string", kind: "positional_optional", doc: "The keyfile storage path to."},
+			{name: "data", wire: "data", typ: "bool", kind: "flag", doc: "If specified, the keyfile content will be append to the report, rather than to local file specified by DESTINATION."},
+		},
+		run: func(ctx context.Context, r *mvs_api.RPCClient, v values) (interface{}, error) {
+			return r.DumpkeyfileContext(ctx, v["ACCOUNTNAME"].(string), v["ACCOUNTAUTH"].(string), v["LASTWORD"].(string), v["DESTINATION"].(string), v["data"].(bool))
+		},
+	},
+	{
+		name: "getpeerinfo",
+		params: []param{
+			{name: "ADMINNAME", wire: "ADMINNAME", typ: "string", kind: "positional", doc: "Administrator required.(when administrator_required in mvs.conf is set true)"},
+			{name: "ADMINAUTH", wire: "ADMINAUTH", typ: "string", kind: "positional", doc: "Administrator password required."},
+		},
+		run: func(ctx context.Context, r *mvs_api.RPCClient, v values) (interface{}, error) {
+			return r.GetpeerinfoContext(ctx, v["ADMINNAME"].(string), v["ADMINAUTH"].(string))
+		},
+	},
+	{
+		name: "didsendfrom",
+		params: []param{
+			{name: "ACCOUNTNAME", wire: "ACCOUNTNAME", typ: "string", kind: "positional", doc: "Account name required."},
+			{name: "ACCOUNTAUTH", wire: "ACCOUNTAUTH", typ: "string", kind: "positional", doc: "Account password(authorization) required."},
+			{name: "FROM_", wire: "FROM_", typ: "string", kind: "positional", doc: "Send from this did/address"},
+			{name: "TO_", wire: "TO_", typ: "string", kind: "positional", doc: "Send to this did/address"},
+			{name: "AMOUNT", wire: "AMOUNT", typ: "Amount", kind: "positional", doc: "ETP integer bits."},
+			{name: "memo", wire: "memo", typ: "string", kind: "optional", doc: "The memo to descript transaction"},
+			{name: "fee", wire: "fee", typ: "Amount", kind: "optional", doc: "Transaction fee. defaults to 10000 ETP bits"},
+		},
+		run: func(ctx context.Context, r *mvs_api.RPCClient, v values) (interface{}, error) {
+			return r.DidsendfromContext(ctx, v["ACCOUNTNAME"].(string), v["ACCOUNTAUTH"].(string), v["FROM_"].(string), v["TO_"].(string), v["AMOUNT"].(mvs_api.Amount), v["memo"].(string), v["fee"].(mvs_api.Amount))
+		},
+	},
+	{
+		name: "getaccount",
+		params: []param{
+			{name: "ACCOUNTNAME", wire: "ACCOUNTNAME", typ: "string", kind: "positional", doc: "Account name required."},
+			{name: "ACCOUNTAUTH", wire: "ACCOUNTAUTH", typ: "string", kind: "positional", doc: "Account password(authorization) required."},
+			{name: "LASTWORD", wire: "LASTWORD", typ: "string", kind: "positional", doc: "The last word of your backup words."},
+		},
+		run: func(ctx context.Context, r *mvs_api.RPCClient, v values) (interface{}, error) {
+			return r.GetaccountContext(ctx, v["ACCOUNTNAME"].(string), v["ACCOUNTAUTH"].(string), v["LASTWORD"].(string))
+		},
+	},
+	{
+		name: "addnode",
+		params: []param{
+			{name: "NODEADDRESS", wire: "NODEADDRESS", typ: "string", kind: "positional", doc: "The target node address[x.x.x.x:port]."},
+			{name: "ADMINNAME", wire: "ADMINNAME", typ: "string", kind: "positional", doc: "admin name."},
+			{name: "ADMINAUTH", wire: "ADMINAUTH", typ: "string", kind: "positional", doc: "admin password/authorization."},
+			{name: "operation", wire: "operation", typ: "string", kind: "optional", doc: "The operation[ add|ban ] to the target node address. default: add."},
+		},
+		run: func(ctx context.Context, r *mvs_api.RPCClient, v values) (interface{}, error) {
+			return r.AddnodeContext(ctx, v["NODEADDRESS"].(string), v["ADMINNAME"].(string), v["ADMINAUTH"].(string), v["operation"].(string))
+		},
+	},
+	{
+		name: "getblock",
+		params: []param{
+			{name: "HASH_OR_HEIGH", wire: "HASH_OR_HEIGH", typ: "string", kind: "positional", doc: "block hash or block height"},
+			{name: "json", wire: "json", typ: "bool", kind: "positional", doc: "Json/Raw format, default is '--json=true'."},
+			{name: "tx_json", wire: "tx_json", typ: "bool", kind: "positional", doc: "Json/Raw format for txs, default is '--tx_json=true'."},
+		},
+		run: func(ctx context.Context, r *mvs_api.RPCClient, v values) (interface{}, error) {
+			return r.GetblockContext(ctx, v["HASH_OR_HEIGH"].(string), v["json"].(bool), v["tx_json"].(bool))
+		},
+	},
+}
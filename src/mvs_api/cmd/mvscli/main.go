@@ -0,0 +1,637 @@
+// Command mvscli calls any RPCClient method from the shell, with one
+// subcommand per method of commands.json:
+//
+//	mvscli [-url url] [-profile name] [-o json|raw|table] command [flags] [args]
+//	mvscli getbalance
+//	mvscli -o table listaddresses
+//	mvscli send -fee 20000 MKfqzVbhjRiVbXm3nkyiV4J6yqSm7UXWGR 100000000
+//
+// Positional parameters are arguments, the others flags, which may come
+// before or between them; "mvscli help command" lists both. List flags may
+// be repeated or given comma separated, receivers as "to:amount", and
+// amounts are integers of the smallest unit, as the node takes them.
+//
+// The account and admin names and passwords are not taken from the command
+// line when they are set in the environment or a profile, so they stay out
+// of shell history and ps. The environment wins:
+//
+//	MVS_URL, MVS_ACCOUNT, MVS_PASSWORD, MVS_ADMIN, MVS_ADMIN_PASSWORD
+//
+// Profiles are read from $MVS_PROFILE_FILE, or ~/.mvscli.json, and chosen
+// with -profile or $MVS_PROFILE, "default" if neither is set. The file may
+// hold passwords, so it is refused unless only its owner can read and write
+// it (chmod 600):
+//
+//	{
+//	  "default": {"url": "http://127.0.0.1:8820/rpc/v2", "account": "Alice", "password": "A123456"},
+//	  "testnet": {"url": "http://127.0.0.1:18820/rpc/v2", "timeout": "1m"}
+//	}
+//
+// Results are printed as indented JSON by default; -o raw prints the result
+// as the node sent it, and -o table prints lists of objects as columns.
+//
+//go:generate go run ../mvsgen -spec ../../commands.json -cli commands.go
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"mvs_api"
+)
+
+const defaultUrl = "http://127.0.0.1:8820/rpc/v2"
+
+type command struct {
+	name   string
+	params []param
+	run    func(ctx context.Context, r *mvs_api.RPCClient, v values) (interface{}, error)
+}
+
+type param struct {
+	name, wire, typ, kind, doc string
+}
+
+func (p param) positional() bool {
+	return p.kind == "positional" || p.kind == "positional_optional"
+}
+
+// optional reports whether a positional parameter may be left out. The
+// admin ones are only checked by a node with administrator_required set.
+func (p param) optional() bool {
+	return p.kind == "positional_optional" || p.name == "ADMINNAME" || p.name == "ADMINAUTH"
+}
+
+func (p param) list() bool {
+	return p.typ == "[]string" || p.typ == "Receivers"
+}
+
+// values holds the value of every parameter of a command by its name.
+type values map[string]interface{}
+
+// Profile is one entry of the profile file.
+type Profile struct {
+	Url           string `json:"url"`
+	Timeout       string `json:"timeout"`
+	Account       string `json:"account"`
+	Password      string `json:"password"`
+	Admin         string `json:"admin"`
+	AdminPassword string `json:"admin_password"`
+}
+
+// credentials maps the parameters a profile can fill to their environment
+// variables.
+var credentials = map[string]string{
+	"ACCOUNTNAME": "MVS_ACCOUNT",
+	"ACCOUNTAUTH": "MVS_PASSWORD",
+	"ADMINNAME":   "MVS_ADMIN",
+	"ADMINAUTH":   "MVS_ADMIN_PASSWORD",
+}
+
+func (p *Profile) credential(name string) string {
+	if v := os.Getenv(credentials[name]); v != "" {
+		return v
+	}
+	switch name {
+	case "ACCOUNTNAME":
+		return p.Account
+	case "ACCOUNTAUTH":
+		return p.Password
+	case "ADMINNAME":
+		return p.Admin
+	case "ADMINAUTH":
+		return p.AdminPassword
+	}
+	return ""
+}
+
+func main() {
+	flag.Usage = usage
+	url := flag.String("url", "", "node URL (default $MVS_URL, the profile's, or "+defaultUrl+")")
+	profileName := flag.String("profile", os.Getenv("MVS_PROFILE"), "profile to use")
+	output := flag.String("o", "json", "output: json, raw or table")
+	timeout := flag.Duration("timeout", 0, "call timeout (default the profile's, or 30s)")
+	flag.Parse()
+
+	if flag.NArg() == 0 {
+		usage()
+		os.Exit(2)
+	}
+	if flag.Arg(0) == "help" {
+		if flag.NArg() > 1 {
+			if c := lookup(flag.Arg(1)); c != nil {
+				c.usage(os.Stdout)
+				return
+			}
+			fatalf("unknown command %q", flag.Arg(1))
+		}
+		flag.CommandLine.SetOutput(os.Stdout)
+		usage()
+		return
+	}
+	c := lookup(flag.Arg(0))
+	if c == nil {
+		fatalf("unknown command %q; see mvscli help", flag.Arg(0))
+	}
+	switch *output {
+	case "json", "raw", "table":
+	default:
+		fatalf("unknown output %q", *output)
+	}
+
+	profile, err := loadProfile(*profileName)
+	if err != nil {
+		fatalf("%v", err)
+	}
+	v, err := c.parse(flag.Args()[1:], profile)
+	if err == flag.ErrHelp {
+		return
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "mvscli %s: %v\n", c.name, err)
+		c.usage(os.Stderr)
+		os.Exit(2)
+	}
+
+	if *url == "" {
+		*url = os.Getenv("MVS_URL")
+	}
+	if *url == "" {
+		*url = profile.Url
+	}
+	if *url == "" {
+		*url = defaultUrl
+	}
+	if *timeout == 0 && profile.Timeout != "" {
+		if *timeout, err = time.ParseDuration(profile.Timeout); err != nil {
+			fatalf("profile timeout: %v", err)
+		}
+	}
+	if *timeout == 0 {
+		*timeout = 30 * time.Second
+	}
+	r, err := mvs_api.New(*url, mvs_api.WithTimeout(*timeout))
+	if err != nil {
+		fatalf("%v", err)
+	}
+
+	var raw json.RawMessage
+	result, err := c.run(mvs_api.WithRawResult(context.Background(), &raw), r, v)
+	if err != nil {
+		fatalf("%v", err)
+	}
+	if err := printResult(os.Stdout, result, raw, *output); err != nil {
+		fatalf("%v", err)
+	}
+}
+
+func usage() {
+	w := flag.CommandLine.Output()
+	fmt.Fprintf(w, "usage: mvscli [flags] command [flags] [args]\n\nflags:\n")
+	flag.PrintDefaults()
+	fmt.Fprintf(w, "\ncommands:\n")
+	names := make([]string, 0, len(commands))
+	for _, c := range commands {
+		names = append(names, c.name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(w, "  %s\n", name)
+	}
+	fmt.Fprintf(w, "\nRun \"mvscli help command\" for the parameters of a command.\n")
+}
+
+func fatalf(format string, args ...interface{}) {
+	fmt.Fprintf(os.Stderr, "mvscli: "+format+"\n", args...)
+	os.Exit(1)
+}
+
+func lookup(name string) *command {
+	for _, c := range commands {
+		if c.name == strings.ToLower(name) {
+			return c
+		}
+	}
+	return nil
+}
+
+// loadProfile reads profile name from the profile file. A missing file is
+// an empty profile, unless a profile was asked for by name.
+func loadProfile(name string) (*Profile, error) {
+	path := os.Getenv("MVS_PROFILE_FILE")
+	if path == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return &Profile{}, nil
+		}
+		path = filepath.Join(home, ".mvscli.json")
+	}
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) && name == "" {
+		return &Profile{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := checkPrivate(path); err != nil {
+		return nil, err
+	}
+	var profiles map[string]*Profile
+	if err := json.Unmarshal(data, &profiles); err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	if name == "" {
+		name = "default"
+		if profiles[name] == nil {
+			return &Profile{}, nil
+		}
+	}
+	p := profiles[name]
+	if p == nil {
+		return nil, fmt.Errorf("%s: no profile %q", path, name)
+	}
+	return p, nil
+}
+
+// checkPrivate refuses a profile file that users other than its owner can
+// read or write.
+func checkPrivate(path string) error {
+	if runtime.GOOS == "windows" {
+		return nil
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	if mode := info.Mode().Perm(); mode&0077 != 0 {
+		return fmt.Errorf("%s is open to other users (mode %04o) and may hold passwords; run chmod 600 %s", path, mode, path)
+	}
+	return nil
+}
+
+func (c *command) usage(w io.Writer) {
+	var args []string
+	for _, p := range c.params {
+		switch {
+		case !p.positional():
+		case p.optional():
+			args = append(args, "["+p.name+"]")
+		case p.list():
+			args = append(args, p.name+"...")
+		default:
+			args = append(args, p.name)
+		}
+	}
+	fmt.Fprintf(w, "usage: mvscli %s [flags] %s\n", c.name, strings.Join(args, " "))
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	for _, p := range c.params {
+		if !p.positional() {
+			continue
+		}
+		doc := p.doc
+		if env, ok := credentials[p.name]; ok {
+			doc += " Omitted when $" + env + " or the profile sets it."
+		}
+		fmt.Fprintf(tw, "  %s\t%s\t%s\n", p.name, p.typ, doc)
+	}
+	for _, p := range c.params {
+		if p.positional() {
+			continue
+		}
+		doc := p.doc
+		if p.kind == "required" {
+			doc = "(required) " + doc
+		}
+		fmt.Fprintf(tw, "  -%s\t%s\t%s\n", p.wire, p.typ, doc)
+	}
+	tw.Flush()
+}
+
+// flagValue collects the strings given for one flag.
+type flagValue struct {
+	typ string
+	raw []string
+}
+
+func (f *flagValue) String() string {
+	if f == nil {
+		return ""
+	}
+	return strings.Join(f.raw, ",")
+}
+
+func (f *flagValue) Set(s string) error {
+	f.raw = append(f.raw, s)
+	return nil
+}
+
+func (f *flagValue) IsBoolFlag() bool {
+	return f.typ == "bool"
+}
+
+// parse reads the arguments of c, filling credentials from profile.
+func (c *command) parse(args []string, profile *Profile) (values, error) {
+	fs := flag.NewFlagSet(c.name, flag.ContinueOnError)
+	fs.SetOutput(ioutil.Discard)
+	raw := map[string]*flagValue{}
+	for _, p := range c.params {
+		if !p.positional() {
+			raw[p.name] = &flagValue{typ: p.typ}
+			fs.Var(raw[p.name], p.wire, p.doc)
+		}
+	}
+	var rest []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			if err == flag.ErrHelp {
+				c.usage(os.Stdout)
+			}
+			return nil, err
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			break
+		}
+		rest, args = append(rest, args[0]), args[1:]
+	}
+
+	// Arguments go to the positional parameters in order; a list takes
+	// whatever the ones after it leave.
+	var positional []param
+	for _, p := range c.params {
+		if p.positional() {
+			if v := profile.credential(p.name); v != "" {
+				raw[p.name] = &flagValue{typ: p.typ, raw: []string{v}}
+				continue
+			}
+			positional = append(positional, p)
+		}
+	}
+	for i, p := range positional {
+		after := 0
+		for _, q := range positional[i+1:] {
+			if !q.optional() {
+				after++
+			}
+		}
+		n := 1
+		if p.list() {
+			n = len(rest) - after
+		}
+		if n > len(rest) || n < 1 {
+			if !p.optional() {
+				return nil, fmt.Errorf("missing %s", p.name)
+			}
+			continue
+		}
+		raw[p.name] = &flagValue{typ: p.typ, raw: rest[:n]}
+		rest = rest[n:]
+	}
+	if len(rest) > 0 {
+		return nil, fmt.Errorf("unexpected arguments %q", rest)
+	}
+
+	v := values{}
+	for _, p := range c.params {
+		var given []string
+		if f := raw[p.name]; f != nil {
+			given = f.raw
+		}
+		if p.kind == "required" && len(given) == 0 {
+			return nil, fmt.Errorf("-%s is required", p.wire)
+		}
+		value, err := parseValue(p.typ, given)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", p.name, err)
+		}
+		v[p.name] = value
+	}
+	return v, nil
+}
+
+// parseValue converts the strings given for a parameter of type typ, the
+// zero value if there are none.
+func parseValue(typ string, raw []string) (interface{}, error) {
+	var s string
+	if len(raw) > 0 {
+		s = raw[len(raw)-1]
+	}
+	var items []string
+	for _, r := range raw {
+		for _, item := range strings.Split(r, ",") {
+			if item != "" {
+				items = append(items, item)
+			}
+		}
+	}
+	unsigned := func(bits int) (uint64, error) {
+		if s == "" {
+			return 0, nil
+		}
+		return strconv.ParseUint(s, 10, bits)
+	}
+	switch typ {
+	case "string":
+		return s, nil
+	case "bool":
+		if s == "" {
+			return false, nil
+		}
+		return strconv.ParseBool(s)
+	case "int32":
+		if s == "" {
+			return int32(0), nil
+		}
+		n, err := strconv.ParseInt(s, 10, 32)
+		return int32(n), err
+	case "uint16":
+		n, err := unsigned(16)
+		return uint16(n), err
+	case "uint32":
+		n, err := unsigned(32)
+		return uint32(n), err
+	case "uint64":
+		return unsigned(64)
+	case "Amount":
+		n, err := unsigned(64)
+		return mvs_api.Amount(n), err
+	case "[]string":
+		if items == nil {
+			return []string(nil), nil
+		}
+		return items, nil
+	case "Receivers":
+		var receivers mvs_api.Receivers
+		for _, item := range items {
+			rc, err := mvs_api.ParseReceiver(item)
+			if err != nil {
+				return nil, err
+			}
+			receivers = append(receivers, rc)
+		}
+		return receivers, nil
+	case "[2]uint64":
+		var r [2]uint64
+		if s == "" {
+			return r, nil
+		}
+		i := strings.IndexByte(s, ':')
+		if i < 0 {
+			return nil, fmt.Errorf("%q is not from:to", s)
+		}
+		var err error
+		if r[0], err = strconv.ParseUint(s[:i], 10, 64); err == nil {
+			r[1], err = strconv.ParseUint(s[i+1:], 10, 64)
+		}
+		return r, err
+	}
+	return nil, fmt.Errorf("unsupported type %s", typ)
+}
+
+// printResult writes result in the output format; raw is the result as the
+// node sent it, for -o raw.
+func printResult(w io.Writer, result interface{}, raw json.RawMessage, output string) error {
+	if rv := reflect.ValueOf(result); rv.Kind() == reflect.Ptr && rv.IsNil() {
+		result = nil
+	} else if hex := hexOnly(rv); hex != "" {
+		result = hex
+	}
+	data, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+	switch output {
+	case "raw":
+		if len(raw) > 0 {
+			data = raw
+		}
+		_, err = fmt.Fprintf(w, "%s\n", data)
+	case "table":
+		err = table(w, data)
+	default:
+		var buf bytes.Buffer
+		if err := json.Indent(&buf, data, "", "  "); err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(w, "%s\n", buf.Bytes())
+	}
+	return err
+}
+
+// hexOnly is the Hex of a transaction or block asked for with json=false,
+// which then carries nothing else and leaves Hex out of its JSON.
+func hexOnly(rv reflect.Value) string {
+	rv = reflect.Indirect(rv)
+	if rv.Kind() != reflect.Struct {
+		return ""
+	}
+	if f := rv.FieldByName("Hex"); f.IsValid() && f.Kind() == reflect.String {
+		return f.String()
+	}
+	return ""
+}
+
+// table prints a list of objects as columns, an object as name and value
+// rows, and anything else one value per line.
+func table(w io.Writer, data []byte) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	var list []json.RawMessage
+	var object map[string]json.RawMessage
+	switch {
+	case json.Unmarshal(data, &list) == nil:
+		var columns []string
+		rows := make([]map[string]json.RawMessage, len(list))
+		for i, item := range list {
+			if json.Unmarshal(item, &rows[i]) != nil {
+				fmt.Fprintf(tw, "%s\n", cell(item))
+				continue
+			}
+			for _, key := range objectKeys(item) {
+				if !contains(columns, key) {
+					columns = append(columns, key)
+				}
+			}
+		}
+		if len(columns) > 0 {
+			fmt.Fprintf(tw, "%s\n", strings.ToUpper(strings.Join(columns, "\t")))
+		}
+		for _, row := range rows {
+			if row == nil {
+				continue
+			}
+			cells := make([]string, len(columns))
+			for i, column := range columns {
+				cells[i] = cell(row[column])
+			}
+			fmt.Fprintf(tw, "%s\n", strings.Join(cells, "\t"))
+		}
+	case json.Unmarshal(data, &object) == nil && object != nil:
+		for _, key := range objectKeys(data) {
+			fmt.Fprintf(tw, "%s\t%s\n", key, cell(object[key]))
+		}
+	default:
+		fmt.Fprintf(tw, "%s\n", cell(data))
+	}
+	return tw.Flush()
+}
+
+// cell is a JSON value as one table cell: strings unquoted, anything else
+// compact JSON.
+func cell(data json.RawMessage) string {
+	var s string
+	if json.Unmarshal(data, &s) == nil {
+		return s
+	}
+	if len(data) == 0 {
+		return ""
+	}
+	var buf bytes.Buffer
+	if json.Compact(&buf, data) != nil {
+		return string(data)
+	}
+	return buf.String()
+}
+
+// objectKeys lists the keys of a JSON object in the order they appear.
+func objectKeys(data []byte) []string {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if t, err := dec.Token(); err != nil || t != json.Delim('{') {
+		return nil
+	}
+	var keys []string
+	for dec.More() {
+		t, err := dec.Token()
+		if err != nil {
+			break
+		}
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			break
+		}
+		keys = append(keys, t.(string))
+	}
+	return keys
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,293 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+
+	"mvs_api"
+	"mvs_api/mvstest"
+)
+
+// listFirst has a list followed by required positionals, which no node
+// method has yet.
+var listFirst = &command{
+	name: "listfirst",
+	params: []param{
+		{name: "WORD", typ: "[]string", kind: "positional"},
+		{name: "NAME", typ: "string", kind: "positional"},
+		{name: "AMOUNT", typ: "Amount", kind: "positional"},
+		{name: "EXTRA", typ: "string", kind: "positional_optional"},
+		{name: "fee", wire: "fee", typ: "Amount", kind: "optional"},
+	},
+}
+
+func TestParse(t *testing.T) {
+	none := &Profile{}
+	alice := &Profile{Account: "Alice", Password: "A123456"}
+	tests := []struct {
+		c       *command
+		args    []string
+		profile *Profile
+		want    values
+		err     string
+	}{
+		{lookup("send"), []string{"Alice", "pw", "MKfq", "100"}, none,
+			values{"ACCOUNTNAME": "Alice", "ACCOUNTAUTH": "pw", "TOADDRESS": "MKfq", "AMOUNT": mvs_api.Amount(100), "memo": "", "fee": mvs_api.Amount(0)}, ""},
+		// Flags may come between the arguments.
+		{lookup("send"), []string{"Alice", "-fee", "20000", "pw", "MKfq", "-memo", "rent", "100"}, none,
+			values{"ACCOUNTNAME": "Alice", "ACCOUNTAUTH": "pw", "TOADDRESS": "MKfq", "AMOUNT": mvs_api.Amount(100), "memo": "rent", "fee": mvs_api.Amount(20000)}, ""},
+		// The profile fills the credentials, which then are not arguments.
+		{lookup("send"), []string{"MKfq", "100"}, alice,
+			values{"ACCOUNTNAME": "Alice", "ACCOUNTAUTH": "A123456", "TOADDRESS": "MKfq", "AMOUNT": mvs_api.Amount(100), "memo": "", "fee": mvs_api.Amount(0)}, ""},
+		{lookup("send"), []string{"Alice", "pw", "MKfq", "100"}, alice, nil, "unexpected arguments"},
+		{lookup("send"), []string{"MKfq"}, alice, nil, "missing AMOUNT"},
+		{lookup("send"), []string{"MKfq", "1.5"}, alice, nil, "AMOUNT"},
+		// The admin credentials may be left out.
+		{lookup("getinfo"), nil, none, values{"ADMINNAME": "", "ADMINAUTH": ""}, ""},
+		{listFirst, []string{"a", "b", "c", "Bob", "7"}, none,
+			values{"WORD": []string{"a", "b", "c"}, "NAME": "Bob", "AMOUNT": mvs_api.Amount(7), "EXTRA": "", "fee": mvs_api.Amount(0)}, ""},
+		{listFirst, []string{"a", "-fee", "5", "Bob", "7"}, none,
+			values{"WORD": []string{"a"}, "NAME": "Bob", "AMOUNT": mvs_api.Amount(7), "EXTRA": "", "fee": mvs_api.Amount(5)}, ""},
+		{listFirst, []string{"Bob", "7"}, none, nil, "missing WORD"},
+		{lookup("importaccount"), []string{"-accountname", "Bob", "-password", "pw", "w1", "w2"}, none,
+			values{"WORD": []string{"w1", "w2"}, "language": "", "accountname": "Bob", "password": "pw", "hd_index": uint32(0)}, ""},
+		{lookup("importaccount"), []string{"-password", "pw", "w1"}, none, nil, "-accountname is required"},
+		{lookup("createrawtx"), []string{"-type", "0", "-senders", "M1,M2", "-senders", "M3", "-receivers", "MLasJFxZQnA49XEvhTHmRKi2qstkj9ppjo:5", "-receivers", "M9L3ipy3Hcf6kdvknU3mH7mwH9ER3uCziu:6"}, none,
+			values{"type_": uint16(0), "senders": []string{"M1", "M2", "M3"}, "receivers": mvs_api.Receivers{{To: "MLasJFxZQnA49XEvhTHmRKi2qstkj9ppjo", Amount: 5}, {To: "M9L3ipy3Hcf6kdvknU3mH7mwH9ER3uCziu", Amount: 6}},
+				"symbol": "", "deposit": uint16(0), "mychange": "", "message": "", "fee": mvs_api.Amount(0)}, ""},
+		{lookup("send"), []string{"-nope", "MKfq", "1"}, alice, nil, "flag provided but not defined"},
+	}
+	for _, tt := range tests {
+		got, err := tt.c.parse(tt.args, tt.profile)
+		if tt.err != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("%s %q: err = %v, want %q", tt.c.name, tt.args, err, tt.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s %q: %v", tt.c.name, tt.args, err)
+		} else if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s %q = %#v, want %#v", tt.c.name, tt.args, got, tt.want)
+		}
+	}
+}
+
+func TestParseEnvironment(t *testing.T) {
+	t.Setenv("MVS_ACCOUNT", "Carol")
+	t.Setenv("MVS_PASSWORD", "C123456")
+	got, err := lookup("getbalance").parse(nil, &Profile{Account: "Alice", Password: "A123456"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got["ACCOUNTNAME"] != "Carol" || got["ACCOUNTAUTH"] != "C123456" {
+		t.Errorf("environment did not win over the profile: %v", got)
+	}
+}
+
+func TestParseValue(t *testing.T) {
+	tests := []struct {
+		typ  string
+		raw  []string
+		want interface{}
+		err  bool
+	}{
+		{"string", nil, "", false},
+		{"string", []string{"a", "b"}, "b", false},
+		{"bool", nil, false, false},
+		{"bool", []string{"true"}, true, false},
+		{"bool", []string{"yes"}, nil, true},
+		{"int32", []string{"-5"}, int32(-5), false},
+		{"uint16", []string{"65535"}, uint16(65535), false},
+		{"uint16", []string{"65536"}, nil, true},
+		{"uint32", nil, uint32(0), false},
+		{"uint64", []string{"18446744073709551615"}, uint64(18446744073709551615), false},
+		{"Amount", []string{"100000000"}, mvs_api.ETP, false},
+		{"Amount", []string{"-1"}, nil, true},
+		{"[]string", nil, []string(nil), false},
+		{"[]string", []string{"a,b", "", "c"}, []string{"a", "b", "c"}, false},
+		{"Receivers", []string{"BIAM:5"}, mvs_api.Receivers{{To: "BIAM", Amount: 5}}, false},
+		{"Receivers", []string{"BIAM"}, nil, true},
+		{"[2]uint64", []string{"10:20"}, [2]uint64{10, 20}, false},
+		{"[2]uint64", nil, [2]uint64{}, false},
+		{"[2]uint64", []string{"10"}, nil, true},
+		{"float64", []string{"1"}, nil, true},
+	}
+	for _, tt := range tests {
+		got, err := parseValue(tt.typ, tt.raw)
+		if tt.err {
+			if err == nil {
+				t.Errorf("parseValue(%s, %q) = %v, want an error", tt.typ, tt.raw, got)
+			}
+			continue
+		}
+		if err != nil || !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("parseValue(%s, %q) = %#v, %v; want %#v", tt.typ, tt.raw, got, err, tt.want)
+		}
+	}
+}
+
+func writeProfiles(t *testing.T, mode os.FileMode, profiles string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "mvscli.json")
+	if err := os.WriteFile(path, []byte(profiles), mode); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chmod(path, mode); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("MVS_PROFILE_FILE", path)
+	return path
+}
+
+func TestLoadProfile(t *testing.T) {
+	writeProfiles(t, 0600, `{
+		"default": {"url": "http://127.0.0.1:8820/rpc/v2", "account": "Alice", "password": "A123456"},
+		"testnet": {"url": "http://127.0.0.1:18820/rpc/v2", "timeout": "1m"}
+	}`)
+	tests := []struct {
+		name string
+		want *Profile
+		err  bool
+	}{
+		{"", &Profile{Url: "http://127.0.0.1:8820/rpc/v2", Account: "Alice", Password: "A123456"}, false},
+		{"default", &Profile{Url: "http://127.0.0.1:8820/rpc/v2", Account: "Alice", Password: "A123456"}, false},
+		{"testnet", &Profile{Url: "http://127.0.0.1:18820/rpc/v2", Timeout: "1m"}, false},
+		{"mainnet", nil, true},
+	}
+	for _, tt := range tests {
+		got, err := loadProfile(tt.name)
+		if (err != nil) != tt.err || !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("loadProfile(%q) = %+v, %v", tt.name, got, err)
+		}
+	}
+
+	got, err := lookup("getbalance").parse(nil, tests[0].want)
+	if err != nil || got["ACCOUNTNAME"] != "Alice" || got["ACCOUNTAUTH"] != "A123456" {
+		t.Errorf("credentials from the profile: %v, %v", got, err)
+	}
+}
+
+func TestLoadProfileMissing(t *testing.T) {
+	t.Setenv("MVS_PROFILE_FILE", filepath.Join(t.TempDir(), "none.json"))
+	if p, err := loadProfile(""); err != nil || !reflect.DeepEqual(p, &Profile{}) {
+		t.Errorf("no file: %+v, %v", p, err)
+	}
+	if _, err := loadProfile("testnet"); err == nil {
+		t.Error("no file: loaded a named profile")
+	}
+	writeProfiles(t, 0600, `{"testnet": {}}`)
+	if p, err := loadProfile(""); err != nil || !reflect.DeepEqual(p, &Profile{}) {
+		t.Errorf("no default profile: %+v, %v", p, err)
+	}
+	writeProfiles(t, 0600, `{"default": [`)
+	if _, err := loadProfile(""); err == nil {
+		t.Error("loaded bad JSON")
+	}
+}
+
+func TestLoadProfilePermissions(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("no Unix permissions")
+	}
+	for _, mode := range []os.FileMode{0644, 0640, 0604, 0620} {
+		path := writeProfiles(t, mode, `{"default": {"password": "A123456"}}`)
+		if _, err := loadProfile(""); err == nil || !strings.Contains(err.Error(), "chmod 600 "+path) {
+			t.Errorf("mode %04o: err = %v", mode, err)
+		}
+	}
+	writeProfiles(t, 0400, `{"default": {"password": "A123456"}}`)
+	if _, err := loadProfile(""); err != nil {
+		t.Errorf("mode 0400: %v", err)
+	}
+}
+
+func TestPrintResult(t *testing.T) {
+	result := []string{"MLasJFxZQnA49XEvhTHmRKi2qstkj9ppjo"}
+	raw := json.RawMessage(`{"addresses": ["MLasJFxZQnA49XEvhTHmRKi2qstkj9ppjo"]}`)
+	tests := []struct {
+		output string
+		raw    json.RawMessage
+		want   string
+	}{
+		{"json", raw, "[\n  \"MLasJFxZQnA49XEvhTHmRKi2qstkj9ppjo\"\n]\n"},
+		{"raw", raw, string(raw) + "\n"},
+		{"raw", nil, `["MLasJFxZQnA49XEvhTHmRKi2qstkj9ppjo"]` + "\n"},
+		{"table", raw, "MLasJFxZQnA49XEvhTHmRKi2qstkj9ppjo\n"},
+	}
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		if err := printResult(&buf, result, tt.raw, tt.output); err != nil {
+			t.Errorf("-o %s: %v", tt.output, err)
+		} else if buf.String() != tt.want {
+			t.Errorf("-o %s printed %q, want %q", tt.output, buf.String(), tt.want)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := printResult(&buf, []map[string]interface{}{{"a": 1, "b": "x"}, {"a": 2}}, nil, "table"); err != nil {
+		t.Fatal(err)
+	}
+	if want := "A  B\n1  x\n2  \n"; buf.String() != want {
+		t.Errorf("table printed %q, want %q", buf.String(), want)
+	}
+	if err := printResult(&buf, (*mvs_api.Transaction)(nil), nil, "json"); err != nil || !strings.HasSuffix(buf.String(), "null\n") {
+		t.Errorf("nil result printed %q, %v", buf.String(), err)
+	}
+}
+
+func TestRunRawForms(t *testing.T) {
+	node := mvstest.NewNode()
+	defer node.Close()
+	hash := node.Fund(mvstest.Address, 5*mvs_api.ETP)
+	node.Mine(1)
+
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{"gettx", []string{"false", hash}},
+		{"getblock", []string{"1", "false", "false"}},
+	}
+	for _, tt := range tests {
+		c := lookup(tt.name)
+		v, err := c.parse(tt.args, &Profile{})
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		var raw json.RawMessage
+		result, err := c.run(mvs_api.WithRawResult(context.Background(), &raw), node.Client(), v)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		var hex string
+		switch r := result.(type) {
+		case *mvs_api.Transaction:
+			hex = r.Hex
+		case *mvs_api.Block:
+			hex = r.Hex
+		}
+		if hex == "" {
+			t.Fatalf("%s %v returned no hex: %+v", tt.name, tt.args, result)
+		}
+		for output, want := range map[string]string{
+			"json":  `"` + hex + `"` + "\n",
+			"table": hex + "\n",
+			"raw":   string(raw) + "\n",
+		} {
+			var buf bytes.Buffer
+			if err := printResult(&buf, result, raw, output); err != nil {
+				t.Errorf("%s -o %s: %v", tt.name, output, err)
+			} else if buf.String() != want {
+				t.Errorf("%s -o %s printed %q, want %q", tt.name, output, buf.String(), want)
+			}
+		}
+	}
+}
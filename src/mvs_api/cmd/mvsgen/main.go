@@ -0,0 +1,328 @@
+// Command mvsgen writes the RPCClient methods of mvs_api.go from the command
+// spec in commands.json. Everything after the "auto-generate code begin"
+// line is replaced; the code above it is kept as is.
+//
+//	go run ./cmd/mvsgen -spec commands.json -out mvs_api.go
+//
+// A command lists its parameters in the order of the Go signature. Each
+// parameter has a kind:
+//
+//	positional           always sent as a positional argument
+//	positional_optional  sent as a trailing positional argument when set
+//	required             always sent in the options object
+//	optional             sent in the options object when not the zero value
+//	flag                 a bool sent as "--wire" when true
+//
+// wire is the name mvsd knows the parameter by when it differs from the Go
+// name, and join, for a []string positional, joins the list into one word.
+//
+// Every command with parameters that may be left out also gets an options
+// struct holding them, SendOptions for Send, and a method taking it,
+// SendWithOptions.
+//
+// With -cli, mvsgen instead writes the command table of cmd/mvscli, one
+// subcommand per method:
+//
+//	go run ../mvsgen -spec ../../commands.json -cli commands.go
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"log"
+	"strings"
+)
+
+const marker = "// auto-generate code begin\n"
+
+type Spec struct {
+	Commands []Command `json:"commands"`
+}
+
+type Command struct {
+	Name      string  `json:"name"`
+	Method    string  `json:"method"`
+	Result    string  `json:"result"`
+	ResultKey string  `json:"result_key,omitempty"`
+	Params    []Param `json:"params"`
+}
+
+type Param struct {
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	Kind    string `json:"kind"`
+	Wire    string `json:"wire,omitempty"`
+	Join    string `json:"join,omitempty"`
+	CppType string `json:"cpp_type"`
+	Doc     string `json:"doc"`
+}
+
+func (p Param) wire() string {
+	if p.Wire != "" {
+		return p.Wire
+	}
+	return p.Name
+}
+
+// zero is the value an optional parameter of type t has when it is not set.
+var zero = map[string]string{
+	"string":    `""`,
+	"bool":      "false",
+	"int32":     "0",
+	"uint16":    "0",
+	"uint32":    "0",
+	"uint64":    "0",
+	"Amount":    "0",
+	"[]string":  "nil",
+	"Receivers": "nil",
+	"[2]uint64": "[2]uint64{0, 0}",
+}
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("mvsgen: ")
+	specFile := flag.String("spec", "commands.json", "command spec")
+	outFile := flag.String("out", "mvs_api.go", "file to rewrite after the generated code marker")
+	cliFile := flag.String("cli", "", "write the mvscli command table to this file instead")
+	flag.Parse()
+
+	data, err := ioutil.ReadFile(*specFile)
+	if err != nil {
+		log.Fatal(err)
+	}
+	var spec Spec
+	if err := json.Unmarshal(data, &spec); err != nil {
+		log.Fatalf("%s: %v", *specFile, err)
+	}
+
+	if *cliFile != "" {
+		src, err := generateCLI(spec)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if err := ioutil.WriteFile(*cliFile, src, 0644); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	src, err := ioutil.ReadFile(*outFile)
+	if err != nil {
+		log.Fatal(err)
+	}
+	src, err = rewrite(src, spec)
+	if err != nil {
+		log.Fatalf("%s: %v", *outFile, err)
+	}
+	if err := ioutil.WriteFile(*outFile, src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// rewrite replaces the generated code of src, everything after marker.
+func rewrite(src []byte, spec Spec) ([]byte, error) {
+	i := bytes.Index(src, []byte(marker))
+	if i < 0 {
+		return nil, fmt.Errorf("no %q line", strings.TrimSpace(marker))
+	}
+	var buf bytes.Buffer
+	buf.Write(src[:i+len(marker)])
+	for _, c := range spec.Commands {
+		if err := generate(&buf, c); err != nil {
+			return nil, fmt.Errorf("%s: %v", c.Name, err)
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+func generate(w *bytes.Buffer, c Command) error {
+	var sig, names, positional []string
+	var positionalOptional, required, optional, flags []Param
+	for _, p := range c.Params {
+		if _, ok := zero[p.Type]; !ok {
+			return fmt.Errorf("%s: unsupported type %s", p.Name, p.Type)
+		}
+		sig = append(sig, p.Name+" "+p.Type)
+		names = append(names, p.Name)
+		switch p.Kind {
+		case "positional":
+			if p.Join != "" {
+				positional = append(positional, fmt.Sprintf("strings.Join(%s, %q)", p.Name, p.Join))
+			} else {
+				positional = append(positional, p.Name)
+			}
+		case "positional_optional":
+			positionalOptional = append(positionalOptional, p)
+		case "required":
+			required = append(required, p)
+		case "optional":
+			optional = append(optional, p)
+		case "flag":
+			if p.Type != "bool" {
+				return fmt.Errorf("%s: flag must be a bool", p.Name)
+			}
+			flags = append(flags, p)
+		default:
+			return fmt.Errorf("%s: unknown kind %q", p.Name, p.Kind)
+		}
+	}
+
+	fmt.Fprintf(w, "\n/*\n")
+	for _, p := range c.Params {
+		lines := strings.Split(p.Doc, "\n")
+		fmt.Fprintf(w, "   :param: %s(%s): %s\n", p.wire(), p.CppType, lines[0])
+		for _, line := range lines[1:] {
+			fmt.Fprintf(w, "   %s\n", line)
+		}
+	}
+	fmt.Fprintf(w, "*/\n")
+	fmt.Fprintf(w, "func (r *RPCClient) %s(%s) (%s, error) {\n", c.Method, strings.Join(sig, ", "), c.Result)
+	fmt.Fprintf(w, "\treturn r.%sContext(%s)\n}\n\n", c.Method, strings.Join(append([]string{"context.Background()"}, names...), ", "))
+
+	fmt.Fprintf(w, "// %sContext is like %s but honours the deadline and cancellation of ctx.\n", c.Method, c.Method)
+	fmt.Fprintf(w, "func (r *RPCClient) %sContext(%s) (%s, error) {\n", c.Method, strings.Join(append([]string{"ctx context.Context"}, sig...), ", "), c.Result)
+	fmt.Fprintf(w, "\tcmd := %q\n", c.Name)
+	fmt.Fprintf(w, "\tpositional := []interface{}{%s}\n", strings.Join(positional, ", "))
+	for _, p := range positionalOptional {
+		fmt.Fprintf(w, "\tif %s != %s {\n\t\tpositional = append(positional, %s)\n\t}\n", p.Name, zero[p.Type], p.Name)
+	}
+	if len(positionalOptional) == 0 {
+		fmt.Fprintf(w, "\n")
+	}
+
+	if len(required) == 0 {
+		fmt.Fprintf(w, "\toptional := map[string]interface{}{}\n")
+	} else {
+		width := 0
+		for _, p := range required {
+			if n := len(p.wire()) + 3; n > width {
+				width = n
+			}
+		}
+		fmt.Fprintf(w, "\toptional := map[string]interface{}{\n")
+		for _, p := range required {
+			fmt.Fprintf(w, "\t\t%-*s %s,\n", width, fmt.Sprintf("%q:", p.wire()), p.Name)
+		}
+		fmt.Fprintf(w, "\t}\n")
+	}
+	for _, p := range flags {
+		fmt.Fprintf(w, "\tif %s == true {\n\t\tpositional = append(positional, \"--%s\")\n\t}\n", p.Name, p.wire())
+	}
+	if len(flags) == 0 || len(optional) == 0 {
+		fmt.Fprintf(w, "\n")
+	}
+	for _, p := range optional {
+		fmt.Fprintf(w, "\tif %s != %s {\n\t\toptional[%q] = %s\n\t}\n", p.Name, zero[p.Type], p.wire(), value(p))
+	}
+
+	fmt.Fprintf(w, "\targs := append(positional, optional)\n")
+	fmt.Fprintf(w, "\tvar result %s\n", c.Result)
+	fmt.Fprintf(w, "\terr := r.call(ctx, cmd, args, %q, &result)\n", c.ResultKey)
+	fmt.Fprintf(w, "\treturn result, err\n}\n")
+	return generateOptions(w, c)
+}
+
+// generateOptions writes the options struct of c, holding the parameters
+// that mvsd does not require, and the method taking it.
+func generateOptions(w *bytes.Buffer, c Command) error {
+	var sig, args []string
+	var fields []Param
+	seen := map[string]bool{}
+	for _, p := range c.Params {
+		switch p.Kind {
+		case "positional", "required":
+			sig = append(sig, p.Name+" "+p.Type)
+			args = append(args, p.Name)
+		default:
+			field := fieldName(p.Name)
+			if seen[field] {
+				return fmt.Errorf("%s: option %s given twice", p.Name, field)
+			}
+			seen[field] = true
+			fields = append(fields, p)
+			args = append(args, "opts."+field)
+		}
+	}
+	if len(fields) == 0 {
+		return nil
+	}
+
+	fmt.Fprintf(w, "\n// %sOptions holds the parameters of %s that may be left out.\n", c.Method, c.Method)
+	fmt.Fprintf(w, "type %sOptions struct {\n", c.Method)
+	for _, p := range fields {
+		if doc := strings.Trim(strings.TrimSpace(strings.Split(p.Doc, "\n")[0]), `"`); doc != "" {
+			fmt.Fprintf(w, "\t// %s\n", doc)
+		}
+		fmt.Fprintf(w, "\t%s %s\n", fieldName(p.Name), p.Type)
+	}
+	fmt.Fprintf(w, "}\n\n")
+	fmt.Fprintf(w, "// %sWithOptions is %sContext with the parameters that may be left out\n// in opts, which may be nil.\n", c.Method, c.Method)
+	fmt.Fprintf(w, "func (r *RPCClient) %sWithOptions(%s) (%s, error) {\n", c.Method, strings.Join(append(append([]string{"ctx context.Context"}, sig...), "opts *"+c.Method+"Options"), ", "), c.Result)
+	fmt.Fprintf(w, "\tif opts == nil {\n\t\topts = &%sOptions{}\n\t}\n", c.Method)
+	fmt.Fprintf(w, "\treturn r.%sContext(%s)\n}\n", c.Method, strings.Join(append([]string{"ctx"}, args...), ", "))
+	return nil
+}
+
+// fieldName is the exported Go name of parameter name: hd_index is HdIndex
+// and DESTINATION is Destination.
+func fieldName(name string) string {
+	var b strings.Builder
+	for _, part := range strings.Split(name, "_") {
+		if part == "" {
+			continue
+		}
+		if strings.ToUpper(part) == part {
+			part = strings.ToLower(part)
+		}
+		b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	return b.String()
+}
+
+// value is the expression sent for an optional parameter.
+func value(p Param) string {
+	if p.Type == "[2]uint64" {
+		// A range is sent as "from:to".
+		return fmt.Sprintf("strings.Join([]string{strconv.FormatUint(uint64(%s[0]), 10), strconv.FormatUint(uint64(%s[1]), 10)}, \":\")", p.Name, p.Name)
+	}
+	return p.Name
+}
+
+// generateCLI writes the commands table of cmd/mvscli: the parameters of
+// each command, and a function calling its method with the parsed values.
+func generateCLI(spec Spec) ([]byte, error) {
+	var w bytes.Buffer
+	fmt.Fprintf(&w, "// Code generated by mvsgen from commands.json; DO NOT EDIT.\n\n")
+	fmt.Fprintf(&w, "package main\n\nimport (\n\t\"context\"\n\n\t\"mvs_api\"\n)\n\n")
+	fmt.Fprintf(&w, "var commands = []*command{\n")
+	for _, c := range spec.Commands {
+		args := []string{"ctx"}
+		fmt.Fprintf(&w, "{\nname: %q,\nparams: []param{\n", c.Name)
+		for _, p := range c.Params {
+			if _, ok := zero[p.Type]; !ok {
+				return nil, fmt.Errorf("%s: %s: unsupported type %s", c.Name, p.Name, p.Type)
+			}
+			doc := strings.Trim(strings.TrimSpace(strings.Split(p.Doc, "\n")[0]), `"`)
+			fmt.Fprintf(&w, "{name: %q, wire: %q, typ: %q, kind: %q, doc: %q},\n", p.Name, p.wire(), p.Type, p.Kind, doc)
+			args = append(args, fmt.Sprintf("v[%q].(%s)", p.Name, qualify(p.Type)))
+		}
+		fmt.Fprintf(&w, "},\n")
+		fmt.Fprintf(&w, "run: func(ctx context.Context, r *mvs_api.RPCClient, v values) (interface{}, error) {\n")
+		fmt.Fprintf(&w, "return r.%sContext(%s)\n},\n},\n", c.Method, strings.Join(args, ", "))
+	}
+	fmt.Fprintf(&w, "}\n")
+	return format.Source(w.Bytes())
+}
+
+// qualify is type t as written outside package mvs_api.
+func qualify(t string) string {
+	switch t {
+	case "Amount", "Receivers":
+		return "mvs_api." + t
+	}
+	return t
+}
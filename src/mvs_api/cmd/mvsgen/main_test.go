@@ -0,0 +1,136 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+func readSpec(t *testing.T) Spec {
+	t.Helper()
+	data, err := ioutil.ReadFile("../../commands.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var spec Spec
+	if err := json.Unmarshal(data, &spec); err != nil {
+		t.Fatal(err)
+	}
+	return spec
+}
+
+// The committed files must be what go generate writes, so that a change of
+// commands.json cannot be forgotten.
+func TestGoldenClient(t *testing.T) {
+	src, err := ioutil.ReadFile("../../mvs_api.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := rewrite(src, readSpec(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, src) {
+		t.Error("mvs_api.go is stale, run go generate in mvs_api")
+	}
+}
+
+func TestGoldenCLI(t *testing.T) {
+	want, err := ioutil.ReadFile("../mvscli/commands.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := generateCLI(readSpec(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Error("cmd/mvscli/commands.go is stale, run go generate in cmd/mvscli")
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	c := Command{Name: "send", Method: "Send", Result: "*Transaction", ResultKey: "transaction", Params: []Param{
+		{Name: "ACCOUNTNAME", Type: "string", Kind: "positional"},
+		{Name: "WORD", Type: "[]string", Kind: "positional", Join: " "},
+		{Name: "DESTINATION", Type: "string", Kind: "positional_optional"},
+		{Name: "accountname", Type: "string", Kind: "required"},
+		{Name: "type_", Type: "uint16", Kind: "optional", Wire: "type"},
+		{Name: "height", Type: "[2]uint64", Kind: "optional"},
+		{Name: "broadcast", Type: "bool", Kind: "flag"},
+	}}
+	var buf bytes.Buffer
+	if err := generate(&buf, c); err != nil {
+		t.Fatal(err)
+	}
+	src := buf.String()
+	for _, want := range []string{
+		"func (r *RPCClient) SendContext(ctx context.Context, ACCOUNTNAME string, WORD []string, DESTINATION string, accountname string, type_ uint16, height [2]uint64, broadcast bool) (*Transaction, error) {",
+		`positional := []interface{}{ACCOUNTNAME, strings.Join(WORD, " ")}`,
+		"if DESTINATION != \"\" {\n\t\tpositional = append(positional, DESTINATION)",
+		`"accountname": accountname,`,
+		"if type_ != 0 {\n\t\toptional[\"type\"] = type_",
+		"if broadcast == true {\n\t\tpositional = append(positional, \"--broadcast\")",
+		`err := r.call(ctx, cmd, args, "transaction", &result)`,
+		"type SendOptions struct {",
+		"\tDestination string\n\tType uint16\n\tHeight [2]uint64\n\tBroadcast bool\n}",
+		"func (r *RPCClient) SendWithOptions(ctx context.Context, ACCOUNTNAME string, WORD []string, accountname string, opts *SendOptions) (*Transaction, error) {",
+		"return r.SendContext(ctx, ACCOUNTNAME, WORD, opts.Destination, accountname, opts.Type, opts.Height, opts.Broadcast)",
+	} {
+		if !strings.Contains(src, want) {
+			t.Errorf("generated code lacks %q:\n%s", want, src)
+		}
+	}
+
+	buf.Reset()
+	generate(&buf, Command{Name: "getinfo", Method: "Getinfo", Result: "*Info", Params: []Param{{Name: "ADMINNAME", Type: "string", Kind: "positional"}}})
+	if strings.Contains(buf.String(), "Options") {
+		t.Error("options generated for a command without any")
+	}
+}
+
+func TestGenerateErrors(t *testing.T) {
+	tests := []struct {
+		param Param
+		err   string
+	}{
+		{Param{Name: "x", Type: "float64", Kind: "optional"}, "unsupported type"},
+		{Param{Name: "x", Type: "string", Kind: "sometimes"}, "unknown kind"},
+		{Param{Name: "x", Type: "string", Kind: "flag"}, "flag must be a bool"},
+	}
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		err := generate(&buf, Command{Name: "x", Method: "X", Result: "string", Params: []Param{tt.param}})
+		if err == nil || !strings.Contains(err.Error(), tt.err) {
+			t.Errorf("%+v: err = %v, want %q", tt.param, err, tt.err)
+		}
+	}
+	var buf bytes.Buffer
+	err := generate(&buf, Command{Name: "x", Method: "X", Result: "string", Params: []Param{
+		{Name: "symbol", Type: "string", Kind: "optional"},
+		{Name: "SYMBOL", Type: "string", Kind: "positional_optional"},
+	}})
+	if err == nil || !strings.Contains(err.Error(), "given twice") {
+		t.Errorf("clashing options: err = %v", err)
+	}
+	if _, err := rewrite([]byte("package mvs_api\n"), Spec{}); err == nil {
+		t.Error("rewrote a file without the marker")
+	}
+}
+
+func TestFieldName(t *testing.T) {
+	for name, want := range map[string]string{
+		"fee":           "Fee",
+		"type_":         "Type",
+		"hd_index":      "HdIndex",
+		"greater_equal": "GreaterEqual",
+		"DESTINATION":   "Destination",
+		"selfpublickey": "Selfpublickey",
+	} {
+		if got := fieldName(name); got != want {
+			t.Errorf("fieldName(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
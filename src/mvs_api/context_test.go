@@ -0,0 +1,85 @@
+package mvs_api_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"mvs_api"
+	"mvs_api/mvstest"
+)
+
+// block makes method wait until the returned function is called, and
+// reports on entered each time a call arrives.
+func block(s *mvstest.Server, method string) (entered chan struct{}, release func()) {
+	entered, done := make(chan struct{}, 16), make(chan struct{})
+	s.Handle(method, func(mvstest.Call) (interface{}, error) {
+		entered <- struct{}{}
+		<-done
+		return 1, nil
+	})
+	return entered, func() { close(done) }
+}
+
+func TestContextCancelInFlight(t *testing.T) {
+	s := mvstest.NewServer()
+	defer s.Close()
+	entered, release := block(s, "getheight")
+	defer release()
+	r := s.Client()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errc := make(chan error, 1)
+	go func() {
+		_, err := r.GetheightContext(ctx, "", "")
+		errc <- err
+	}()
+	<-entered
+	cancel()
+	select {
+	case err := <-errc:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("err = %v, want context.Canceled", err)
+		}
+		if mvs_api.Classify(err) != mvs_api.ErrorClassCanceled {
+			t.Errorf("class = %v", mvs_api.Classify(err))
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("the call did not return after cancel")
+	}
+	if st := r.Stats(); st.Failures != 0 || st.State != mvs_api.Healthy {
+		t.Errorf("a cancelled call counted against the node: %+v", st)
+	}
+}
+
+func TestContextDeadline(t *testing.T) {
+	s := mvstest.NewServer()
+	defer s.Close()
+	_, release := block(s, "send")
+	defer release()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err := s.Client().SendContext(ctx, "Alice", "A123456", mvstest.Address, 1, "", 0)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("err = %v, want context.DeadlineExceeded", err)
+	}
+	if d := time.Since(start); d > 5*time.Second {
+		t.Errorf("the call took %v", d)
+	}
+}
+
+func TestContextCanceledBeforeCall(t *testing.T) {
+	s := mvstest.NewServer()
+	defer s.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := s.Client().GetheightContext(ctx, "", ""); !errors.Is(err, context.Canceled) {
+		t.Errorf("err = %v, want context.Canceled", err)
+	}
+	if n := len(s.Calls("getheight")); n != 0 {
+		t.Errorf("%d calls reached the node", n)
+	}
+}
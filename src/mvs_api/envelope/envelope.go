@@ -0,0 +1,620 @@
+// Package envelope is a file format for passing a partly signed multisig
+// transaction between cosigners on different machines, and for collecting
+// their signatures until it can be sent.
+//
+// An envelope is saved as JSON, where binary values are hex:
+//
+//	{
+//	  "version": 1,
+//	  "description": "pay the auditors",
+//	  "tx": "0400000001...",
+//	  "redeem_script": "5221...53ae",
+//	  "m": 2,
+//	  "public_keys": ["0380...", "0257...", "03af..."],
+//	  "signatures": [
+//	    {"input": 0, "public_key": "0257...", "signature": "3044...01"}
+//	  ]
+//	}
+//
+// tx is the transaction from Createmultisigtx with every input script
+// empty, so that it does not change as signatures are added. redeem_script
+// is the serialized redeem script, as Multisig.RedeemScript builds it; the
+// multisig-script of Getnewmultisig and Listmultisig is a text rendering
+// of it and is not accepted. m and public_keys are read from the redeem
+// script, in its order. Each signature is a DER signature with its hash
+// type byte, of one input by one key. Hex is read in either case and
+// written in lower case.
+//
+// MarshalBinary gives a compact form of the same envelope for channels
+// that carry bytes, such as QR codes; Parse reads both forms. All integers
+// in it are unsigned varints and all byte strings are preceded by their
+// varint length:
+//
+//	magic        "MVSE"
+//	version      1
+//	description  string
+//	tx           bytes
+//	redeem       bytes
+//	count        number of signatures, then for each:
+//	  input      input index
+//	  key        index of the public key in the redeem script
+//	  signature  bytes
+//
+// A cosigner with the key in mvsd passes PartialTx to Signmultisigtx and
+// the result to AddSignedTx; one with the key at hand calls Sign. Once M
+// keys have signed every input, Finalize gives the transaction for
+// Sendrawtx.
+package envelope
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	"mvs_api/keys"
+	"mvs_api/rawtx"
+)
+
+// Version is the format version this package writes and reads.
+const Version = 1
+
+// magic starts the binary form.
+const magic = "MVSE"
+
+var (
+	ErrMismatch          = errors.New("envelope: envelopes are for different transactions")
+	ErrMissingSignatures = errors.New("envelope: missing signatures")
+	ErrNoInputs          = errors.New("envelope: tx has no inputs")
+)
+
+type Envelope struct {
+	Version      int         `json:"version"`
+	Description  string      `json:"description,omitempty"`
+	Tx           string      `json:"tx"`
+	RedeemScript string      `json:"redeem_script"`
+	M            int         `json:"m"`
+	PublicKeys   []string    `json:"public_keys"`
+	Signatures   []Signature `json:"signatures"`
+}
+
+type Signature struct {
+	Input     int    `json:"input"`
+	PublicKey string `json:"public_key"`
+	Signature string `json:"signature"`
+}
+
+// New wraps tx, spending from the address of redeemScript, both hex. Any
+// signatures already in tx are moved into the envelope.
+func New(tx, redeemScript, description string) (*Envelope, error) {
+	decoded, err := rawtx.Decode(tx)
+	if err != nil {
+		return nil, err
+	}
+	redeem, err := hex.DecodeString(redeemScript)
+	if err != nil {
+		return nil, fmt.Errorf("envelope: redeem script: %v", err)
+	}
+	m, pubs, err := rawtx.ParseMultisig(redeem)
+	if err != nil {
+		return nil, err
+	}
+	e := &Envelope{
+		Version:      Version,
+		Description:  description,
+		RedeemScript: hex.EncodeToString(redeem),
+		M:            m,
+		Signatures:   []Signature{},
+	}
+	for _, pub := range pubs {
+		e.PublicKeys = append(e.PublicKeys, pub.String())
+	}
+	if err := e.collect(decoded, redeem); err != nil {
+		return nil, err
+	}
+	for i := range decoded.Inputs {
+		decoded.Inputs[i].Script = nil
+	}
+	if e.Tx, err = decoded.Hex(); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// Parse reads and verifies an envelope in JSON or binary form.
+func Parse(data []byte) (*Envelope, error) {
+	e := &Envelope{}
+	if bytes.HasPrefix(data, []byte(magic)) {
+		if err := e.UnmarshalBinary(data); err != nil {
+			return nil, err
+		}
+		return e, nil
+	}
+	if err := json.Unmarshal(data, e); err != nil {
+		return nil, fmt.Errorf("envelope: %v", err)
+	}
+	e.normalize()
+	if err := e.Verify(); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+func Load(path string) (*Envelope, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(data)
+}
+
+func (e *Envelope) Marshal() ([]byte, error) {
+	data, err := json.MarshalIndent(e, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+func (e *Envelope) Save(path string) error {
+	data, err := e.Marshal()
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, data, 0644)
+}
+
+// MarshalBinary writes e in the binary form of the package doc.
+func (e *Envelope) MarshalBinary() ([]byte, error) {
+	tx, redeem, err := e.decode()
+	if err != nil {
+		return nil, err
+	}
+	encoded, err := tx.Encode()
+	if err != nil {
+		return nil, err
+	}
+	w := &writer{}
+	w.buf.WriteString(magic)
+	w.uint(uint64(e.Version))
+	w.bytes([]byte(e.Description))
+	w.bytes(encoded)
+	w.bytes(redeem)
+	w.uint(uint64(len(e.Signatures)))
+	for _, sig := range e.Signatures {
+		key := e.keyIndex(sig.PublicKey)
+		if key < 0 {
+			return nil, fmt.Errorf("envelope: %s is not a key of the redeem script", sig.PublicKey)
+		}
+		b, err := hex.DecodeString(sig.Signature)
+		if err != nil {
+			return nil, fmt.Errorf("envelope: signature of input %d: %v", sig.Input, err)
+		}
+		w.uint(uint64(sig.Input))
+		w.uint(uint64(key))
+		w.bytes(b)
+	}
+	return w.buf.Bytes(), nil
+}
+
+// UnmarshalBinary reads the binary form into e and verifies it.
+func (e *Envelope) UnmarshalBinary(data []byte) error {
+	if !bytes.HasPrefix(data, []byte(magic)) {
+		return errors.New("envelope: not a binary envelope")
+	}
+	r := &reader{data: data[len(magic):]}
+	version := r.uint()
+	if r.err == nil && version != Version {
+		return fmt.Errorf("envelope: unsupported version %d", version)
+	}
+	description, tx, redeem := r.bytes(), r.bytes(), r.bytes()
+	if r.err != nil {
+		return r.err
+	}
+	m, pubs, err := rawtx.ParseMultisig(redeem)
+	if err != nil {
+		return err
+	}
+	decoded := Envelope{
+		Version:      Version,
+		Description:  string(description),
+		Tx:           hex.EncodeToString(tx),
+		RedeemScript: hex.EncodeToString(redeem),
+		M:            m,
+		Signatures:   []Signature{},
+	}
+	for _, pub := range pubs {
+		decoded.PublicKeys = append(decoded.PublicKeys, pub.String())
+	}
+	for n := r.uint(); n > 0 && r.err == nil; n-- {
+		input, key, sig := r.uint(), r.uint(), r.bytes()
+		if r.err == nil && (input >= 1<<31 || key >= uint64(len(pubs))) {
+			return fmt.Errorf("envelope: signature of input %d by key %d", input, key)
+		}
+		decoded.Signatures = append(decoded.Signatures, Signature{
+			Input:     int(input),
+			PublicKey: decoded.PublicKeys[key],
+			Signature: hex.EncodeToString(sig),
+		})
+	}
+	if r.err != nil {
+		return r.err
+	}
+	if len(r.data) != 0 {
+		return errors.New("envelope: data after the signatures")
+	}
+	if err := decoded.Verify(); err != nil {
+		return err
+	}
+	*e = decoded
+	return nil
+}
+
+// normalize lower cases the hex of e, which is read in either case.
+func (e *Envelope) normalize() {
+	e.Tx = strings.ToLower(e.Tx)
+	e.RedeemScript = strings.ToLower(e.RedeemScript)
+	for i := range e.PublicKeys {
+		e.PublicKeys[i] = strings.ToLower(e.PublicKeys[i])
+	}
+	for i := range e.Signatures {
+		e.Signatures[i].PublicKey = strings.ToLower(e.Signatures[i].PublicKey)
+		e.Signatures[i].Signature = strings.ToLower(e.Signatures[i].Signature)
+	}
+}
+
+// decode returns the transaction and redeem script of e.
+func (e *Envelope) decode() (*rawtx.Tx, []byte, error) {
+	tx, err := rawtx.Decode(e.Tx)
+	if err != nil {
+		return nil, nil, err
+	}
+	redeem, err := hex.DecodeString(e.RedeemScript)
+	if err != nil {
+		return nil, nil, fmt.Errorf("envelope: redeem script: %v", err)
+	}
+	return tx, redeem, nil
+}
+
+// Verify checks that e is well formed: a known version, an unsigned
+// transaction, m and public keys that match the redeem script, and valid
+// signatures, at most one per input and key.
+func (e *Envelope) Verify() error {
+	if e.Version != Version {
+		return fmt.Errorf("envelope: unsupported version %d", e.Version)
+	}
+	tx, redeem, err := e.decode()
+	if err != nil {
+		return err
+	}
+	for i, in := range tx.Inputs {
+		if len(in.Script) != 0 {
+			return fmt.Errorf("envelope: input %d of tx has a script", i)
+		}
+	}
+	m, pubs, err := rawtx.ParseMultisig(redeem)
+	if err != nil {
+		return err
+	}
+	if m != e.M || len(pubs) != len(e.PublicKeys) {
+		return errors.New("envelope: m or public keys do not match the redeem script")
+	}
+	for i, pub := range pubs {
+		if !strings.EqualFold(pub.String(), e.PublicKeys[i]) {
+			return errors.New("envelope: m or public keys do not match the redeem script")
+		}
+	}
+
+	seen := map[Signature]bool{}
+	for _, sig := range e.Signatures {
+		key := Signature{Input: sig.Input, PublicKey: sig.PublicKey}
+		if seen[key] {
+			return fmt.Errorf("envelope: two signatures of input %d by %s", sig.Input, sig.PublicKey)
+		}
+		seen[key] = true
+		if err := e.check(tx, redeem, sig); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// check verifies one signature.
+func (e *Envelope) check(tx *rawtx.Tx, redeem []byte, sig Signature) error {
+	pub, err := e.publicKey(sig.PublicKey)
+	if err != nil {
+		return err
+	}
+	if sig.Input < 0 || sig.Input >= len(tx.Inputs) {
+		return fmt.Errorf("envelope: signature of missing input %d", sig.Input)
+	}
+	hash, err := tx.SignatureHash(sig.Input, redeem)
+	if err != nil {
+		return err
+	}
+	b, err := hex.DecodeString(sig.Signature)
+	if err != nil || !pub.Verify(hash, b) {
+		return fmt.Errorf("envelope: bad signature of input %d by %s", sig.Input, sig.PublicKey)
+	}
+	return nil
+}
+
+func (e *Envelope) publicKey(s string) (*keys.PublicKey, error) {
+	i := e.keyIndex(s)
+	if i < 0 {
+		return nil, fmt.Errorf("envelope: %s is not a key of the redeem script", s)
+	}
+	b, err := hex.DecodeString(e.PublicKeys[i])
+	if err != nil {
+		return nil, keys.ErrBadPublicKey
+	}
+	return keys.ParsePublicKey(b)
+}
+
+// keyIndex is the position of the hex public key s in PublicKeys, in
+// either case, or -1.
+func (e *Envelope) keyIndex(s string) int {
+	for i, k := range e.PublicKeys {
+		if strings.EqualFold(k, s) {
+			return i
+		}
+	}
+	return -1
+}
+
+// add keeps sig unless e has a signature of its input by its key.
+func (e *Envelope) add(sig Signature) bool {
+	for _, have := range e.Signatures {
+		if have.Input == sig.Input && have.PublicKey == sig.PublicKey {
+			return false
+		}
+	}
+	e.Signatures = append(e.Signatures, sig)
+	sort.SliceStable(e.Signatures, func(i, j int) bool {
+		return e.Signatures[i].Input < e.Signatures[j].Input
+	})
+	return true
+}
+
+// collect adds the signatures in the input scripts of tx.
+func (e *Envelope) collect(tx *rawtx.Tx, redeem []byte) error {
+	for i := range tx.Inputs {
+		sigs, err := tx.MultisigSignatures(i, redeem)
+		if err != nil {
+			return err
+		}
+		for _, pub := range e.PublicKeys {
+			if sig, ok := sigs[pub]; ok {
+				e.add(Signature{Input: i, PublicKey: pub, Signature: hex.EncodeToString(sig)})
+			}
+		}
+	}
+	return nil
+}
+
+// Sign signs every input with key, which must be one of the public keys.
+func (e *Envelope) Sign(key *keys.PrivateKey) error {
+	pub := key.PublicKey().String()
+	if _, err := e.publicKey(pub); err != nil {
+		return err
+	}
+	tx, redeem, err := e.decode()
+	if err != nil {
+		return err
+	}
+	for i := range tx.Inputs {
+		sig, err := tx.Sign(i, redeem, key)
+		if err != nil {
+			return err
+		}
+		e.add(Signature{Input: i, PublicKey: pub, Signature: hex.EncodeToString(sig)})
+	}
+	return nil
+}
+
+// AddSignedTx takes the signatures out of tx, as returned by Signmultisigtx
+// for PartialTx, and reports how many were new.
+func (e *Envelope) AddSignedTx(tx string) (int, error) {
+	signed, err := rawtx.Decode(tx)
+	if err != nil {
+		return 0, err
+	}
+	unsigned, redeem, err := e.decode()
+	if err != nil {
+		return 0, err
+	}
+	scripts := make([][]byte, len(signed.Inputs))
+	for i := range signed.Inputs {
+		scripts[i] = signed.Inputs[i].Script
+		signed.Inputs[i].Script = nil
+	}
+	if !sameTx(signed, unsigned) {
+		return 0, ErrMismatch
+	}
+	for i := range signed.Inputs {
+		signed.Inputs[i].Script = scripts[i]
+	}
+	before := len(e.Signatures)
+	if err := e.collect(signed, redeem); err != nil {
+		return 0, err
+	}
+	return len(e.Signatures) - before, nil
+}
+
+// Merge adds the signatures of other, an envelope of the same transaction.
+func (e *Envelope) Merge(other *Envelope) error {
+	if !strings.EqualFold(other.Tx, e.Tx) || !strings.EqualFold(other.RedeemScript, e.RedeemScript) {
+		return ErrMismatch
+	}
+	tx, redeem, err := e.decode()
+	if err != nil {
+		return err
+	}
+	for _, sig := range other.Signatures {
+		if err := e.check(tx, redeem, sig); err != nil {
+			return err
+		}
+	}
+	for _, sig := range other.Signatures {
+		sig.PublicKey = e.PublicKeys[e.keyIndex(sig.PublicKey)]
+		sig.Signature = strings.ToLower(sig.Signature)
+		e.add(sig)
+	}
+	return nil
+}
+
+// Signers lists the public keys that have signed every input, in the order
+// of the redeem script.
+func (e *Envelope) Signers() []string {
+	tx, err := rawtx.Decode(e.Tx)
+	if err != nil {
+		return nil
+	}
+	count := map[string]int{}
+	for _, sig := range e.Signatures {
+		count[sig.PublicKey]++
+	}
+	var signers []string
+	for _, pub := range e.PublicKeys {
+		if len(tx.Inputs) > 0 && count[pub] == len(tx.Inputs) {
+			signers = append(signers, pub)
+		}
+	}
+	return signers
+}
+
+// Missing is the number of signatures the input with the fewest still
+// needs. A transaction that does not decode or spends nothing cannot be
+// signed, so it is an error rather than a count.
+func (e *Envelope) Missing() (int, error) {
+	tx, err := rawtx.Decode(e.Tx)
+	if err != nil {
+		return 0, err
+	}
+	if len(tx.Inputs) == 0 {
+		return 0, ErrNoInputs
+	}
+	missing := 0
+	for i := range tx.Inputs {
+		n := e.M
+		for _, sig := range e.Signatures {
+			if sig.Input == i {
+				n--
+			}
+		}
+		if n > missing {
+			missing = n
+		}
+	}
+	return missing, nil
+}
+
+// PartialTx is the transaction with the signatures so far, for the next
+// cosigner's Signmultisigtx.
+func (e *Envelope) PartialTx() (string, error) {
+	return e.build(true)
+}
+
+// Finalize is the fully signed transaction for Sendrawtx.
+func (e *Envelope) Finalize() (string, error) {
+	n, err := e.Missing()
+	if err != nil {
+		return "", err
+	}
+	if n > 0 {
+		return "", fmt.Errorf("%w: %d more needed", ErrMissingSignatures, n)
+	}
+	return e.build(false)
+}
+
+func (e *Envelope) build(partial bool) (string, error) {
+	tx, redeem, err := e.decode()
+	if err != nil {
+		return "", err
+	}
+	for i := range tx.Inputs {
+		var sigs [][]byte
+		for _, sig := range e.Signatures {
+			if sig.Input == i {
+				b, err := hex.DecodeString(sig.Signature)
+				if err != nil {
+					return "", fmt.Errorf("envelope: signature of input %d: %v", i, err)
+				}
+				sigs = append(sigs, b)
+			}
+		}
+		if partial && len(sigs) == 0 {
+			continue
+		}
+		if partial {
+			err = tx.SetPartialMultisigScript(i, redeem, sigs)
+		} else {
+			err = tx.SetMultisigScript(i, redeem, sigs)
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+	return tx.Hex()
+}
+
+func sameTx(a, b *rawtx.Tx) bool {
+	x, err1 := a.Encode()
+	y, err2 := b.Encode()
+	return err1 == nil && err2 == nil && bytes.Equal(x, y)
+}
+
+// writer and reader handle the varints and length-prefixed byte strings of
+// the binary form; reader keeps its first error.
+type writer struct {
+	buf bytes.Buffer
+}
+
+func (w *writer) uint(v uint64) {
+	var b [binary.MaxVarintLen64]byte
+	w.buf.Write(b[:binary.PutUvarint(b[:], v)])
+}
+
+func (w *writer) bytes(b []byte) {
+	w.uint(uint64(len(b)))
+	w.buf.Write(b)
+}
+
+type reader struct {
+	data []byte
+	err  error
+}
+
+var errTruncated = errors.New("envelope: truncated binary envelope")
+
+func (r *reader) uint() uint64 {
+	if r.err != nil {
+		return 0
+	}
+	v, n := binary.Uvarint(r.data)
+	if n <= 0 {
+		r.err = errTruncated
+		return 0
+	}
+	r.data = r.data[n:]
+	return v
+}
+
+func (r *reader) bytes() []byte {
+	n := r.uint()
+	if r.err != nil {
+		return nil
+	}
+	if n > uint64(len(r.data)) {
+		r.err = errTruncated
+		return nil
+	}
+	b := r.data[:n]
+	r.data = r.data[n:]
+	return b
+}
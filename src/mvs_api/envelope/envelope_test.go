@@ -0,0 +1,313 @@
+package envelope_test
+
+import (
+	"encoding/hex"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"mvs_api/envelope"
+	"mvs_api/keys"
+	"mvs_api/rawtx"
+)
+
+// fixtureTx is the Createmultisigtx fixture of mvstest.
+const fixtureTx = "0400000001c4b3a2e0d9e5f1f6b8aac2c7c2b2f0c5d0a1c6e3c0f7d6b5a44ffb9f3ceae7b80000000000ffffffff0100e1f505000000001976a9141f9d8d1b2bd8e6c4ee2e6d48a9c8bfbcdd0a6e2b88ac010000000000000000e1f5050000000000000000"
+
+func key(b byte) *keys.PrivateKey {
+	k, err := keys.NewPrivateKey(append(make([]byte, 31), b))
+	if err != nil {
+		panic(err)
+	}
+	return k
+}
+
+// setup returns a two input transaction and the 2 of 3 redeem script of
+// keys 1, 2 and 3, both hex.
+func setup(t *testing.T) (string, string) {
+	t.Helper()
+	tx, err := rawtx.Decode(fixtureTx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx.Inputs = append(tx.Inputs, tx.Inputs[0])
+	tx.Inputs[1].PrevIndex = 1
+	txHex, err := tx.Hex()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var pubs []string
+	for _, b := range []byte{1, 2, 3} {
+		pubs = append(pubs, key(b).PublicKey().String())
+	}
+	redeem, err := rawtx.MultisigScript(2, pubs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return txHex, hex.EncodeToString(redeem)
+}
+
+// nodeSign signs tx with k as Signmultisigtx does, keeping the signatures
+// already in it.
+func nodeSign(t *testing.T, tx, redeemHex string, k *keys.PrivateKey) string {
+	t.Helper()
+	decoded, err := rawtx.Decode(tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	redeem, _ := hex.DecodeString(redeemHex)
+	for i := range decoded.Inputs {
+		have, err := decoded.MultisigSignatures(i, redeem)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sig, err := decoded.Sign(i, redeem, k)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sigs := [][]byte{sig}
+		for _, s := range have {
+			sigs = append(sigs, s)
+		}
+		if err := decoded.SetPartialMultisigScript(i, redeem, sigs); err != nil {
+			t.Fatal(err)
+		}
+	}
+	signed, err := decoded.Hex()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signed
+}
+
+func TestEnvelopeFlow(t *testing.T) {
+	tx, redeem := setup(t)
+	alice, err := envelope.New(tx, redeem, "pay the auditors")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n, err := alice.Missing(); err != nil || n != 2 {
+		t.Fatalf("Missing = %d, %v; want 2", n, err)
+	}
+	if alice.M != 2 || len(alice.PublicKeys) != 3 || len(alice.Signers()) != 0 {
+		t.Fatalf("new envelope %+v", alice)
+	}
+	if _, err := alice.Finalize(); !errors.Is(err, envelope.ErrMissingSignatures) {
+		t.Errorf("finalize unsigned: err = %v", err)
+	}
+	if err := alice.Sign(key(3)); err != nil {
+		t.Fatal(err)
+	}
+	if err := alice.Sign(key(4)); err == nil {
+		t.Error("signed with a key not in the redeem script")
+	}
+
+	path := filepath.Join(t.TempDir(), "spend.json")
+	if err := alice.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	bob, err := envelope.Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(bob, alice) {
+		t.Errorf("loaded %+v, want %+v", bob, alice)
+	}
+
+	// Bob's key is in mvsd: it signs the partial transaction.
+	partial, err := bob.PartialTx()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n, err := bob.AddSignedTx(nodeSign(t, partial, redeem, key(1))); err != nil || n != 2 {
+		t.Fatalf("AddSignedTx = %d, %v; want 2 new signatures", n, err)
+	}
+	if n, err := bob.AddSignedTx(fixtureTx); !errors.Is(err, envelope.ErrMismatch) {
+		t.Errorf("AddSignedTx of another tx = %d, %v", n, err)
+	}
+	if err := alice.Merge(bob); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := alice.Signers(), []string{key(1).PublicKey().String(), key(3).PublicKey().String()}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Signers = %v, want %v", got, want)
+	}
+	if n, err := alice.Missing(); err != nil || n != 0 {
+		t.Errorf("Missing = %d, %v", n, err)
+	}
+
+	final, err := alice.Finalize()
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := rawtx.Decode(final)
+	if err != nil {
+		t.Fatal(err)
+	}
+	script, _ := hex.DecodeString(redeem)
+	for i := range decoded.Inputs {
+		if signers, err := decoded.MultisigSigners(i, script); err != nil || len(signers) != 2 {
+			t.Errorf("input %d signed by %v, %v", i, signers, err)
+		}
+	}
+}
+
+func TestEnvelopeRedeemScript(t *testing.T) {
+	tx, _ := setup(t)
+	var pubs []string
+	for _, b := range []byte{1, 2, 3} {
+		pubs = append(pubs, key(b).PublicKey().String())
+	}
+	text := "2 [ " + strings.Join(pubs, " ] [ ") + " ] 3 checkmultisig"
+	if _, err := envelope.New(tx, text, ""); err == nil {
+		t.Error("took the text form of the multisig script")
+	}
+}
+
+func TestEnvelopeCase(t *testing.T) {
+	tx, redeem := setup(t)
+	e, err := envelope.New(tx, redeem, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := e.Sign(key(2)); err != nil {
+		t.Fatal(err)
+	}
+	data, err := e.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Hex is read in either case.
+	shout := strings.NewReplacer(e.Tx, strings.ToUpper(e.Tx), e.RedeemScript, strings.ToUpper(e.RedeemScript),
+		e.Signatures[0].PublicKey, strings.ToUpper(e.Signatures[0].PublicKey), e.Signatures[0].Signature, strings.ToUpper(e.Signatures[0].Signature))
+	parsed, err := envelope.Parse([]byte(shout.Replace(string(data))))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(parsed, e) {
+		t.Errorf("parsed %+v, want %+v", parsed, e)
+	}
+
+	e.PublicKeys[1] = strings.ToUpper(e.PublicKeys[1])
+	if err := e.Sign(key(2)); err != nil {
+		t.Errorf("sign with an upper cased key in the envelope: %v", err)
+	}
+}
+
+func TestEnvelopeBinary(t *testing.T) {
+	tx, redeem := setup(t)
+	e, err := envelope.New(tx, redeem, "pay the auditors")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := e.Sign(key(1)); err != nil {
+		t.Fatal(err)
+	}
+	if err := e.Sign(key(3)); err != nil {
+		t.Fatal(err)
+	}
+	data, err := e.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	js, _ := e.Marshal()
+	if len(data) >= len(js)/2 {
+		t.Errorf("binary form is %d bytes, JSON %d", len(data), len(js))
+	}
+	parsed, err := envelope.Parse(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(parsed, e) {
+		t.Errorf("parsed %+v, want %+v", parsed, e)
+	}
+	var again envelope.Envelope
+	if err := again.UnmarshalBinary(data); err != nil || !reflect.DeepEqual(&again, e) {
+		t.Errorf("UnmarshalBinary = %+v, %v", again, err)
+	}
+
+	for n := 4; n < len(data); n++ {
+		if _, err := envelope.Parse(data[:n]); err == nil {
+			t.Fatalf("parsed %d of %d bytes", n, len(data))
+		}
+	}
+	if _, err := envelope.Parse(append(data, 0)); err == nil {
+		t.Error("parsed a trailing byte")
+	}
+	bad := append([]byte(nil), data...)
+	bad[4] = 2
+	if _, err := envelope.Parse(bad); err == nil {
+		t.Error("parsed version 2")
+	}
+	bad = append([]byte(nil), data...)
+	bad[len(bad)-2] ^= 1
+	if _, err := envelope.Parse(bad); err == nil {
+		t.Error("parsed a bad signature")
+	}
+}
+
+func TestEnvelopeVerify(t *testing.T) {
+	tx, redeem := setup(t)
+	e, err := envelope.New(tx, redeem, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := e.Sign(key(1)); err != nil {
+		t.Fatal(err)
+	}
+	tests := []func(e *envelope.Envelope){
+		func(e *envelope.Envelope) { e.Version = 2 },
+		func(e *envelope.Envelope) { e.M = 3 },
+		func(e *envelope.Envelope) { e.PublicKeys[0], e.PublicKeys[1] = e.PublicKeys[1], e.PublicKeys[0] },
+		func(e *envelope.Envelope) { e.Signatures[0].Input = 1 },
+		func(e *envelope.Envelope) { e.Signatures[0].Input = 5 },
+		func(e *envelope.Envelope) { e.Signatures[1] = e.Signatures[0] },
+		func(e *envelope.Envelope) { e.Signatures[0].PublicKey = key(2).PublicKey().String() },
+		func(e *envelope.Envelope) { e.Tx = nodeSign(t, e.Tx, redeem, key(2)) },
+	}
+	for i, tamper := range tests {
+		data, _ := e.Marshal()
+		tampered, err := envelope.Parse(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		tamper(tampered)
+		if err := tampered.Verify(); err == nil {
+			t.Errorf("tampered envelope %d verifies", i)
+		}
+	}
+}
+
+func TestEnvelopeMissingUnsignable(t *testing.T) {
+	tx, redeem := setup(t)
+	e, err := envelope.New(tx, redeem, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := rawtx.Decode(e.Tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded.Inputs = nil
+	noInputs, err := decoded.Hex()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	e.Tx = noInputs
+	if n, err := e.Missing(); !errors.Is(err, envelope.ErrNoInputs) {
+		t.Errorf("Missing of a tx without inputs = %d, %v", n, err)
+	}
+	if final, err := e.Finalize(); !errors.Is(err, envelope.ErrNoInputs) {
+		t.Errorf("Finalize of a tx without inputs = %q, %v", final, err)
+	}
+
+	e.Tx = "not hex"
+	if n, err := e.Missing(); err == nil {
+		t.Errorf("Missing of an undecodable tx = %d", n)
+	}
+	if final, err := e.Finalize(); err == nil {
+		t.Errorf("Finalize of an undecodable tx = %q", final)
+	}
+}
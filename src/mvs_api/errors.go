@@ -0,0 +1,193 @@
+package mvs_api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Sentinels for common MVS failures. Match them with errors.Is against the
+// error returned by any RPCClient method.
+var (
+	ErrBadPassword         = errors.New("mvs_api: bad account password")
+	ErrInsufficientBalance = errors.New("mvs_api: insufficient balance")
+	ErrUnknownSymbol       = errors.New("mvs_api: unknown symbol")
+	ErrDIDRegistered       = errors.New("mvs_api: did already registered")
+)
+
+// cause describes how the node reports one of the sentinels: by the
+// explorer exception code, or by a phrase of its message when the code is 0
+// or none of the known codes.
+type cause struct {
+	codes   []int
+	phrases []string
+}
+
+var causes = map[error]cause{
+	ErrBadPassword: {
+		codes:   []int{1002},
+		phrases: []string{"account password authority failed"},
+	},
+	ErrInsufficientBalance: {
+		codes:   []int{1008, 3001},
+		phrases: []string{"not enough", "no enough", "insufficient", "balance lack"},
+	},
+	ErrUnknownSymbol: {
+		codes:   []int{3009, 7006},
+		phrases: []string{"symbol not exist", "symbol does not exist", "asset not exist", "asset does not exist", "not found for symbol"},
+	},
+	ErrDIDRegistered: {
+		codes:   []int{7001},
+		phrases: []string{"did symbol already exist", "did already exist", "already registered"},
+	},
+}
+
+// knownCodes are the codes of all causes. A known code decides alone.
+var knownCodes = map[int]bool{}
+
+func init() {
+	for _, c := range causes {
+		for _, code := range c.codes {
+			knownCodes[code] = true
+		}
+	}
+}
+
+// RPCError is the error object of a JSON-RPC response.
+type RPCError struct {
+	Code    int             `json:"code"`
+	Message string          `json:"message"`
+	Data    json.RawMessage `json:"data,omitempty"`
+	// Method and Id identify the failed request; they are not part of the
+	// response.
+	Method string `json:"-"`
+	Id     uint64 `json:"-"`
+}
+
+func (e *RPCError) Error() string {
+	msg := e.Message
+	if msg == "" {
+		msg = "unknown error"
+	}
+	if e.Method == "" {
+		return fmt.Sprintf("%s (code %d)", msg, e.Code)
+	}
+	return fmt.Sprintf("%s (id %d): %s (code %d)", e.Method, e.Id, msg, e.Code)
+}
+
+// Is reports whether the error is one of the MVS sentinels.
+func (e *RPCError) Is(target error) bool {
+	c, ok := causes[target]
+	if !ok {
+		return false
+	}
+	for _, code := range c.codes {
+		if e.Code == code {
+			return true
+		}
+	}
+	if knownCodes[e.Code] {
+		return false
+	}
+	msg := strings.ToLower(e.Message)
+	for _, phrase := range c.phrases {
+		if strings.Contains(msg, phrase) {
+			return true
+		}
+	}
+	return false
+}
+
+// UnmarshalJSON tolerates nodes that send the error as a bare string or
+// with a message that is not a string.
+func (e *RPCError) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if isJSONString(data) {
+		return json.Unmarshal(data, &e.Message)
+	}
+	var v struct {
+		Code    json.Number     `json:"code"`
+		Message json.RawMessage `json:"message"`
+		Data    json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	if v.Code != "" {
+		code, err := v.Code.Int64()
+		if err != nil {
+			return err
+		}
+		e.Code = int(code)
+	}
+	if isJSONString(v.Message) {
+		json.Unmarshal(v.Message, &e.Message)
+	} else if len(v.Message) > 0 && string(v.Message) != "null" {
+		e.Message = string(v.Message)
+	}
+	e.Data = v.Data
+	return nil
+}
+
+// TransportError is a failure to get a JSON-RPC response from the node:
+// the connection failed, or the node answered with an HTTP error or with a
+// body that is not a JSON-RPC response. Only these count against the
+// health of a client.
+type TransportError struct {
+	Method string
+	Id     uint64
+	// StatusCode is the HTTP status of the response, 0 if none arrived.
+	StatusCode int
+	Err        error
+}
+
+func (e *TransportError) Error() string {
+	if e.StatusCode != 0 && (e.StatusCode < 200 || e.StatusCode > 299) {
+		return fmt.Sprintf("%s (id %d): http status %d: %v", e.Method, e.Id, e.StatusCode, e.Err)
+	}
+	return fmt.Sprintf("%s (id %d): %v", e.Method, e.Id, e.Err)
+}
+
+func (e *TransportError) Unwrap() error {
+	return e.Err
+}
+
+type ErrorClass int
+
+const (
+	ErrorClassNone ErrorClass = iota
+	// ErrorClassTransport: the node could not be reached.
+	ErrorClassTransport
+	// ErrorClassServer: the node was reached but did not answer with JSON-RPC.
+	ErrorClassServer
+	// ErrorClassApplication: the node rejected the call with an RPCError.
+	ErrorClassApplication
+	// ErrorClassCanceled: the context of the call was cancelled or expired.
+	ErrorClassCanceled
+	ErrorClassOther
+)
+
+// Classify tells which part of a call an error returned by RPCClient comes from.
+func Classify(err error) ErrorClass {
+	if err == nil {
+		return ErrorClassNone
+	}
+	var rpcErr *RPCError
+	if errors.As(err, &rpcErr) {
+		return ErrorClassApplication
+	}
+	var transportErr *TransportError
+	if errors.As(err, &transportErr) {
+		if transportErr.StatusCode != 0 {
+			return ErrorClassServer
+		}
+		return ErrorClassTransport
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return ErrorClassCanceled
+	}
+	return ErrorClassOther
+}
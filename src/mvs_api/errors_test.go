@@ -0,0 +1,91 @@
+package mvs_api_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+
+	"mvs_api"
+	"mvs_api/mvstest"
+)
+
+func TestRPCErrorIs(t *testing.T) {
+	tests := []struct {
+		err    *mvs_api.RPCError
+		target error
+		want   bool
+	}{
+		{&mvs_api.RPCError{Code: 1002}, mvs_api.ErrBadPassword, true},
+		{&mvs_api.RPCError{Code: 1002, Message: "whatever"}, mvs_api.ErrBadPassword, true},
+		{&mvs_api.RPCError{Message: "account password authority failed"}, mvs_api.ErrBadPassword, true},
+		{&mvs_api.RPCError{Code: 9999, Message: "Account password authority failed."}, mvs_api.ErrBadPassword, true},
+		// A known code decides, whatever the message says.
+		{&mvs_api.RPCError{Code: 1008, Message: "account password authority failed"}, mvs_api.ErrBadPassword, false},
+		{&mvs_api.RPCError{Code: 7001, Message: "not enough balance"}, mvs_api.ErrInsufficientBalance, false},
+		// Bare words are not enough.
+		{&mvs_api.RPCError{Code: 1001, Message: "password length must be at least 6"}, mvs_api.ErrBadPassword, false},
+		{&mvs_api.RPCError{Message: "password length must be at least 6"}, mvs_api.ErrBadPassword, false},
+		{&mvs_api.RPCError{Message: "no authority to issue asset"}, mvs_api.ErrBadPassword, false},
+		{&mvs_api.RPCError{Code: 3001}, mvs_api.ErrInsufficientBalance, true},
+		{&mvs_api.RPCError{Code: 5302, Message: "not enough balance"}, mvs_api.ErrInsufficientBalance, true},
+		{&mvs_api.RPCError{Code: 7006}, mvs_api.ErrUnknownSymbol, true},
+		{&mvs_api.RPCError{Message: "asset does not exist"}, mvs_api.ErrUnknownSymbol, true},
+		{&mvs_api.RPCError{Code: 7001}, mvs_api.ErrDIDRegistered, true},
+		{&mvs_api.RPCError{Code: 7001}, mvs_api.ErrUnknownSymbol, false},
+		{&mvs_api.RPCError{Code: 1002}, io.EOF, false},
+	}
+	for _, tt := range tests {
+		if got := errors.Is(tt.err, tt.target); got != tt.want {
+			t.Errorf("errors.Is(%v, %v) = %v, want %v", tt.err, tt.target, got, tt.want)
+		}
+	}
+}
+
+func TestRPCErrorFromNode(t *testing.T) {
+	s := mvstest.NewServer()
+	defer s.Close()
+	s.Fail("send", &mvs_api.RPCError{Code: 1002, Message: "account password authority failed"})
+
+	_, err := s.Client().Send("Alice", "wrong", mvstest.Address, 1, "", 0)
+	if !errors.Is(err, mvs_api.ErrBadPassword) {
+		t.Errorf("err = %v, want ErrBadPassword", err)
+	}
+	var rpcErr *mvs_api.RPCError
+	if !errors.As(err, &rpcErr) || rpcErr.Method != "send" || rpcErr.Code != 1002 {
+		t.Errorf("err = %#v", err)
+	}
+	if mvs_api.Classify(err) != mvs_api.ErrorClassApplication {
+		t.Errorf("class = %v", mvs_api.Classify(err))
+	}
+}
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		err  error
+		want mvs_api.ErrorClass
+	}{
+		{nil, mvs_api.ErrorClassNone},
+		{&mvs_api.RPCError{Code: 1002}, mvs_api.ErrorClassApplication},
+		{fmt.Errorf("wrapped: %w", &mvs_api.RPCError{}), mvs_api.ErrorClassApplication},
+		{&mvs_api.TransportError{Method: "getheight", Err: io.EOF}, mvs_api.ErrorClassTransport},
+		{&mvs_api.TransportError{Method: "getheight", StatusCode: 500, Err: io.EOF}, mvs_api.ErrorClassServer},
+		{&mvs_api.TransportError{Method: "getheight", StatusCode: 200, Err: io.ErrUnexpectedEOF}, mvs_api.ErrorClassServer},
+		{context.Canceled, mvs_api.ErrorClassCanceled},
+		{fmt.Errorf("getheight: %w", context.DeadlineExceeded), mvs_api.ErrorClassCanceled},
+		{io.EOF, mvs_api.ErrorClassOther},
+	}
+	for _, tt := range tests {
+		if got := mvs_api.Classify(tt.err); got != tt.want {
+			t.Errorf("Classify(%v) = %v, want %v", tt.err, got, tt.want)
+		}
+	}
+
+	s := mvstest.NewServer()
+	s.Close()
+	_, err := s.Client(mvs_api.WithRetryPolicy(mvs_api.RetryPolicy{MaxAttempts: 1})).Getheight("", "")
+	if mvs_api.Classify(err) != mvs_api.ErrorClassTransport {
+		t.Errorf("refused connection: class of %v = %v", err, mvs_api.Classify(err))
+	}
+}
@@ -0,0 +1,101 @@
+package hdwallet
+
+import (
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+
+	"mvs_api/keys"
+)
+
+// Hardened is added to a child index for hardened derivation.
+const Hardened uint32 = 0x80000000
+
+// ErrInvalidChild is returned for the rare index whose key is not valid;
+// BIP32 says to go on with the next index.
+var ErrInvalidChild = errors.New("hdwallet: invalid child key")
+
+// Key is a BIP32 extended private key.
+type Key struct {
+	key   *keys.PrivateKey
+	chain []byte
+}
+
+// NewMaster derives the master key of a seed.
+func NewMaster(seed []byte) (*Key, error) {
+	mac := hmac.New(sha512.New, []byte("Bitcoin seed"))
+	mac.Write(seed)
+	sum := mac.Sum(nil)
+	key, err := keys.NewPrivateKey(sum[:32])
+	if err != nil {
+		return nil, ErrInvalidChild
+	}
+	return &Key{key: key, chain: sum[32:]}, nil
+}
+
+// Child derives child i, hardened if i is Hardened or more.
+func (k *Key) Child(i uint32) (*Key, error) {
+	mac := hmac.New(sha512.New, k.chain)
+	if i >= Hardened {
+		mac.Write([]byte{0})
+		mac.Write(k.key.Bytes())
+	} else {
+		mac.Write(k.key.PublicKey().Bytes())
+	}
+	var index [4]byte
+	binary.BigEndian.PutUint32(index[:], i)
+	mac.Write(index[:])
+	sum := mac.Sum(nil)
+	child, err := k.key.Add(sum[:32])
+	if err != nil {
+		return nil, ErrInvalidChild
+	}
+	return &Key{key: child, chain: sum[32:]}, nil
+}
+
+func (k *Key) PrivateKey() *keys.PrivateKey {
+	return k.key
+}
+
+// AccountKey returns the master key of the account words belong to.
+func AccountKey(words []string) (*Key, error) {
+	seed, err := Seed(words)
+	if err != nil {
+		return nil, err
+	}
+	return NewMaster(seed)
+}
+
+// AddressKey returns the private key of the address with hd index i of the
+// account words belong to, for signing with package rawtx.
+func AddressKey(words []string, i uint32) (*keys.PrivateKey, error) {
+	master, err := AccountKey(words)
+	if err != nil {
+		return nil, err
+	}
+	child, err := master.Child(i)
+	if err != nil {
+		return nil, err
+	}
+	return child.PrivateKey(), nil
+}
+
+// PredictAddresses returns the first n mainnet addresses mvsd gives the
+// account of words, in the order Listaddresses reports them after an
+// Importaccount with hd_index n.
+func PredictAddresses(words []string, n int) ([]string, error) {
+	master, err := AccountKey(words)
+	if err != nil {
+		return nil, err
+	}
+	addresses := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		child, err := master.Child(uint32(i))
+		if err != nil {
+			return nil, err
+		}
+		addresses = append(addresses, child.PrivateKey().PublicKey().Address(false))
+	}
+	return addresses, nil
+}
@@ -0,0 +1,198 @@
+package hdwallet
+
+import (
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// baseline is the mnemonic the robot account of test_api.go imports into
+// mvsd, whose first address is the mining address of Alice there. mvstest
+// uses it for its Alice fixture account.
+const baseline = "notice judge certain company novel quality plunge list blind library ride uncover fold wink biology original aim whale stand coach hire clinic fame robot"
+
+type step struct {
+	path        string
+	index       uint32
+	chain, priv string
+}
+
+// checkVector derives the steps of a BIP32 test vector from seed, each
+// from the one before.
+func checkVector(t *testing.T, seed string, steps []step) {
+	t.Helper()
+	b, _ := hex.DecodeString(seed)
+	k, err := NewMaster(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, s := range steps {
+		if i > 0 {
+			if k, err = k.Child(s.index); err != nil {
+				t.Fatalf("%s: %v", s.path, err)
+			}
+		}
+		if got := hex.EncodeToString(k.chain); got != s.chain {
+			t.Errorf("%s: chain code %s, want %s", s.path, got, s.chain)
+		}
+		if got := hex.EncodeToString(k.PrivateKey().Bytes()); got != s.priv {
+			t.Errorf("%s: private key %s, want %s", s.path, got, s.priv)
+		}
+	}
+}
+
+func TestBIP32Vector1(t *testing.T) {
+	checkVector(t, "000102030405060708090a0b0c0d0e0f", []step{
+		{"m", 0, "873dff81c02f525623fd1fe5167eac3a55a049de3d314bb42ee227ffed37d508", "e8f32e723decf4051aefac8e2c93c9c5b214313817cdb01a1494b917c8436b35"},
+		{"m/0H", Hardened, "47fdacbd0f1097043b78c63c20c34ef4ed9a111d980047ad16282c7ae6236141", "edb2e14f9ee77d26dd93b4ecede8d16ed408ce149b6cd80b0715a2d911a0afea"},
+		{"m/0H/1", 1, "2a7857631386ba23dacac34180dd1983734e444fdbf774041578e9b6adb37c19", "3c6cb8d0f6a264c91ea8b5030fadaa8e538b020f0a387421a12de9319dc93368"},
+		{"m/0H/1/2H", Hardened + 2, "04466b9cc8e161e966409ca52986c584f07e9dc81f735db683c3ff6ec7b1503f", "cbce0d719ecf7431d88e6a89fa1483e02e35092af60c042b1df2ff59fa424dca"},
+		{"m/0H/1/2H/2", 2, "cfb71883f01676f587d023cc53a35bc7f88f724b1f8c2892ac1275ac822a3edd", "0f479245fb19a38a1954c5c7c0ebab2f9bdfd96a17563ef28a6a4b1a2a764ef4"},
+		{"m/0H/1/2H/2/1000000000", 1000000000, "c783e67b921d2beb8f6b389cc646d7263b4145701dadd2161548a8b078e65e9e", "471b76e389e528d6de6d816857e012c5455051cad6660850e58372a6c3e6e7c8"},
+	})
+}
+
+func TestBIP32Vector2(t *testing.T) {
+	checkVector(t, "fffcf9f6f3f0edeae7e4e1dedbd8d5d2cfccc9c6c3c0bdbab7b4b1aeaba8a5a29f9c999693908d8a8784817e7b7875726f6c696663605d5a5754514e4b484542", []step{
+		{"m", 0, "60499f801b896d83179a4374aeb7822aaeaceaa0db1f85ee3e904c4defbd9689", "4b03d6fc340455b363f51020ad3ecca4f0850280cf436c70c727923f6db46c3e"},
+		{"m/0", 0, "f0909affaa7ee7abe5dd4e100598d4dc53cd709d5a5c2cac40e7412f232f7c9c", "abe74a98f6c7eabee0428f53798f0ab8aa1bd37873999041703c742f15ac7e1e"},
+		{"m/0/2147483647H", Hardened + 2147483647, "be17a268474a6bb9c61e1d720cf6215e2a88c5406c4aee7b38547f585c9a37d9", "877c779ad9687164e9c2f4f0f4ff0340814392330693ce95a58fe18fd52e6e93"},
+		{"m/0/2147483647H/1", 1, "f366f48f1ea9f2d1d3fe958c95ca84ea18e4c4ddb9366c336c927eb246fb38cb", "704addf544a06e5ee4bea37098463c23613da32020d604506da8c0518e1da4b7"},
+		{"m/0/2147483647H/1/2147483646H", Hardened + 2147483646, "637807030d55d01f9a0cb3a7839515d796bd07706386a6eddf06cc29a65a0e29", "f1c7c871a54a804afe328b4c83a1c33b8e5ff48f5087273f04efa83b247d6a2d"},
+		{"m/0/2147483647H/1/2147483646H/2", 2, "9452b549be8cea3ecb7a84bec10dcfd94afe4d129ebfd3b3cb58eedf394ed271", "bb7d39bdb83ecf58f2fd82b6d918341cbef428661ef01ab97c28a4842125ac23"},
+	})
+}
+
+func TestSeed(t *testing.T) {
+	// BIP39 seed of the all-zero entropy, without a passphrase.
+	words := strings.Fields("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about")
+	seed, err := Seed(words)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := hex.EncodeToString(seed), "5eb00bbddcf069084889a8ab9155568165f5c453ccb85e70811aaed6f6da5fc19a5ac40b389cd370d086206dec8aa6c43daea6690f20ad3d8d48b2d2ce9e38e4"; got != want {
+		t.Errorf("seed = %s, want %s", got, want)
+	}
+	// Space around a word is ignored.
+	padded := append([]string(nil), words...)
+	padded[0] = " abandon "
+	if again, err := Seed(padded); err != nil || hex.EncodeToString(again) != hex.EncodeToString(seed) {
+		t.Errorf("seed of padded words = %x, %v", again, err)
+	}
+}
+
+func TestPredictAddresses(t *testing.T) {
+	got, err := PredictAddresses(strings.Fields(baseline), 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"MLasJFxZQnA49XEvhTHmRKi2qstkj9ppjo", "M9L3ipy3Hcf6kdvknU3mH7mwH9ER3uCziu", "MP5FoYQHiEQ52pcEURkaYmuqZMnYHNAZ83"}
+	if strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("addresses = %v, want %v", got, want)
+	}
+	k, err := AddressKey(strings.Fields(baseline), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The key of MLasJF..., whose public key is in the multisig example of
+	// test_api.go.
+	if got := hex.EncodeToString(k.Bytes()); got != "cf118f817ca7f45fcead37cfce07b4200b4480c0f2fb344255226b7f8674f98c" {
+		t.Errorf("AddressKey(0) = %s", got)
+	}
+	if got := k.PublicKey().String(); got != "0380990a7312b87abda80e5857ee6ebf798a2bf62041b07111287d19926c429d11" {
+		t.Errorf("AddressKey(0) public key = %s", got)
+	}
+}
+
+func TestNewMnemonic(t *testing.T) {
+	tests := []struct{ entropy, words string }{
+		{"00000000000000000000000000000000", "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"},
+		{"7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f", "legal winner thank year wave sausage worth useful legal winner thank yellow"},
+		{"8080808080808080808080808080808080808080808080808080808080808080", "letter advice cage absurd amount doctor acoustic avoid letter advice cage absurd amount doctor acoustic avoid letter advice cage absurd amount doctor acoustic bless"},
+		{"ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", "zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo vote"},
+	}
+	for _, tt := range tests {
+		entropy, _ := hex.DecodeString(tt.entropy)
+		words, err := NewMnemonic(entropy, English)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := strings.Join(words, " "); got != tt.words {
+			t.Errorf("NewMnemonic(%s) = %q, want %q", tt.entropy, got, tt.words)
+		}
+		if err := CheckMnemonic(words, English); err != nil {
+			t.Errorf("CheckMnemonic(%q): %v", tt.words, err)
+		}
+	}
+	if _, err := NewMnemonic(make([]byte, 15), English); err == nil {
+		t.Error("took 15 bytes of entropy")
+	}
+	if _, err := NewMnemonic(make([]byte, 16), "xx"); !errors.Is(err, ErrUnknownLanguage) {
+		t.Errorf("unknown language: err = %v", err)
+	}
+}
+
+func TestCheckMnemonic(t *testing.T) {
+	words := strings.Fields(baseline)
+	if err := CheckMnemonic(words, ""); err != nil {
+		t.Fatal(err)
+	}
+	bad := append([]string(nil), words...)
+	bad[23] = "abandon"
+	if err := CheckMnemonic(bad, ""); !errors.Is(err, ErrChecksum) {
+		t.Errorf("bad checksum: err = %v", err)
+	}
+	bad[23] = "robots"
+	if err := CheckMnemonic(bad, ""); !errors.Is(err, ErrUnknownWord) {
+		t.Errorf("unknown word: err = %v", err)
+	}
+	if err := CheckMnemonic(words[:23], English); !errors.Is(err, ErrWordCount) {
+		t.Errorf("23 words: err = %v", err)
+	}
+	if _, err := Seed(bad); err == nil {
+		t.Error("Seed took a bad mnemonic")
+	}
+}
+
+func TestLanguages(t *testing.T) {
+	for _, lang := range Languages {
+		words, err := GenerateMnemonic(lang)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(words) != 24 {
+			t.Errorf("%s: %d words", lang, len(words))
+		}
+		if got, err := Language(words); err != nil || got != lang {
+			t.Errorf("Language of %s words = %s, %v", lang, got, err)
+		}
+		if err := CheckMnemonic(words, lang); err != nil {
+			t.Errorf("%s: %v", lang, err)
+		}
+	}
+}
+
+// Words typed in any normalization form are found, in every wordlist.
+func TestNormalization(t *testing.T) {
+	for _, lang := range Languages {
+		words, err := Wordlist(lang)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, w := range words {
+			for _, form := range []norm.Form{norm.NFC, norm.NFD, norm.NFKC, norm.NFKD} {
+				got, err := canonical([]string{form.String(w)}, lang)
+				if err != nil || got[0] != w {
+					t.Fatalf("%s: %q in %v = %q, %v", lang, w, form, got, err)
+				}
+			}
+		}
+	}
+	// Full-width letters are compatibility forms of the ASCII ones.
+	if got, err := canonical([]string{"ｒｏｂｏｔ"}, English); err != nil || got[0] != "robot" {
+		t.Errorf("full-width robot = %q, %v", got, err)
+	}
+}
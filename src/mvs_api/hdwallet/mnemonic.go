@@ -0,0 +1,230 @@
+// Package hdwallet generates and checks the BIP39 mnemonics of mvsd accounts
+// and derives their keys and addresses offline, as Getnewaccount,
+// Importaccount and Getnewaddress do:
+//
+//	words, err := hdwallet.GenerateMnemonic(hdwallet.English)
+//	...
+//	addresses, err := hdwallet.PredictAddresses(words, 5)
+//
+// mvsd derives the key of the i-th address of an account as the BIP32 child
+// m/i of the master key of the mnemonic seed, without a passphrase, and
+// pays to its compressed public key.
+package hdwallet
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"embed"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Languages of the wordlists, by the names mvsd gives them.
+const (
+	English            = "en"
+	Spanish            = "es"
+	Japanese           = "ja"
+	ChineseSimplified  = "zh_Hans"
+	ChineseTraditional = "zh_Hant"
+)
+
+// Languages lists the languages in the order Language tries them.
+var Languages = []string{English, Spanish, Japanese, ChineseSimplified, ChineseTraditional}
+
+var (
+	ErrUnknownLanguage = errors.New("hdwallet: unknown language")
+	ErrUnknownWord     = errors.New("hdwallet: word not in wordlist")
+	ErrWordCount       = errors.New("hdwallet: mnemonic must have 12, 15, 18, 21 or 24 words")
+	ErrChecksum        = errors.New("hdwallet: bad mnemonic checksum")
+)
+
+// The BIP39 wordlists, in the NFKD form mvsd compares words in.
+//
+//go:embed wordlists/*.txt
+var wordlistFiles embed.FS
+
+var wordlists = map[string][]string{}
+var wordIndex = map[string]map[string]int{}
+
+func init() {
+	for _, lang := range Languages {
+		data, err := wordlistFiles.ReadFile("wordlists/" + lang + ".txt")
+		if err != nil {
+			panic(err)
+		}
+		words := strings.Split(strings.TrimSpace(string(data)), "\n")
+		if len(words) != 2048 {
+			panic("hdwallet: wordlist " + lang + " does not have 2048 words")
+		}
+		wordlists[lang] = words
+		wordIndex[lang] = map[string]int{}
+		for i, w := range words {
+			wordIndex[lang][w] = i
+		}
+	}
+}
+
+// Wordlist returns the 2048 words of lang.
+func Wordlist(lang string) ([]string, error) {
+	words, ok := wordlists[lang]
+	if !ok {
+		return nil, fmt.Errorf("%w %q", ErrUnknownLanguage, lang)
+	}
+	return append([]string(nil), words...), nil
+}
+
+// GenerateMnemonic returns 24 random words of lang, the length mvsd gives a
+// new account.
+func GenerateMnemonic(lang string) ([]string, error) {
+	entropy := make([]byte, 32)
+	if _, err := rand.Read(entropy); err != nil {
+		return nil, err
+	}
+	return NewMnemonic(entropy, lang)
+}
+
+// NewMnemonic encodes 16 to 32 bytes of entropy, a multiple of 4, as words
+// of lang.
+func NewMnemonic(entropy []byte, lang string) ([]string, error) {
+	words, ok := wordlists[lang]
+	if !ok {
+		return nil, fmt.Errorf("%w %q", ErrUnknownLanguage, lang)
+	}
+	if len(entropy) < 16 || len(entropy) > 32 || len(entropy)%4 != 0 {
+		return nil, fmt.Errorf("hdwallet: %d bytes of entropy", len(entropy))
+	}
+	sum := sha256.Sum256(entropy)
+	bits := append(append([]byte(nil), entropy...), sum[0])
+	mnemonic := make([]string, len(entropy)*8/32*3)
+	for i := range mnemonic {
+		mnemonic[i] = words[elevenBits(bits, i)]
+	}
+	return mnemonic, nil
+}
+
+// elevenBits reads the i-th 11 bit group of b.
+func elevenBits(b []byte, i int) int {
+	v := 0
+	for bit := i * 11; bit < i*11+11; bit++ {
+		v = v<<1 | int(b[bit/8]>>(7-bit%8)&1)
+	}
+	return v
+}
+
+// Language finds the language of words. Simplified and traditional Chinese
+// share many words, so the one whose checksum holds is preferred.
+func Language(words []string) (string, error) {
+	if len(words) == 0 {
+		return "", ErrWordCount
+	}
+	found := ""
+	for _, lang := range Languages {
+		if _, err := canonical(words, lang); err != nil {
+			continue
+		}
+		if _, err := normalize(words, lang); err == nil {
+			return lang, nil
+		}
+		if found == "" {
+			found = lang
+		}
+	}
+	if found != "" {
+		return found, nil
+	}
+	return "", fmt.Errorf("%w: %q", ErrUnknownWord, words[0])
+}
+
+// canonical returns the words as they appear in the wordlist of lang.
+func canonical(words []string, lang string) ([]string, error) {
+	index, ok := wordIndex[lang]
+	if !ok {
+		return nil, fmt.Errorf("%w %q", ErrUnknownLanguage, lang)
+	}
+	list := make([]string, len(words))
+	for i, w := range words {
+		w = norm.NFKD.String(strings.TrimSpace(w))
+		if _, ok := index[w]; !ok {
+			return nil, fmt.Errorf("%w: %q", ErrUnknownWord, words[i])
+		}
+		list[i] = w
+	}
+	return list, nil
+}
+
+// CheckMnemonic validates words against the wordlist of lang, or of any
+// language if lang is "", and their checksum.
+func CheckMnemonic(words []string, lang string) error {
+	_, err := normalize(words, lang)
+	return err
+}
+
+func normalize(words []string, lang string) ([]string, error) {
+	if lang == "" {
+		var err error
+		if lang, err = Language(words); err != nil {
+			return nil, err
+		}
+	}
+	list, err := canonical(words, lang)
+	if err != nil {
+		return nil, err
+	}
+	if len(list) < 12 || len(list) > 24 || len(list)%3 != 0 {
+		return nil, ErrWordCount
+	}
+	bits := make([]byte, (len(list)*11+7)/8)
+	for i, w := range list {
+		v := wordIndex[lang][w]
+		for j := 0; j < 11; j++ {
+			if v>>(10-j)&1 == 1 {
+				bit := i*11 + j
+				bits[bit/8] |= 1 << (7 - bit%8)
+			}
+		}
+	}
+	entropy := bits[:len(list)*11*32/33/8]
+	sum := sha256.Sum256(entropy)
+	checksumBits := uint(len(list) / 3)
+	got := bits[len(entropy)] >> (8 - checksumBits)
+	if got != sum[0]>>(8-checksumBits) {
+		return nil, ErrChecksum
+	}
+	return list, nil
+}
+
+// Seed checks words and returns the 64 byte seed mvsd derives keys from:
+// PBKDF2-HMAC-SHA512 of the words joined by spaces, salted with "mnemonic".
+func Seed(words []string) ([]byte, error) {
+	list, err := normalize(words, "")
+	if err != nil {
+		return nil, err
+	}
+	return pbkdf2([]byte(strings.Join(list, " ")), []byte("mnemonic"), 2048), nil
+}
+
+// pbkdf2 is PBKDF2 with HMAC-SHA512 and a 64 byte key.
+func pbkdf2(password, salt []byte, iterations int) []byte {
+	mac := hmac.New(sha512.New, password)
+	var block [4]byte
+	binary.BigEndian.PutUint32(block[:], 1)
+	mac.Write(salt)
+	mac.Write(block[:])
+	u := mac.Sum(nil)
+	key := append([]byte(nil), u...)
+	for i := 1; i < iterations; i++ {
+		mac.Reset()
+		mac.Write(u)
+		u = mac.Sum(u[:0])
+		for j := range key {
+			key[j] ^= u[j]
+		}
+	}
+	return key
+}
@@ -0,0 +1,257 @@
+package mvs_api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrUnhealthy is returned without calling the node while it is sick.
+var ErrUnhealthy = errors.New("mvs_api: node is unhealthy")
+
+type HealthState int
+
+const (
+	// Healthy clients take traffic as usual.
+	Healthy HealthState = iota
+	// Sick clients have seen SickThreshold transport or server failures in a
+	// row. Their calls fail with ErrUnhealthy without reaching the node.
+	Sick
+	// HalfOpen clients have been sick for at least ProbeInterval. They let
+	// one call through at a time, a probe or a caller's, to find out whether
+	// the node is back.
+	HalfOpen
+)
+
+func (s HealthState) String() string {
+	switch s {
+	case Healthy:
+		return "healthy"
+	case Sick:
+		return "sick"
+	case HalfOpen:
+		return "half-open"
+	}
+	return "unknown"
+}
+
+type HealthPolicy struct {
+	// SickThreshold is the number of consecutive failures that make a client sick.
+	SickThreshold int
+	// RecoverThreshold is the number of consecutive successes that make a
+	// sick client healthy again.
+	RecoverThreshold int
+	// ProbeInterval is how long a sick client waits before it turns half-open.
+	ProbeInterval time.Duration
+	// ProbeAdminName and ProbeAdminAuth are passed to Getheight by Probe.
+	ProbeAdminName string
+	ProbeAdminAuth string
+	// ManualProbe stops a sick client from probing the node by itself every
+	// ProbeInterval; it then waits for a call, Probe or RPCPool.Watch.
+	ManualProbe bool
+	// Now is the clock used to time ProbeInterval, time.Now if nil.
+	Now func() time.Time
+}
+
+var DefaultHealthPolicy = HealthPolicy{
+	SickThreshold:    5,
+	RecoverThreshold: 1,
+	ProbeInterval:    10 * time.Second,
+}
+
+type HealthStats struct {
+	State                HealthState
+	ConsecutiveFailures  int
+	ConsecutiveSuccesses int
+	Requests             uint64
+	Failures             uint64
+	LastError            error
+	LastFailure          time.Time
+	SickSince            time.Time
+}
+
+// SetHealthPolicy replaces the thresholds used to track the client health.
+// Zero fields fall back to DefaultHealthPolicy.
+func (r *RPCClient) SetHealthPolicy(p HealthPolicy) {
+	if p.SickThreshold <= 0 {
+		p.SickThreshold = DefaultHealthPolicy.SickThreshold
+	}
+	if p.RecoverThreshold <= 0 {
+		p.RecoverThreshold = DefaultHealthPolicy.RecoverThreshold
+	}
+	if p.ProbeInterval <= 0 {
+		p.ProbeInterval = DefaultHealthPolicy.ProbeInterval
+	}
+	r.Lock()
+	r.health = p
+	r.Unlock()
+}
+
+func (r *RPCClient) healthPolicy() HealthPolicy {
+	r.RLock()
+	defer r.RUnlock()
+	if r.health.SickThreshold == 0 {
+		return DefaultHealthPolicy
+	}
+	return r.health
+}
+
+func (r *RPCClient) Healthy() bool {
+	return r.State() == Healthy
+}
+
+func (r *RPCClient) State() HealthState {
+	r.RLock()
+	defer r.RUnlock()
+	return r.state()
+}
+
+func (r *RPCClient) state() HealthState {
+	if !r.sick {
+		return Healthy
+	}
+	if r.now().Sub(r.sickSince) >= r.probeInterval() {
+		return HalfOpen
+	}
+	return Sick
+}
+
+func (r *RPCClient) now() time.Time {
+	if r.health.Now != nil {
+		return r.health.Now()
+	}
+	return time.Now()
+}
+
+func (r *RPCClient) probeInterval() time.Duration {
+	if r.health.ProbeInterval == 0 {
+		return DefaultHealthPolicy.ProbeInterval
+	}
+	return r.health.ProbeInterval
+}
+
+// admit decides whether a call may go to the node. trial is set for the one
+// call a half-open client lets through; pass it to done.
+func (r *RPCClient) admit() (trial bool, err error) {
+	r.Lock()
+	defer r.Unlock()
+	switch r.state() {
+	case Healthy:
+		return false, nil
+	case HalfOpen:
+		if !r.trial {
+			r.trial = true
+			return true, nil
+		}
+	}
+	return false, fmt.Errorf("%w: %s", ErrUnhealthy, r.Url)
+}
+
+// done ends the trial call of a half-open client, whatever its outcome.
+func (r *RPCClient) done(trial bool) {
+	if trial {
+		r.Lock()
+		r.trial = false
+		r.Unlock()
+	}
+}
+
+func (r *RPCClient) Stats() HealthStats {
+	r.RLock()
+	defer r.RUnlock()
+	return HealthStats{
+		State:                r.state(),
+		ConsecutiveFailures:  r.sickRate,
+		ConsecutiveSuccesses: r.successRate,
+		Requests:             r.requests,
+		Failures:             r.failures,
+		LastError:            r.lastError,
+		LastFailure:          r.lastFailure,
+		SickSince:            r.sickSince,
+	}
+}
+
+// Probe checks the node with Getheight, also while it is sick. The outcome
+// is recorded like any other call, so a successful probe is how a sick
+// client gets healthy.
+func (r *RPCClient) Probe(ctx context.Context) error {
+	_, err := r.probe(ctx)
+	if Classify(err) == ErrorClassApplication {
+		// The node answered, which is all a probe wants to know.
+		return nil
+	}
+	return err
+}
+
+// probe sends Getheight once, past the retry policy, so that every failure
+// shows in the health state.
+func (r *RPCClient) probe(ctx context.Context) (uint64, error) {
+	p := r.healthPolicy()
+	method, params := "getheight", []interface{}{p.ProbeAdminName, p.ProbeAdminAuth, map[string]interface{}{}}
+	id := r.nextId(ctx)
+	start := time.Now()
+	rpcResp, err := r.exchange(ctx, r.Url, id, method, params)
+	r.logCall(id, method, start, err)
+	if err != nil {
+		return 0, err
+	}
+	var height uint64
+	err = decodeResult(rpcResp.Result, "", &height)
+	return height, err
+}
+
+// scheduleProbe probes the node once the client turns half-open. A failed
+// probe marks the client sick again, which schedules the next one.
+func (r *RPCClient) scheduleProbe() {
+	if r.health.ManualProbe || r.probeTimer != nil {
+		return
+	}
+	r.probeTimer = time.AfterFunc(r.probeInterval(), func() {
+		r.Lock()
+		r.probeTimer = nil
+		sick := r.sick
+		r.Unlock()
+		if sick {
+			r.Probe(context.Background())
+		}
+	})
+}
+
+func (r *RPCClient) markSuccess() {
+	r.Lock()
+	r.requests++
+	r.successRate++
+	r.sickRate = 0
+	threshold := r.health.RecoverThreshold
+	if threshold == 0 {
+		threshold = DefaultHealthPolicy.RecoverThreshold
+	}
+	if r.sick && r.successRate >= threshold {
+		r.sick = false
+		r.sickSince = time.Time{}
+	}
+	r.Unlock()
+}
+
+func (r *RPCClient) markSick(err error) {
+	r.Lock()
+	r.requests++
+	r.failures++
+	r.sickRate++
+	r.successRate = 0
+	r.lastError = err
+	r.lastFailure = r.now()
+	threshold := r.health.SickThreshold
+	if threshold == 0 {
+		threshold = DefaultHealthPolicy.SickThreshold
+	}
+	if r.sick || r.sickRate >= threshold {
+		// Every failure while sick, including a failed probe, restarts
+		// the wait before the next probe.
+		r.sick = true
+		r.sickSince = r.lastFailure
+		r.scheduleProbe()
+	}
+	r.Unlock()
+}
@@ -0,0 +1,191 @@
+package mvs_api_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"mvs_api"
+	"mvs_api/mvstest"
+)
+
+type clock struct {
+	mu sync.Mutex
+	t  time.Time
+}
+
+func (c *clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.t
+}
+
+func (c *clock) Advance(d time.Duration) {
+	c.mu.Lock()
+	c.t = c.t.Add(d)
+	c.mu.Unlock()
+}
+
+func newClock() *clock {
+	return &clock{t: time.Date(2018, 6, 27, 0, 0, 0, 0, time.UTC)}
+}
+
+var errBroken = errors.New("broken node")
+
+func TestHealthTransitions(t *testing.T) {
+	s := mvstest.NewServer()
+	defer s.Close()
+	clk := newClock()
+	r := s.Client(mvs_api.WithHealthPolicy(mvs_api.HealthPolicy{
+		SickThreshold:    2,
+		RecoverThreshold: 2,
+		ProbeInterval:    time.Minute,
+		ManualProbe:      true,
+		Now:              clk.Now,
+	}))
+	state := func(want mvs_api.HealthState) {
+		t.Helper()
+		if got := r.State(); got != want {
+			t.Fatalf("state = %v, want %v", got, want)
+		}
+	}
+
+	// Rejections by the node are answers, they do not count.
+	s.Fail("getheight", &mvs_api.RPCError{Code: 1002, Message: "account password authority failed"})
+	for i := 0; i < 5; i++ {
+		r.Getheight("", "")
+	}
+	state(mvs_api.Healthy)
+
+	s.Fail("getheight", errBroken)
+	r.Getheight("", "")
+	state(mvs_api.Healthy)
+	if st := r.Stats(); st.ConsecutiveFailures != 1 || st.Failures != 1 {
+		t.Errorf("stats after one failure: %+v", st)
+	}
+	r.Getheight("", "")
+	state(mvs_api.Sick)
+	if st := r.Stats(); !st.SickSince.Equal(clk.Now()) || st.LastFailure != clk.Now() {
+		t.Errorf("stats when sick: %+v", st)
+	}
+
+	// A sick client does not bother the node.
+	before := len(s.Calls(""))
+	if _, err := r.Getheight("", ""); !errors.Is(err, mvs_api.ErrUnhealthy) {
+		t.Errorf("call while sick: err = %v, want ErrUnhealthy", err)
+	}
+	if len(s.Calls("")) != before {
+		t.Error("a call reached the node while sick")
+	}
+
+	clk.Advance(59 * time.Second)
+	state(mvs_api.Sick)
+	clk.Advance(time.Second)
+	state(mvs_api.HalfOpen)
+
+	// A failed trial starts the wait again.
+	r.Getheight("", "")
+	state(mvs_api.Sick)
+	if len(s.Calls("")) != before+1 {
+		t.Error("the half-open client did not try the node")
+	}
+	clk.Advance(time.Minute)
+	state(mvs_api.HalfOpen)
+
+	s.Reset()
+	if _, err := r.Getheight("", ""); err != nil {
+		t.Fatal(err)
+	}
+	state(mvs_api.HalfOpen)
+	if _, err := r.Getheight("", ""); err != nil {
+		t.Fatal(err)
+	}
+	state(mvs_api.Healthy)
+	if st := r.Stats(); st.ConsecutiveSuccesses != 2 || st.ConsecutiveFailures != 0 || !st.SickSince.IsZero() {
+		t.Errorf("stats after recovery: %+v", st)
+	}
+}
+
+func TestHealthHalfOpenOneTrial(t *testing.T) {
+	s := mvstest.NewServer()
+	defer s.Close()
+	clk := newClock()
+	r := s.Client(mvs_api.WithHealthPolicy(mvs_api.HealthPolicy{SickThreshold: 1, ProbeInterval: time.Minute, ManualProbe: true, Now: clk.Now}))
+	s.Fail("getheight", errBroken)
+	r.Getheight("", "")
+	clk.Advance(time.Minute)
+
+	entered, release := block(s, "getheight")
+	errc := make(chan error, 1)
+	go func() {
+		_, err := r.Getheight("", "")
+		errc <- err
+	}()
+	<-entered
+	if _, err := r.Getheight("", ""); !errors.Is(err, mvs_api.ErrUnhealthy) {
+		t.Errorf("second call during the trial: err = %v, want ErrUnhealthy", err)
+	}
+	release()
+	if err := <-errc; err != nil {
+		t.Fatal(err)
+	}
+	if r.State() != mvs_api.Healthy {
+		t.Errorf("state = %v, want healthy", r.State())
+	}
+}
+
+func TestHealthProbe(t *testing.T) {
+	s := mvstest.NewServer()
+	defer s.Close()
+	clk := newClock()
+	r := s.Client(
+		mvs_api.WithHealthPolicy(mvs_api.HealthPolicy{SickThreshold: 1, ProbeInterval: time.Minute, ManualProbe: true, Now: clk.Now}),
+		mvs_api.WithRetryPolicy(mvs_api.RetryPolicy{MaxAttempts: 5, InitialBackoff: time.Millisecond}),
+	)
+	s.Fail("getheight", errBroken)
+
+	// The probe goes past the retry policy, so its one failure shows.
+	if err := r.Probe(context.Background()); err == nil {
+		t.Fatal("probe of a broken node succeeded")
+	}
+	if n := len(s.Calls("getheight")); n != 1 {
+		t.Errorf("probe sent %d calls, want 1", n)
+	}
+	if r.State() != mvs_api.Sick {
+		t.Fatalf("state = %v, want sick", r.State())
+	}
+
+	// Probe works on a sick client, and an answer of any kind heals it.
+	s.Fail("getheight", &mvs_api.RPCError{Code: 1002, Message: "administrator authority failed"})
+	if err := r.Probe(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if r.State() != mvs_api.Healthy {
+		t.Errorf("state = %v, want healthy", r.State())
+	}
+}
+
+func TestHealthBackgroundProbe(t *testing.T) {
+	s := mvstest.NewServer()
+	defer s.Close()
+	r := s.Client(mvs_api.WithHealthPolicy(mvs_api.HealthPolicy{SickThreshold: 1, ProbeInterval: 10 * time.Millisecond}))
+	s.Fail("getheight", errBroken)
+	r.Getheight("", "")
+	if r.State() == mvs_api.Healthy {
+		t.Fatal("state = healthy after a failure")
+	}
+	s.Reset()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for r.State() != mvs_api.Healthy {
+		if time.Now().After(deadline) {
+			t.Fatal("the client did not recover by itself")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	if len(s.Calls("getheight")) == 0 {
+		t.Error("no probe reached the node")
+	}
+}
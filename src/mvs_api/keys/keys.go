@@ -0,0 +1,157 @@
+// Package keys holds the secp256k1 keys that own MVS addresses and signs
+// with them offline, so that a private key need not be imported into mvsd:
+//
+//	key, err := keys.ParsePrivateKey(hexKey)
+//	...
+//	fmt.Println(key.PublicKey().Address(false))
+//	sig, err := key.Sign(hash)
+//
+// Signatures are deterministic (RFC 6979) and DER encoded with a low S, as
+// the node requires. The curve arithmetic is that of the decred secp256k1
+// package, which is constant time where secrets are involved.
+package keys
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+	"golang.org/x/crypto/ripemd160"
+
+	"mvs_api/address"
+)
+
+var (
+	ErrBadPrivateKey = errors.New("keys: bad private key")
+	ErrBadPublicKey  = errors.New("keys: bad public key")
+	ErrBadSignature  = errors.New("keys: bad signature")
+)
+
+type PrivateKey struct {
+	k *secp256k1.PrivateKey
+}
+
+// NewPrivateKey takes the 32 byte big-endian secret, which must be in
+// [1, n-1].
+func NewPrivateKey(b []byte) (*PrivateKey, error) {
+	if len(b) != 32 {
+		return nil, ErrBadPrivateKey
+	}
+	var d secp256k1.ModNScalar
+	if overflow := d.SetByteSlice(b); overflow || d.IsZero() {
+		return nil, ErrBadPrivateKey
+	}
+	return &PrivateKey{k: secp256k1.NewPrivateKey(&d)}, nil
+}
+
+// ParsePrivateKey reads a hex encoded secret.
+func ParsePrivateKey(s string) (*PrivateKey, error) {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return nil, ErrBadPrivateKey
+	}
+	return NewPrivateKey(b)
+}
+
+// Bytes is the 32 byte secret.
+func (k *PrivateKey) Bytes() []byte {
+	return k.k.Serialize()
+}
+
+// Add returns the key k + tweak mod n, as BIP32 derives a child key. It
+// fails for a tweak of n or more, or a zero result.
+func (k *PrivateKey) Add(tweak []byte) (*PrivateKey, error) {
+	var d secp256k1.ModNScalar
+	if len(tweak) > 32 || d.SetByteSlice(tweak) {
+		return nil, ErrBadPrivateKey
+	}
+	d.Add(&k.k.Key)
+	if d.IsZero() {
+		return nil, ErrBadPrivateKey
+	}
+	return &PrivateKey{k: secp256k1.NewPrivateKey(&d)}, nil
+}
+
+func (k *PrivateKey) PublicKey() *PublicKey {
+	return &PublicKey{p: k.k.PubKey()}
+}
+
+// Sign signs a 32 byte hash and returns the DER signature, without the hash
+// type byte scripts append.
+func (k *PrivateKey) Sign(hash []byte) ([]byte, error) {
+	if len(hash) != 32 {
+		return nil, errors.New("keys: hash must be 32 bytes")
+	}
+	return ecdsa.Sign(k.k, hash).Serialize(), nil
+}
+
+type PublicKey struct {
+	p *secp256k1.PublicKey
+}
+
+// ParsePublicKey reads a compressed (33 byte) or uncompressed (65 byte)
+// SEC encoded key.
+func ParsePublicKey(b []byte) (*PublicKey, error) {
+	if len(b) != 33 && (len(b) != 65 || b[0] != 0x04) {
+		return nil, ErrBadPublicKey
+	}
+	p, err := secp256k1.ParsePubKey(b)
+	if err != nil {
+		return nil, ErrBadPublicKey
+	}
+	return &PublicKey{p: p}, nil
+}
+
+// Bytes is the compressed encoding the MVS wallet uses.
+func (pub *PublicKey) Bytes() []byte {
+	return pub.p.SerializeCompressed()
+}
+
+func (pub *PublicKey) String() string {
+	return hex.EncodeToString(pub.Bytes())
+}
+
+func (pub *PublicKey) Equal(other *PublicKey) bool {
+	return bytes.Equal(pub.Bytes(), other.Bytes())
+}
+
+// Address is the pay-to-public-key-hash address of the compressed key.
+func (pub *PublicKey) Address(testnet bool) string {
+	return address.New(address.PubKeyHash, testnet, Hash160(pub.Bytes())).String()
+}
+
+// Verify reports whether sig, DER with or without a trailing hash type
+// byte, is a signature of hash by pub.
+func (pub *PublicKey) Verify(hash, sig []byte) bool {
+	s, err := parseSignature(sig)
+	if err != nil {
+		return false
+	}
+	return s.Verify(hash, pub.p)
+}
+
+// Hash160 is RIPEMD-160 of SHA-256, the hash addresses are built on.
+func Hash160(b []byte) [20]byte {
+	sum := sha256.Sum256(b)
+	h := ripemd160.New()
+	h.Write(sum[:])
+	var out [20]byte
+	copy(out[:], h.Sum(nil))
+	return out
+}
+
+// parseSignature reads a strict DER signature, allowing one trailing hash
+// type byte.
+func parseSignature(sig []byte) (*ecdsa.Signature, error) {
+	if len(sig) >= 2 && int(sig[1])+3 == len(sig) {
+		sig = sig[:len(sig)-1]
+	}
+	s, err := ecdsa.ParseDERSignature(sig)
+	if err != nil {
+		return nil, ErrBadSignature
+	}
+	return s, nil
+}
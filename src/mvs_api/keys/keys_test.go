@@ -0,0 +1,168 @@
+package keys
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"testing"
+)
+
+func mustKey(t *testing.T, s string) *PrivateKey {
+	t.Helper()
+	k, err := ParsePrivateKey(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return k
+}
+
+const one = "0000000000000000000000000000000000000000000000000000000000000001"
+
+func TestPublicKey(t *testing.T) {
+	pub := mustKey(t, one).PublicKey()
+	if got, want := pub.String(), "0279be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798"; got != want {
+		t.Errorf("public key of 1 = %s, want G %s", got, want)
+	}
+	if got := Hash160(pub.Bytes()); hex.EncodeToString(got[:]) != "751e76e8199196d454941c45d1b3a323f1433bd6" {
+		t.Errorf("Hash160 = %x", got)
+	}
+
+	uncompressed, _ := hex.DecodeString("0479be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798483ada7726a3c4655da4fbfc0e1108a8fd17b448a68554199c47d08ffb10d4b8")
+	parsed, err := ParsePublicKey(uncompressed)
+	if err != nil || !parsed.Equal(pub) {
+		t.Errorf("ParsePublicKey(uncompressed G) = %v, %v", parsed, err)
+	}
+	back, err := ParsePublicKey(pub.Bytes())
+	if err != nil || !back.Equal(pub) {
+		t.Errorf("ParsePublicKey(compressed G) = %v, %v", back, err)
+	}
+	uncompressed[64] ^= 1
+	if _, err := ParsePublicKey(uncompressed); err != ErrBadPublicKey {
+		t.Errorf("point off the curve: err = %v", err)
+	}
+	if _, err := ParsePublicKey(pub.Bytes()[:32]); err != ErrBadPublicKey {
+		t.Errorf("short key: err = %v", err)
+	}
+}
+
+func TestPrivateKeyRange(t *testing.T) {
+	for _, s := range []string{
+		"0000000000000000000000000000000000000000000000000000000000000000",
+		"fffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd0364141",
+		"01",
+		"zz",
+	} {
+		if _, err := ParsePrivateKey(s); err != ErrBadPrivateKey {
+			t.Errorf("ParsePrivateKey(%s): err = %v", s, err)
+		}
+	}
+	max := mustKey(t, "fffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd0364140")
+	if _, err := max.Add(mustKey(t, one).Bytes()); err != ErrBadPrivateKey {
+		t.Errorf("n-1 + 1: err = %v, want a zero key refused", err)
+	}
+	sum, err := mustKey(t, one).Add(mustKey(t, one).Bytes())
+	if err != nil || hex.EncodeToString(sum.Bytes()) != strings.Repeat("0", 63)+"2" {
+		t.Errorf("1 + 1 = %v, %v", sum, err)
+	}
+}
+
+func TestSignRFC6979(t *testing.T) {
+	// Deterministic signatures of secp256k1 with SHA-256, as published by
+	// bitcoinjs and python-ecdsa, with S made low.
+	tests := []struct{ key, msg, r, s string }{
+		{one, "Satoshi Nakamoto",
+			"934b1ea10a4b3c1757e2b0c017d0b6143ce3c9a7e6a4a49860d7a6ab210ee3d8",
+			"2442ce9d2b916064108014783e923ec36b49743e2ffa1c4496f01a512aafd9e5"},
+		{one, "All those moments will be lost in time, like tears in rain. Time to die...",
+			"8600dbd41e348fe5c9465ab92d23e3db8b98b873beecd930736488696438cb6b",
+			"547fe64427496db33bf66019dacbf0039c04199abb0122918601db38a72cfc21"},
+	}
+	for _, tt := range tests {
+		k := mustKey(t, tt.key)
+		hash := sha256.Sum256([]byte(tt.msg))
+		sig, err := k.Sign(hash[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+		parsed, err := parseSignature(sig)
+		if err != nil {
+			t.Fatalf("%q: %v", tt.msg, err)
+		}
+		r, s := parsed.R(), parsed.S()
+		if got := r.String(); got != tt.r {
+			t.Errorf("%q: r = %s, want %s", tt.msg, got, tt.r)
+		}
+		if got := s.String(); got != tt.s {
+			t.Errorf("%q: s = %s, want %s", tt.msg, got, tt.s)
+		}
+		if !k.PublicKey().Verify(hash[:], sig) {
+			t.Errorf("%q: signature does not verify", tt.msg)
+		}
+	}
+}
+
+func TestSignVerify(t *testing.T) {
+	k := mustKey(t, "c28a9f80738f770d527803a566cf6fc3edf6cea586c4fc4a5223a5ad797e1ac3")
+	other := mustKey(t, one)
+	for i := 0; i < 32; i++ {
+		hash := sha256.Sum256([]byte{byte(i)})
+		sig, err := k.Sign(hash[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+		parsed, err := parseSignature(sig)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if s := parsed.S(); s.IsOverHalfOrder() {
+			t.Errorf("hash %d: high S %s", i, s.String())
+		}
+		if !k.PublicKey().Verify(hash[:], sig) {
+			t.Errorf("hash %d: signature does not verify", i)
+		}
+		if !k.PublicKey().Verify(hash[:], append(sig, 0x01)) {
+			t.Errorf("hash %d: signature with hash type does not verify", i)
+		}
+		if other.PublicKey().Verify(hash[:], sig) {
+			t.Errorf("hash %d: verifies with another key", i)
+		}
+		hash[0] ^= 1
+		if k.PublicKey().Verify(hash[:], sig) {
+			t.Errorf("hash %d: verifies for another hash", i)
+		}
+	}
+	hash := sha256.Sum256(nil)
+	first, _ := k.Sign(hash[:])
+	again, _ := k.Sign(hash[:])
+	if hex.EncodeToString(first) != hex.EncodeToString(again) {
+		t.Error("signatures are not deterministic")
+	}
+	if _, err := k.Sign(make([]byte, 31)); err == nil {
+		t.Error("signed a 31 byte hash")
+	}
+}
+
+func TestParseSignature(t *testing.T) {
+	good := "3006020101020102"
+	bad := []string{
+		"3106020101020102",     // not a sequence
+		"3007020101020102",     // wrong length
+		"30060201010201020000", // two trailing bytes
+		"300602010102018f",     // negative S
+		"30070202000102010200", // padded R
+		"3006020100020102",     // zero R
+	}
+	b, _ := hex.DecodeString(good)
+	if _, err := parseSignature(b); err != nil {
+		t.Errorf("parseSignature(%s): %v", good, err)
+	}
+	if _, err := parseSignature(append(b, 1)); err != nil {
+		t.Errorf("parseSignature(%s01): %v", good, err)
+	}
+	for _, s := range bad {
+		b, _ := hex.DecodeString(s)
+		if _, err := parseSignature(b); err == nil {
+			t.Errorf("parseSignature(%s) took it", s)
+		}
+	}
+}
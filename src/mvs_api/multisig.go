@@ -0,0 +1,268 @@
+package mvs_api
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"mvs_api/address"
+	"mvs_api/envelope"
+	"mvs_api/rawtx"
+)
+
+var (
+	ErrNotCosigner       = errors.New("mvs_api: not a public key of the multisig")
+	ErrAlreadySigned     = errors.New("mvs_api: public key has already signed")
+	ErrAlreadyBroadcast  = errors.New("mvs_api: multisig spend already broadcast")
+	ErrMissingSignatures = errors.New("mvs_api: multisig spend is missing signatures")
+	ErrMultisigMismatch  = errors.New("mvs_api: multisig address does not match its keys")
+	ErrNotSigned         = errors.New("mvs_api: node did not sign with the public key")
+)
+
+// Verify rebuilds the redeem script and address of m offline from M and the
+// public keys, as returned by Getnewmultisig or Listmultisig or passed on by
+// a cosigner, and fails unless both match MultisigScript, if set, and
+// Address.
+func (m *Multisig) Verify() error {
+	addr, err := address.Decode(m.Address)
+	if err != nil {
+		return err
+	}
+	keys := m.keys()
+	if m.N != 0 && int(m.N) != len(keys) {
+		return fmt.Errorf("%w: n is %d for %d keys", ErrMultisigMismatch, m.N, len(keys))
+	}
+	script, err := rawtx.MultisigScript(int(m.M), keys)
+	if err != nil {
+		return err
+	}
+	if m.MultisigScript != "" {
+		reported, err := rawtx.ParseMultisigText(m.MultisigScript)
+		if err != nil {
+			return fmt.Errorf("%w: multisig script: %v", ErrMultisigMismatch, err)
+		}
+		if !bytes.Equal(reported, script) {
+			return fmt.Errorf("%w: multisig script", ErrMultisigMismatch)
+		}
+	}
+	want, err := rawtx.MultisigAddress(int(m.M), keys, addr.Testnet())
+	if err != nil {
+		return err
+	}
+	if want != m.Address {
+		return fmt.Errorf("%w: want %s", ErrMultisigMismatch, want)
+	}
+	return nil
+}
+
+// keys is PublicKeys with SelfPublicKey, should the node have left it out.
+func (m *Multisig) keys() []string {
+	if m.SelfPublicKey != "" && !containsKey(m.PublicKeys, m.SelfPublicKey) {
+		return append(append([]string(nil), m.PublicKeys...), m.SelfPublicKey)
+	}
+	return m.PublicKeys
+}
+
+// RedeemScript builds the redeem script of m from M and the public keys.
+// The node reports it as MultisigScript in text form, which
+// rawtx.ParseMultisigText reads.
+func (m *Multisig) RedeemScript() ([]byte, error) {
+	return rawtx.MultisigScript(int(m.M), m.keys())
+}
+
+// MultisigSpend follows one spend from a multisig address, from
+// Createmultisigtx through one Signmultisigtx per cosigner to Sendrawtx,
+// which it calls itself once M keys have signed:
+//
+//	spend, err := r.NewMultisigSpend(ctx, "Alice", "A123456", multisig, to, 10*ETP, "", 0)
+//	err = spend.Sign(ctx, "Alice", "A123456", alicePublicKey)
+//	err = spend.Sign(ctx, "Bob", "B123456", bobPublicKey)
+//	fmt.Println(spend.Hash())
+//
+// It is safe for concurrent use.
+type MultisigSpend struct {
+	client   *RPCClient
+	multisig Multisig
+
+	mu     sync.Mutex
+	rawtx  string
+	signed []string
+	hash   string
+}
+
+// NewMultisigSpend creates the transaction sending amount of symbol, or of
+// ETP if symbol is "", from the multisig address to to. The account must
+// hold the multisig.
+func (r *RPCClient) NewMultisigSpend(ctx context.Context, account, password string, multisig *Multisig, to string, amount Amount, symbol string, fee Amount) (*MultisigSpend, error) {
+	var txType uint16
+	if symbol != "" {
+		txType = 3
+	}
+	tx, err := r.CreatemultisigtxContext(ctx, account, password, multisig.Address, to, amount, symbol, txType, fee)
+	if err != nil {
+		return nil, err
+	}
+	return &MultisigSpend{client: r, multisig: *multisig, rawtx: tx}, nil
+}
+
+// ResumeMultisigSpend picks up a spend from its raw transaction, as passed
+// on by another cosigner. The keys that have signed are read from the
+// transaction.
+func (r *RPCClient) ResumeMultisigSpend(multisig *Multisig, tx string) (*MultisigSpend, error) {
+	s := &MultisigSpend{client: r, multisig: *multisig, rawtx: tx}
+	signed, err := multisigSigners(multisig, tx)
+	if err != nil {
+		return nil, err
+	}
+	s.signed = signed
+	return s, nil
+}
+
+// multisigSigners lists the public keys of multisig that signed every input
+// of tx.
+func multisigSigners(multisig *Multisig, tx string) ([]string, error) {
+	decoded, err := rawtx.Decode(tx)
+	if err != nil {
+		return nil, err
+	}
+	redeem, err := multisig.RedeemScript()
+	if err != nil {
+		return nil, err
+	}
+	count := map[string]int{}
+	for i := range decoded.Inputs {
+		pubs, err := decoded.MultisigSigners(i, redeem)
+		if err != nil {
+			return nil, err
+		}
+		for _, pub := range pubs {
+			count[pub.String()]++
+		}
+	}
+	var signed []string
+	for _, pub := range multisig.keys() {
+		if len(decoded.Inputs) > 0 && count[strings.ToLower(pub)] == len(decoded.Inputs) {
+			signed = append(signed, pub)
+		}
+	}
+	return signed, nil
+}
+
+// Sign has account sign with the key of publicKey, then broadcasts the
+// transaction if that was the last signature needed. A key that is not in
+// the multisig or has signed already is refused without calling the node,
+// and the signed transaction is refused with ErrNotSigned unless it carries
+// a valid signature of every input by publicKey.
+func (s *MultisigSpend) Sign(ctx context.Context, account, password, publicKey string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.hash != "" {
+		return ErrAlreadyBroadcast
+	}
+	if !containsKey(s.multisig.keys(), publicKey) {
+		return fmt.Errorf("%w: %s", ErrNotCosigner, publicKey)
+	}
+	if containsKey(s.signed, publicKey) {
+		return fmt.Errorf("%w: %s", ErrAlreadySigned, publicKey)
+	}
+	tx, err := s.client.SignmultisigtxContext(ctx, account, password, s.rawtx, publicKey, false)
+	if err != nil {
+		return err
+	}
+	signers, err := multisigSigners(&s.multisig, tx)
+	if err != nil {
+		return err
+	}
+	if !containsKey(signers, publicKey) {
+		return fmt.Errorf("%w: %s", ErrNotSigned, publicKey)
+	}
+	s.rawtx = tx
+	s.signed = append(s.signed, publicKey)
+	if s.missing() > 0 {
+		return nil
+	}
+	return s.broadcast(ctx)
+}
+
+// Broadcast sends a fully signed transaction. Sign does so by itself;
+// Broadcast is for retrying after that failed.
+func (s *MultisigSpend) Broadcast(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.hash != "" {
+		return ErrAlreadyBroadcast
+	}
+	if n := s.missing(); n > 0 {
+		return fmt.Errorf("%w: %d more needed", ErrMissingSignatures, n)
+	}
+	return s.broadcast(ctx)
+}
+
+func (s *MultisigSpend) broadcast(ctx context.Context) error {
+	hash, err := s.client.SendrawtxContext(ctx, s.rawtx, 0)
+	if err != nil {
+		return err
+	}
+	s.hash = hash
+	return nil
+}
+
+// Signed lists the public keys that have signed, in the order they did.
+func (s *MultisigSpend) Signed() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.signed...)
+}
+
+// Missing is the number of signatures still needed.
+func (s *MultisigSpend) Missing() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.missing()
+}
+
+func (s *MultisigSpend) missing() int {
+	if n := int(s.multisig.M) - len(s.signed); n > 0 {
+		return n
+	}
+	return 0
+}
+
+// RawTx is the transaction with the signatures so far.
+func (s *MultisigSpend) RawTx() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.rawtx
+}
+
+// Envelope writes the spend so far in the format of package envelope, for a
+// cosigner who is not reachable through this client.
+func (s *MultisigSpend) Envelope(description string) (*envelope.Envelope, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	redeem, err := s.multisig.RedeemScript()
+	if err != nil {
+		return nil, err
+	}
+	return envelope.New(s.rawtx, hex.EncodeToString(redeem), description)
+}
+
+// Hash is the hash Sendrawtx returned, "" until the spend is broadcast.
+func (s *MultisigSpend) Hash() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.hash
+}
+
+func containsKey(list []string, key string) bool {
+	for _, k := range list {
+		if strings.EqualFold(k, key) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,254 @@
+package mvs_api_test
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+
+	"mvs_api"
+	"mvs_api/keys"
+	"mvs_api/mvstest"
+	"mvs_api/rawtx"
+)
+
+// cosigner is the private key with secret b.
+func cosigner(b byte) *keys.PrivateKey {
+	k, err := keys.NewPrivateKey(append(make([]byte, 31), b))
+	if err != nil {
+		panic(err)
+	}
+	return k
+}
+
+// testMultisig is the 2 of 3 multisig of cosigners 1, 2 and 3, as
+// Getnewmultisig reports it to the account of cosigner 1.
+func testMultisig(t *testing.T) *mvs_api.Multisig {
+	t.Helper()
+	var pubs []string
+	for _, b := range []byte{1, 2, 3} {
+		pubs = append(pubs, cosigner(b).PublicKey().String())
+	}
+	addr, err := rawtx.MultisigAddress(2, pubs, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sorted := append([]string(nil), pubs...)
+	sort.Strings(sorted)
+	return &mvs_api.Multisig{
+		Address:        addr,
+		M:              2,
+		N:              3,
+		PublicKeys:     pubs,
+		SelfPublicKey:  pubs[0],
+		MultisigScript: fmt.Sprintf("2 [ %s ] [ %s ] [ %s ] 3 checkmultisig", sorted[0], sorted[1], sorted[2]),
+	}
+}
+
+// signer answers signmultisigtx like mvsd holding the cosigner keys.
+func signer(t *testing.T, multisig *mvs_api.Multisig) mvstest.Handler {
+	redeem, err := multisig.RedeemScript()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return func(c mvstest.Call) (interface{}, error) {
+		tx, err := rawtx.Decode(c.Positional[2].(string))
+		if err != nil {
+			return nil, err
+		}
+		var key *keys.PrivateKey
+		for _, b := range []byte{1, 2, 3} {
+			if cosigner(b).PublicKey().String() == c.Optional["selfpublickey"] {
+				key = cosigner(b)
+			}
+		}
+		for i := range tx.Inputs {
+			have, err := tx.MultisigSignatures(i, redeem)
+			if err != nil {
+				return nil, err
+			}
+			sig, err := tx.Sign(i, redeem, key)
+			if err != nil {
+				return nil, err
+			}
+			sigs := [][]byte{sig}
+			for _, s := range have {
+				sigs = append(sigs, s)
+			}
+			if err := tx.SetPartialMultisigScript(i, redeem, sigs); err != nil {
+				return nil, err
+			}
+		}
+		return tx.Hex()
+	}
+}
+
+func TestMultisigRedeemScript(t *testing.T) {
+	var fixture mvs_api.Multisig
+	if err := json.Unmarshal([]byte(mvstest.Fixtures["getnewmultisig"]), &fixture); err != nil {
+		t.Fatal(err)
+	}
+	script, err := fixture.RedeemScript()
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, pubs, err := rawtx.ParseMultisig(script)
+	if err != nil || m != 2 || len(pubs) != 3 {
+		t.Fatalf("ParseMultisig = %d, %v, %v", m, pubs, err)
+	}
+	for i, pub := range pubs {
+		if pub.String() != fixture.PublicKeys[i] {
+			t.Errorf("key %d of the script is %s, want %s", i, pub, fixture.PublicKeys[i])
+		}
+	}
+	// The node may leave its own key out of public-keys.
+	fixture.PublicKeys = []string{fixture.PublicKeys[0], fixture.PublicKeys[2]}
+	if again, err := fixture.RedeemScript(); err != nil || string(again) != string(script) {
+		t.Errorf("redeem script without the self key = %x, %v", again, err)
+	}
+}
+
+func TestMultisigSpend(t *testing.T) {
+	s := mvstest.NewServer()
+	defer s.Close()
+	c := s.Client()
+	ctx := context.Background()
+	multisig := testMultisig(t)
+	s.Handle("signmultisigtx", signer(t, multisig))
+	pubs := multisig.PublicKeys
+
+	spend, err := c.NewMultisigSpend(ctx, "Alice", "A123456", multisig, mvstest.Address, mvs_api.ETP, "", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := spend.Sign(ctx, "Alice", "A123456", mvstest.Address); !errors.Is(err, mvs_api.ErrNotCosigner) {
+		t.Errorf("sign with a stranger: err = %v", err)
+	}
+	if err := spend.Sign(ctx, "Alice", "A123456", pubs[0]); err != nil {
+		t.Fatal(err)
+	}
+	if err := spend.Sign(ctx, "Alice", "A123456", pubs[0]); !errors.Is(err, mvs_api.ErrAlreadySigned) {
+		t.Errorf("sign twice: err = %v", err)
+	}
+	if err := spend.Broadcast(ctx); !errors.Is(err, mvs_api.ErrMissingSignatures) {
+		t.Errorf("broadcast with 1 of 2: err = %v", err)
+	}
+	if spend.Missing() != 1 {
+		t.Errorf("Missing = %d after one signature", spend.Missing())
+	}
+
+	// Bob picks the spend up from the transaction Alice passed on.
+	bobs, err := c.ResumeMultisigSpend(multisig, spend.RawTx())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if signed := bobs.Signed(); len(signed) != 1 || signed[0] != pubs[0] {
+		t.Errorf("resumed spend signed by %v", signed)
+	}
+	if err := bobs.Sign(ctx, "Bob", "B123456", pubs[2]); err != nil {
+		t.Fatal(err)
+	}
+	if bobs.Hash() != mvstest.TxHash {
+		t.Errorf("Hash = %q after the last signature", bobs.Hash())
+	}
+	if err := bobs.Sign(ctx, "Bob", "B123456", pubs[1]); !errors.Is(err, mvs_api.ErrAlreadyBroadcast) {
+		t.Errorf("sign after broadcast: err = %v", err)
+	}
+
+	sent, err := rawtx.Decode(s.LastCall(t, "sendrawtx").Positional[0].(string))
+	if err != nil {
+		t.Fatal(err)
+	}
+	redeem, _ := multisig.RedeemScript()
+	if signers, err := sent.MultisigSigners(0, redeem); err != nil || len(signers) != 2 {
+		t.Errorf("sent transaction signed by %v, %v", signers, err)
+	}
+}
+
+func TestMultisigSpendNotSigned(t *testing.T) {
+	s := mvstest.NewServer()
+	defer s.Close()
+	ctx := context.Background()
+	multisig := testMultisig(t)
+	// A node without the key hands the transaction back unsigned.
+	s.Handle("signmultisigtx", func(c mvstest.Call) (interface{}, error) {
+		return c.Positional[2], nil
+	})
+
+	spend, err := s.Client().NewMultisigSpend(ctx, "Alice", "A123456", multisig, mvstest.Address, mvs_api.ETP, "", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := spend.Sign(ctx, "Alice", "A123456", multisig.PublicKeys[1]); !errors.Is(err, mvs_api.ErrNotSigned) {
+		t.Errorf("unsigned result: err = %v", err)
+	}
+	if len(spend.Signed()) != 0 || spend.Missing() != 2 {
+		t.Errorf("spend counts a signature it does not have: %v", spend.Signed())
+	}
+}
+
+func TestMultisigSpendEnvelope(t *testing.T) {
+	s := mvstest.NewServer()
+	defer s.Close()
+	ctx := context.Background()
+	multisig := testMultisig(t)
+	s.Handle("signmultisigtx", signer(t, multisig))
+
+	spend, err := s.Client().NewMultisigSpend(ctx, "Alice", "A123456", multisig, mvstest.Address, mvs_api.ETP, "", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := spend.Sign(ctx, "Alice", "A123456", multisig.PublicKeys[0]); err != nil {
+		t.Fatal(err)
+	}
+	e, err := spend.Envelope("pay the auditors")
+	if err != nil {
+		t.Fatal(err)
+	}
+	redeem, _ := multisig.RedeemScript()
+	if e.RedeemScript != hex.EncodeToString(redeem) {
+		t.Errorf("envelope redeem script %s, want %x", e.RedeemScript, redeem)
+	}
+	if got := e.Signers(); len(got) != 1 || got[0] != multisig.PublicKeys[0] {
+		t.Errorf("envelope signers %v", got)
+	}
+}
+
+func TestMultisigVerify(t *testing.T) {
+	var fixture mvs_api.Multisig
+	if err := json.Unmarshal([]byte(mvstest.Fixtures["getnewmultisig"]), &fixture); err != nil {
+		t.Fatal(err)
+	}
+	if err := fixture.Verify(); err != nil {
+		t.Fatalf("fixture %s: %v", mvstest.Multisig, err)
+	}
+	if err := testMultisig(t).Verify(); err != nil {
+		t.Errorf("test multisig: %v", err)
+	}
+
+	tests := []func(m *mvs_api.Multisig){
+		func(m *mvs_api.Multisig) { m.M = 3 },
+		func(m *mvs_api.Multisig) { m.N = 4 },
+		func(m *mvs_api.Multisig) { m.Address = mvstest.Address },
+		func(m *mvs_api.Multisig) { m.MultisigScript = strings.Replace(m.MultisigScript, "2 [", "1 [", 1) },
+		func(m *mvs_api.Multisig) { m.MultisigScript = "garbage" },
+		func(m *mvs_api.Multisig) { m.PublicKeys = m.PublicKeys[:2]; m.SelfPublicKey = "" },
+	}
+	for i, tamper := range tests {
+		m := fixture
+		m.PublicKeys = append([]string(nil), fixture.PublicKeys...)
+		tamper(&m)
+		if err := m.Verify(); err == nil {
+			t.Errorf("tampered multisig %d verifies", i)
+		}
+	}
+	m := fixture
+	m.MultisigScript = ""
+	if err := m.Verify(); err != nil {
+		t.Errorf("without multisig-script: %v", err)
+	}
+}
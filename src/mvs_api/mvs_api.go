@@ -1,9 +1,10 @@
 package mvs_api
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
+	"log"
 	"net/http"
 	"strconv"
 	"strings"
@@ -17,7 +18,21 @@ type RPCClient struct {
 	sick        bool
 	sickRate    int
 	successRate int
-	client      *http.Client
+	sickSince   time.Time
+	requests    uint64
+	failures    uint64
+	lastError   error
+	lastFailure time.Time
+	health      HealthPolicy
+	retry       RetryPolicy
+	transport   Transport
+	trial       bool
+	probeTimer  *time.Timer
+	lastId      uint64
+	logger      *log.Logger
+	pool        *RPCPool
+	batch       *batchSink
+	decimals    map[string]uint32
 }
 
 func MustParseDuration(s string) time.Duration {
@@ -28,60 +43,124 @@ func MustParseDuration(s string) time.Duration {
 	return value
 }
 
+// NewRPCClient is kept for existing callers and panics on a bad timeout;
+// New takes options and reports errors instead.
 func NewRPCClient(url, timeout string) *RPCClient {
-	rpcClient := &RPCClient{Url: url}
+	rpcClient := &RPCClient{Url: url, health: DefaultHealthPolicy}
 	timeoutIntv := MustParseDuration(timeout)
-	rpcClient.client = &http.Client{
+	rpcClient.transport = &HTTPTransport{Client: &http.Client{
 		Timeout: timeoutIntv,
-	}
+	}}
 	return rpcClient
 }
 
 type JSONRpcResp struct {
-	Id     *json.RawMessage       `json:"id"`
-	Result *json.RawMessage       `json:"result"`
-	Error  map[string]interface{} `json:"error"`
+	Id     *json.RawMessage `json:"id"`
+	Result *json.RawMessage `json:"result"`
+	Error  *RPCError        `json:"error"`
+}
+
+func (r *RPCClient) doPost(ctx context.Context, url string, method string, params interface{}) (*JSONRpcResp, error) {
+	if err := checkReceivers(method, params); err != nil {
+		return nil, err
+	}
+	if r.batch != nil {
+		return r.batch.doPost(ctx, method, params)
+	}
+	if readOnlyMethods[method] {
+		return r.doPostRetry(ctx, url, method, params)
+	}
+	return r.attempt(ctx, url, method, params)
 }
 
-func (r *RPCClient) doPost(url string, method string, params interface{}) (*JSONRpcResp, error) {
-	jsonReq := map[string]interface{}{"jsonrpc": "2.0", "method": method, "params": params, "id": 0}
-	data, _ := json.Marshal(jsonReq)
+func (r *RPCClient) attempt(ctx context.Context, url string, method string, params interface{}) (*JSONRpcResp, error) {
+	if r.pool != nil {
+		return r.pool.doPost(ctx, method, params)
+	}
+	trial, err := r.admit()
+	if err != nil {
+		return nil, err
+	}
+	defer r.done(trial)
+	id := r.nextId(ctx)
+	start := time.Now()
+	rpcResp, err := r.exchange(ctx, url, id, method, params)
+	r.logCall(id, method, start, err)
+	return rpcResp, err
+}
 
-	req, err := http.NewRequest("POST", url, bytes.NewBuffer(data))
-	req.Header.Set("Content-Length", (string)(len(data)))
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Accept", "application/json")
+func (r *RPCClient) exchange(ctx context.Context, url string, id uint64, method string, params interface{}) (*JSONRpcResp, error) {
+	jsonReq := map[string]interface{}{"jsonrpc": "2.0", "method": method, "params": params, "id": id}
+	data, err := json.Marshal(jsonReq)
+	if err != nil {
+		return nil, err
+	}
 
-	resp, err := r.client.Do(req)
+	status, body, err := r.post(ctx, url, data)
 	if err != nil {
-		r.markSick()
+		// A cancelled or expired context is the caller's doing, not the node's.
+		if ctx.Err() != nil {
+			return nil, err
+		}
+		err = &TransportError{Method: method, Id: id, Err: err}
+		r.markSick(err)
 		return nil, err
 	}
-	defer resp.Body.Close()
 
 	var rpcResp *JSONRpcResp
-	err = json.NewDecoder(resp.Body).Decode(&rpcResp)
+	err = json.Unmarshal(body, &rpcResp)
+	if err == nil && rpcResp == nil {
+		err = errors.New("empty response")
+	}
+	if err == nil && status/100 != 2 && rpcResp.Error == nil {
+		err = errors.New(http.StatusText(status))
+	}
+	if err == nil {
+		err = checkId(rpcResp, id)
+	}
 	if err != nil {
-		r.markSick()
+		err = &TransportError{Method: method, Id: id, StatusCode: status, Err: err}
+		r.markSick(err)
 		return nil, err
 	}
+	// The node answered, even if only to reject the call.
+	r.markSuccess()
 	if rpcResp.Error != nil {
-		r.markSick()
-		return nil, errors.New(rpcResp.Error["message"].(string))
+		rpcResp.Error.Method = method
+		rpcResp.Error.Id = id
+		return nil, rpcResp.Error
 	}
-	return rpcResp, err
+	return rpcResp, nil
+}
+
+func (r *RPCClient) post(ctx context.Context, url string, data []byte) (int, []byte, error) {
+	return r.Transport().Post(ctx, url, data)
+}
+
+// Call sends an arbitrary command and returns the undecoded response. It is
+// the escape hatch for commands and fields the typed methods do not cover.
+func (r *RPCClient) Call(method string, params []interface{}) (*JSONRpcResp, error) {
+	return r.CallContext(context.Background(), method, params)
 }
 
-func (r *RPCClient) markSick() {
-	r.Lock()
-	r.sickRate++
-	r.successRate = 0
-	if r.sickRate >= 5 {
-		r.sick = true
+// CallContext is like Call but honours the deadline and cancellation of ctx.
+func (r *RPCClient) CallContext(ctx context.Context, method string, params []interface{}) (*JSONRpcResp, error) {
+	return r.doPost(ctx, r.Url, method, params)
+}
+
+func (r *RPCClient) call(ctx context.Context, method string, params interface{}, key string, result interface{}) error {
+	rpcResp, err := r.doPost(ctx, r.Url, method, params)
+	if err != nil {
+		return err
 	}
-	r.Unlock()
+	storeRawResult(ctx, rpcResp.Result)
+	return decodeResult(rpcResp.Result, key, result)
 }
 
+//go:generate go run ./cmd/mvsgen -spec commands.json -out mvs_api.go
+
+// The methods below are generated from commands.json; edit the spec and run
+// go generate instead of changing them by hand.
 // auto-generate code begin
 
 /*
@@ -91,7 +170,12 @@ func (r *RPCClient) markSick() {
    :param: DIDSYMBOL(std::string): "Did symbol"
    :param: fee(uint64_t): "Transaction fee. defaults to 10000 ETP bits"
 */
-func (r *RPCClient) Didchangeaddress(ACCOUNTNAME string, ACCOUNTAUTH string, TOADDRESS string, DIDSYMBOL string, fee uint64) (*JSONRpcResp, error) {
+func (r *RPCClient) Didchangeaddress(ACCOUNTNAME string, ACCOUNTAUTH string, TOADDRESS string, DIDSYMBOL string, fee Amount) (*Transaction, error) {
+	return r.DidchangeaddressContext(context.Background(), ACCOUNTNAME, ACCOUNTAUTH, TOADDRESS, DIDSYMBOL, fee)
+}
+
+// DidchangeaddressContext is like Didchangeaddress but honours the deadline and cancellation of ctx.
+func (r *RPCClient) DidchangeaddressContext(ctx context.Context, ACCOUNTNAME string, ACCOUNTAUTH string, TOADDRESS string, DIDSYMBOL string, fee Amount) (*Transaction, error) {
 	cmd := "didchangeaddress"
 	positional := []interface{}{ACCOUNTNAME, ACCOUNTAUTH, TOADDRESS, DIDSYMBOL}
 
@@ -101,7 +185,24 @@ func (r *RPCClient) Didchangeaddress(ACCOUNTNAME string, ACCOUNTAUTH string, TOA
 		optional["fee"] = fee
 	}
 	args := append(positional, optional)
-	return r.doPost(r.Url, cmd, args)
+	var result *Transaction
+	err := r.call(ctx, cmd, args, "transaction", &result)
+	return result, err
+}
+
+// DidchangeaddressOptions holds the parameters of Didchangeaddress that may be left out.
+type DidchangeaddressOptions struct {
+	// Transaction fee. defaults to 10000 ETP bits
+	Fee Amount
+}
+
+// DidchangeaddressWithOptions is DidchangeaddressContext with the parameters that may be left out
+// in opts, which may be nil.
+func (r *RPCClient) DidchangeaddressWithOptions(ctx context.Context, ACCOUNTNAME string, ACCOUNTAUTH string, TOADDRESS string, DIDSYMBOL string, opts *DidchangeaddressOptions) (*Transaction, error) {
+	if opts == nil {
+		opts = &DidchangeaddressOptions{}
+	}
+	return r.DidchangeaddressContext(ctx, ACCOUNTNAME, ACCOUNTAUTH, TOADDRESS, DIDSYMBOL, opts.Fee)
 }
 
 /*
@@ -111,7 +212,12 @@ func (r *RPCClient) Didchangeaddress(ACCOUNTNAME string, ACCOUNTAUTH string, TOA
    :param: selfpublickey(std::string): "The private key of this public key will be used to sign."
    :param: broadcast(bool): "Broadcast the tx if it is fullly signed, disabled by default."
 */
-func (r *RPCClient) Signmultisigtx(ACCOUNTNAME string, ACCOUNTAUTH string, TRANSACTION string, selfpublickey string, broadcast bool) (*JSONRpcResp, error) {
+func (r *RPCClient) Signmultisigtx(ACCOUNTNAME string, ACCOUNTAUTH string, TRANSACTION string, selfpublickey string, broadcast bool) (string, error) {
+	return r.SignmultisigtxContext(context.Background(), ACCOUNTNAME, ACCOUNTAUTH, TRANSACTION, selfpublickey, broadcast)
+}
+
+// SignmultisigtxContext is like Signmultisigtx but honours the deadline and cancellation of ctx.
+func (r *RPCClient) SignmultisigtxContext(ctx context.Context, ACCOUNTNAME string, ACCOUNTAUTH string, TRANSACTION string, selfpublickey string, broadcast bool) (string, error) {
 	cmd := "signmultisigtx"
 	positional := []interface{}{ACCOUNTNAME, ACCOUNTAUTH, TRANSACTION}
 
@@ -123,7 +229,26 @@ func (r *RPCClient) Signmultisigtx(ACCOUNTNAME string, ACCOUNTAUTH string, TRANS
 		optional["selfpublickey"] = selfpublickey
 	}
 	args := append(positional, optional)
-	return r.doPost(r.Url, cmd, args)
+	var result string
+	err := r.call(ctx, cmd, args, "", &result)
+	return result, err
+}
+
+// SignmultisigtxOptions holds the parameters of Signmultisigtx that may be left out.
+type SignmultisigtxOptions struct {
+	// The private key of this public key will be used to sign.
+	Selfpublickey string
+	// Broadcast the tx if it is fullly signed, disabled by default.
+	Broadcast bool
+}
+
+// SignmultisigtxWithOptions is SignmultisigtxContext with the parameters that may be left out
+// in opts, which may be nil.
+func (r *RPCClient) SignmultisigtxWithOptions(ctx context.Context, ACCOUNTNAME string, ACCOUNTAUTH string, TRANSACTION string, opts *SignmultisigtxOptions) (string, error) {
+	if opts == nil {
+		opts = &SignmultisigtxOptions{}
+	}
+	return r.SignmultisigtxContext(ctx, ACCOUNTNAME, ACCOUNTAUTH, TRANSACTION, opts.Selfpublickey, opts.Broadcast)
 }
 
 /*
@@ -133,7 +258,12 @@ func (r *RPCClient) Signmultisigtx(ACCOUNTNAME string, ACCOUNTAUTH string, TRANS
    :param: SYMBOL(std::string): "The symbol of global unique MVS Digital Identity Destination/Index, supports alphabets/numbers/(“@”, “.”, “_”, “-“), case-sensitive, maximum length is 64."
    :param: fee(uint64_t): "The fee of tx. defaults to 1 etp."
 */
-func (r *RPCClient) Registerdid(ACCOUNTNAME string, ACCOUNTAUTH string, ADDRESS string, SYMBOL string, fee uint64) (*JSONRpcResp, error) {
+func (r *RPCClient) Registerdid(ACCOUNTNAME string, ACCOUNTAUTH string, ADDRESS string, SYMBOL string, fee Amount) (*Transaction, error) {
+	return r.RegisterdidContext(context.Background(), ACCOUNTNAME, ACCOUNTAUTH, ADDRESS, SYMBOL, fee)
+}
+
+// RegisterdidContext is like Registerdid but honours the deadline and cancellation of ctx.
+func (r *RPCClient) RegisterdidContext(ctx context.Context, ACCOUNTNAME string, ACCOUNTAUTH string, ADDRESS string, SYMBOL string, fee Amount) (*Transaction, error) {
 	cmd := "registerdid"
 	positional := []interface{}{ACCOUNTNAME, ACCOUNTAUTH, ADDRESS, SYMBOL}
 
@@ -143,7 +273,24 @@ func (r *RPCClient) Registerdid(ACCOUNTNAME string, ACCOUNTAUTH string, ADDRESS
 		optional["fee"] = fee
 	}
 	args := append(positional, optional)
-	return r.doPost(r.Url, cmd, args)
+	var result *Transaction
+	err := r.call(ctx, cmd, args, "transaction", &result)
+	return result, err
+}
+
+// RegisterdidOptions holds the parameters of Registerdid that may be left out.
+type RegisterdidOptions struct {
+	// The fee of tx. defaults to 1 etp.
+	Fee Amount
+}
+
+// RegisterdidWithOptions is RegisterdidContext with the parameters that may be left out
+// in opts, which may be nil.
+func (r *RPCClient) RegisterdidWithOptions(ctx context.Context, ACCOUNTNAME string, ACCOUNTAUTH string, ADDRESS string, SYMBOL string, opts *RegisterdidOptions) (*Transaction, error) {
+	if opts == nil {
+		opts = &RegisterdidOptions{}
+	}
+	return r.RegisterdidContext(ctx, ACCOUNTNAME, ACCOUNTAUTH, ADDRESS, SYMBOL, opts.Fee)
 }
 
 /*
@@ -158,10 +305,15 @@ func (r *RPCClient) Registerdid(ACCOUNTNAME string, ACCOUNTAUTH string, ADDRESS
    eg:
        TYPE=1;LQ=9000;LP=60000;UN=3
        TYPE=2;LQ=9000;LP=60000;UN=3;UC=20000,20000,20000;UQ=3000,3000,3000
-   defaults to disable.
+   defaults to disable. AttenuationModel builds and checks it.
    :param: fee(uint64_t): "The fee of tx. minimum is 10 etp."
 */
-func (r *RPCClient) Issue(ACCOUNTNAME string, ACCOUNTAUTH string, SYMBOL string, model string, fee uint64) (*JSONRpcResp, error) {
+func (r *RPCClient) Issue(ACCOUNTNAME string, ACCOUNTAUTH string, SYMBOL string, model string, fee Amount) (*Transaction, error) {
+	return r.IssueContext(context.Background(), ACCOUNTNAME, ACCOUNTAUTH, SYMBOL, model, fee)
+}
+
+// IssueContext is like Issue but honours the deadline and cancellation of ctx.
+func (r *RPCClient) IssueContext(ctx context.Context, ACCOUNTNAME string, ACCOUNTAUTH string, SYMBOL string, model string, fee Amount) (*Transaction, error) {
 	cmd := "issue"
 	positional := []interface{}{ACCOUNTNAME, ACCOUNTAUTH, SYMBOL}
 
@@ -174,7 +326,26 @@ func (r *RPCClient) Issue(ACCOUNTNAME string, ACCOUNTAUTH string, SYMBOL string,
 		optional["fee"] = fee
 	}
 	args := append(positional, optional)
-	return r.doPost(r.Url, cmd, args)
+	var result *Transaction
+	err := r.call(ctx, cmd, args, "transaction", &result)
+	return result, err
+}
+
+// IssueOptions holds the parameters of Issue that may be left out.
+type IssueOptions struct {
+	// The token offering model by block height.
+	Model string
+	// The fee of tx. minimum is 10 etp.
+	Fee Amount
+}
+
+// IssueWithOptions is IssueContext with the parameters that may be left out
+// in opts, which may be nil.
+func (r *RPCClient) IssueWithOptions(ctx context.Context, ACCOUNTNAME string, ACCOUNTAUTH string, SYMBOL string, opts *IssueOptions) (*Transaction, error) {
+	if opts == nil {
+		opts = &IssueOptions{}
+	}
+	return r.IssueContext(ctx, ACCOUNTNAME, ACCOUNTAUTH, SYMBOL, opts.Model, opts.Fee)
 }
 
 /*
@@ -184,7 +355,12 @@ func (r *RPCClient) Issue(ACCOUNTNAME string, ACCOUNTAUTH string, SYMBOL string,
    :param: password(std::string): Account password(authorization) required.
    :param: hd_index(std::uint32_t): "The HD index for the account."
 */
-func (r *RPCClient) Importaccount(WORD []string, language string, accountname string, password string, hd_index uint32) (*JSONRpcResp, error) {
+func (r *RPCClient) Importaccount(WORD []string, language string, accountname string, password string, hd_index uint32) (*Account, error) {
+	return r.ImportaccountContext(context.Background(), WORD, language, accountname, password, hd_index)
+}
+
+// ImportaccountContext is like Importaccount but honours the deadline and cancellation of ctx.
+func (r *RPCClient) ImportaccountContext(ctx context.Context, WORD []string, language string, accountname string, password string, hd_index uint32) (*Account, error) {
 	cmd := "importaccount"
 	positional := []interface{}{strings.Join(WORD, " ")}
 
@@ -200,21 +376,47 @@ func (r *RPCClient) Importaccount(WORD []string, language string, accountname st
 		optional["hd_index"] = hd_index
 	}
 	args := append(positional, optional)
-	return r.doPost(r.Url, cmd, args)
+	var result *Account
+	err := r.call(ctx, cmd, args, "", &result)
+	return result, err
+}
+
+// ImportaccountOptions holds the parameters of Importaccount that may be left out.
+type ImportaccountOptions struct {
+	// The language identifier of the dictionary of the mnemonic. Options are 'en', 'es', 'ja', 'zh_Hans', 'zh_Hant' and 'any', defaults to 'any'.
+	Language string
+	// The HD index for the account.
+	HdIndex uint32
+}
+
+// ImportaccountWithOptions is ImportaccountContext with the parameters that may be left out
+// in opts, which may be nil.
+func (r *RPCClient) ImportaccountWithOptions(ctx context.Context, WORD []string, accountname string, password string, opts *ImportaccountOptions) (*Account, error) {
+	if opts == nil {
+		opts = &ImportaccountOptions{}
+	}
+	return r.ImportaccountContext(ctx, WORD, opts.Language, accountname, password, opts.HdIndex)
 }
 
 /*
    :param: ADMINNAME(std::string): Administrator required.(when administrator_required in mvs.conf is set true)
    :param: ADMINAUTH(std::string): Administrator password required.
 */
-func (r *RPCClient) Stopmining(ADMINNAME string, ADMINAUTH string) (*JSONRpcResp, error) {
+func (r *RPCClient) Stopmining(ADMINNAME string, ADMINAUTH string) (string, error) {
+	return r.StopminingContext(context.Background(), ADMINNAME, ADMINAUTH)
+}
+
+// StopminingContext is like Stopmining but honours the deadline and cancellation of ctx.
+func (r *RPCClient) StopminingContext(ctx context.Context, ADMINNAME string, ADMINAUTH string) (string, error) {
 	cmd := "stopmining"
 	positional := []interface{}{ADMINNAME, ADMINAUTH}
 
 	optional := map[string]interface{}{}
 
 	args := append(positional, optional)
-	return r.doPost(r.Url, cmd, args)
+	var result string
+	err := r.call(ctx, cmd, args, "", &result)
+	return result, err
 }
 
 /*
@@ -227,7 +429,12 @@ func (r *RPCClient) Stopmining(ADMINNAME string, ADMINAUTH string) (*JSONRpcResp
    :param: type(uint16_t): "Transaction type, defaults to 0. 0 -- transfer etp, 3 -- transfer asset"
    :param: fee(uint64_t): "Transaction fee. defaults to 10000 ETP bits"
 */
-func (r *RPCClient) Createmultisigtx(ACCOUNTNAME string, ACCOUNTAUTH string, FROMADDRESS string, TOADDRESS string, AMOUNT uint64, symbol string, type_ uint16, fee uint64) (*JSONRpcResp, error) {
+func (r *RPCClient) Createmultisigtx(ACCOUNTNAME string, ACCOUNTAUTH string, FROMADDRESS string, TOADDRESS string, AMOUNT Amount, symbol string, type_ uint16, fee Amount) (string, error) {
+	return r.CreatemultisigtxContext(context.Background(), ACCOUNTNAME, ACCOUNTAUTH, FROMADDRESS, TOADDRESS, AMOUNT, symbol, type_, fee)
+}
+
+// CreatemultisigtxContext is like Createmultisigtx but honours the deadline and cancellation of ctx.
+func (r *RPCClient) CreatemultisigtxContext(ctx context.Context, ACCOUNTNAME string, ACCOUNTAUTH string, FROMADDRESS string, TOADDRESS string, AMOUNT Amount, symbol string, type_ uint16, fee Amount) (string, error) {
 	cmd := "createmultisigtx"
 	positional := []interface{}{ACCOUNTNAME, ACCOUNTAUTH, FROMADDRESS, TOADDRESS, AMOUNT}
 
@@ -243,7 +450,28 @@ func (r *RPCClient) Createmultisigtx(ACCOUNTNAME string, ACCOUNTAUTH string, FRO
 		optional["fee"] = fee
 	}
 	args := append(positional, optional)
-	return r.doPost(r.Url, cmd, args)
+	var result string
+	err := r.call(ctx, cmd, args, "", &result)
+	return result, err
+}
+
+// CreatemultisigtxOptions holds the parameters of Createmultisigtx that may be left out.
+type CreatemultisigtxOptions struct {
+	// asset name, not specify this option for etp tx
+	Symbol string
+	// Transaction type, defaults to 0. 0 -- transfer etp, 3 -- transfer asset
+	Type uint16
+	// Transaction fee. defaults to 10000 ETP bits
+	Fee Amount
+}
+
+// CreatemultisigtxWithOptions is CreatemultisigtxContext with the parameters that may be left out
+// in opts, which may be nil.
+func (r *RPCClient) CreatemultisigtxWithOptions(ctx context.Context, ACCOUNTNAME string, ACCOUNTAUTH string, FROMADDRESS string, TOADDRESS string, AMOUNT Amount, opts *CreatemultisigtxOptions) (string, error) {
+	if opts == nil {
+		opts = &CreatemultisigtxOptions{}
+	}
+	return r.CreatemultisigtxContext(ctx, ACCOUNTNAME, ACCOUNTAUTH, FROMADDRESS, TOADDRESS, AMOUNT, opts.Symbol, opts.Type, opts.Fee)
 }
 
 /*
@@ -251,14 +479,21 @@ func (r *RPCClient) Createmultisigtx(ACCOUNTNAME string, ACCOUNTAUTH string, FRO
    :param: ACCOUNTAUTH(std::string): Account password(authorization) required.
    :param: ADDRESS(std::string): "Address."
 */
-func (r *RPCClient) Getpublickey(ACCOUNTNAME string, ACCOUNTAUTH string, ADDRESS string) (*JSONRpcResp, error) {
+func (r *RPCClient) Getpublickey(ACCOUNTNAME string, ACCOUNTAUTH string, ADDRESS string) (*PublicKey, error) {
+	return r.GetpublickeyContext(context.Background(), ACCOUNTNAME, ACCOUNTAUTH, ADDRESS)
+}
+
+// GetpublickeyContext is like Getpublickey but honours the deadline and cancellation of ctx.
+func (r *RPCClient) GetpublickeyContext(ctx context.Context, ACCOUNTNAME string, ACCOUNTAUTH string, ADDRESS string) (*PublicKey, error) {
 	cmd := "getpublickey"
 	positional := []interface{}{ACCOUNTNAME, ACCOUNTAUTH, ADDRESS}
 
 	optional := map[string]interface{}{}
 
 	args := append(positional, optional)
-	return r.doPost(r.Url, cmd, args)
+	var result *PublicKey
+	err := r.call(ctx, cmd, args, "", &result)
+	return result, err
 }
 
 /*
@@ -269,7 +504,12 @@ func (r *RPCClient) Getpublickey(ACCOUNTNAME string, ACCOUNTAUTH string, ADDRESS
    :param: deposit(uint16_t): "Deposits support [7, 30, 90, 182, 365] days. defaluts to 7 days"
    :param: fee(uint64_t): "Transaction fee. defaults to 10000 ETP bits"
 */
-func (r *RPCClient) Deposit(ACCOUNTNAME string, ACCOUNTAUTH string, AMOUNT uint64, address string, deposit uint16, fee uint64) (*JSONRpcResp, error) {
+func (r *RPCClient) Deposit(ACCOUNTNAME string, ACCOUNTAUTH string, AMOUNT Amount, address string, deposit uint16, fee Amount) (*Transaction, error) {
+	return r.DepositContext(context.Background(), ACCOUNTNAME, ACCOUNTAUTH, AMOUNT, address, deposit, fee)
+}
+
+// DepositContext is like Deposit but honours the deadline and cancellation of ctx.
+func (r *RPCClient) DepositContext(ctx context.Context, ACCOUNTNAME string, ACCOUNTAUTH string, AMOUNT Amount, address string, deposit uint16, fee Amount) (*Transaction, error) {
 	cmd := "deposit"
 	positional := []interface{}{ACCOUNTNAME, ACCOUNTAUTH, AMOUNT}
 
@@ -285,7 +525,28 @@ func (r *RPCClient) Deposit(ACCOUNTNAME string, ACCOUNTAUTH string, AMOUNT uint6
 		optional["fee"] = fee
 	}
 	args := append(positional, optional)
-	return r.doPost(r.Url, cmd, args)
+	var result *Transaction
+	err := r.call(ctx, cmd, args, "transaction", &result)
+	return result, err
+}
+
+// DepositOptions holds the parameters of Deposit that may be left out.
+type DepositOptions struct {
+	// The deposit target address.
+	Address string
+	// Deposits support [7, 30, 90, 182, 365] days. defaluts to 7 days
+	Deposit uint16
+	// Transaction fee. defaults to 10000 ETP bits
+	Fee Amount
+}
+
+// DepositWithOptions is DepositContext with the parameters that may be left out
+// in opts, which may be nil.
+func (r *RPCClient) DepositWithOptions(ctx context.Context, ACCOUNTNAME string, ACCOUNTAUTH string, AMOUNT Amount, opts *DepositOptions) (*Transaction, error) {
+	if opts == nil {
+		opts = &DepositOptions{}
+	}
+	return r.DepositContext(ctx, ACCOUNTNAME, ACCOUNTAUTH, AMOUNT, opts.Address, opts.Deposit, opts.Fee)
 }
 
 /*
@@ -294,7 +555,12 @@ func (r *RPCClient) Deposit(ACCOUNTNAME string, ACCOUNTAUTH string, AMOUNT uint6
    :param: SYMBOL(std::string): "Asset symbol."
    :param: cert(bool): "If specified, then only get related asset cert. Default is not specified."
 */
-func (r *RPCClient) Getaccountasset(ACCOUNTNAME string, ACCOUNTAUTH string, SYMBOL string, cert bool) (*JSONRpcResp, error) {
+func (r *RPCClient) Getaccountasset(ACCOUNTNAME string, ACCOUNTAUTH string, SYMBOL string, cert bool) ([]AssetBalance, error) {
+	return r.GetaccountassetContext(context.Background(), ACCOUNTNAME, ACCOUNTAUTH, SYMBOL, cert)
+}
+
+// GetaccountassetContext is like Getaccountasset but honours the deadline and cancellation of ctx.
+func (r *RPCClient) GetaccountassetContext(ctx context.Context, ACCOUNTNAME string, ACCOUNTAUTH string, SYMBOL string, cert bool) ([]AssetBalance, error) {
 	cmd := "getaccountasset"
 	positional := []interface{}{ACCOUNTNAME, ACCOUNTAUTH, SYMBOL}
 
@@ -304,7 +570,24 @@ func (r *RPCClient) Getaccountasset(ACCOUNTNAME string, ACCOUNTAUTH string, SYMB
 	}
 
 	args := append(positional, optional)
-	return r.doPost(r.Url, cmd, args)
+	var result []AssetBalance
+	err := r.call(ctx, cmd, args, "assets", &result)
+	return result, err
+}
+
+// GetaccountassetOptions holds the parameters of Getaccountasset that may be left out.
+type GetaccountassetOptions struct {
+	// If specified, then only get related asset cert. Default is not specified.
+	Cert bool
+}
+
+// GetaccountassetWithOptions is GetaccountassetContext with the parameters that may be left out
+// in opts, which may be nil.
+func (r *RPCClient) GetaccountassetWithOptions(ctx context.Context, ACCOUNTNAME string, ACCOUNTAUTH string, SYMBOL string, opts *GetaccountassetOptions) ([]AssetBalance, error) {
+	if opts == nil {
+		opts = &GetaccountassetOptions{}
+	}
+	return r.GetaccountassetContext(ctx, ACCOUNTNAME, ACCOUNTAUTH, SYMBOL, opts.Cert)
 }
 
 /*
@@ -321,10 +604,15 @@ func (r *RPCClient) Getaccountasset(ACCOUNTNAME string, ACCOUNTAUTH string, SYMB
    eg:
        TYPE=1;LQ=9000;LP=60000;UN=3
        TYPE=2;LQ=9000;LP=60000;UN=3;UC=20000,20000,20000;UQ=3000,3000,3000
-   defaults to disable.
+   defaults to disable. AttenuationModel builds and checks it.
    :param: fee(uint64_t): "Transaction fee. defaults to 10000 ETP bits"
 */
-func (r *RPCClient) Didsendasset(ACCOUNTNAME string, ACCOUNTAUTH string, TO_ string, ASSET string, AMOUNT uint64, model string, fee uint64) (*JSONRpcResp, error) {
+func (r *RPCClient) Didsendasset(ACCOUNTNAME string, ACCOUNTAUTH string, TO_ string, ASSET string, AMOUNT Amount, model string, fee Amount) (*Transaction, error) {
+	return r.DidsendassetContext(context.Background(), ACCOUNTNAME, ACCOUNTAUTH, TO_, ASSET, AMOUNT, model, fee)
+}
+
+// DidsendassetContext is like Didsendasset but honours the deadline and cancellation of ctx.
+func (r *RPCClient) DidsendassetContext(ctx context.Context, ACCOUNTNAME string, ACCOUNTAUTH string, TO_ string, ASSET string, AMOUNT Amount, model string, fee Amount) (*Transaction, error) {
 	cmd := "didsendasset"
 	positional := []interface{}{ACCOUNTNAME, ACCOUNTAUTH, TO_, ASSET, AMOUNT}
 
@@ -337,7 +625,26 @@ func (r *RPCClient) Didsendasset(ACCOUNTNAME string, ACCOUNTAUTH string, TO_ str
 		optional["fee"] = fee
 	}
 	args := append(positional, optional)
-	return r.doPost(r.Url, cmd, args)
+	var result *Transaction
+	err := r.call(ctx, cmd, args, "transaction", &result)
+	return result, err
+}
+
+// DidsendassetOptions holds the parameters of Didsendasset that may be left out.
+type DidsendassetOptions struct {
+	// The token offering model by block height.
+	Model string
+	// Transaction fee. defaults to 10000 ETP bits
+	Fee Amount
+}
+
+// DidsendassetWithOptions is DidsendassetContext with the parameters that may be left out
+// in opts, which may be nil.
+func (r *RPCClient) DidsendassetWithOptions(ctx context.Context, ACCOUNTNAME string, ACCOUNTAUTH string, TO_ string, ASSET string, AMOUNT Amount, opts *DidsendassetOptions) (*Transaction, error) {
+	if opts == nil {
+		opts = &DidsendassetOptions{}
+	}
+	return r.DidsendassetContext(ctx, ACCOUNTNAME, ACCOUNTAUTH, TO_, ASSET, AMOUNT, opts.Model, opts.Fee)
 }
 
 /*
@@ -346,27 +653,41 @@ func (r *RPCClient) Didsendasset(ACCOUNTNAME string, ACCOUNTAUTH string, TO_ str
    :param: SYMBOL(std::string): "The asset will be burned."
    :param: AMOUNT(uint64_t): "Asset integer bits. see asset <decimal_number>."
 */
-func (r *RPCClient) Burn(ACCOUNTNAME string, ACCOUNTAUTH string, SYMBOL string, AMOUNT uint64) (*JSONRpcResp, error) {
+func (r *RPCClient) Burn(ACCOUNTNAME string, ACCOUNTAUTH string, SYMBOL string, AMOUNT Amount) (*Transaction, error) {
+	return r.BurnContext(context.Background(), ACCOUNTNAME, ACCOUNTAUTH, SYMBOL, AMOUNT)
+}
+
+// BurnContext is like Burn but honours the deadline and cancellation of ctx.
+func (r *RPCClient) BurnContext(ctx context.Context, ACCOUNTNAME string, ACCOUNTAUTH string, SYMBOL string, AMOUNT Amount) (*Transaction, error) {
 	cmd := "burn"
 	positional := []interface{}{ACCOUNTNAME, ACCOUNTAUTH, SYMBOL, AMOUNT}
 
 	optional := map[string]interface{}{}
 
 	args := append(positional, optional)
-	return r.doPost(r.Url, cmd, args)
+	var result *Transaction
+	err := r.call(ctx, cmd, args, "transaction", &result)
+	return result, err
 }
 
 /*
    :param: height(uint32_t): "specify the starting point to pop out blocks. eg, if specified 1000000, then all blocks with height greater than or equal to 1000000 will be poped out."
 */
-func (r *RPCClient) Popblock(height uint32) (*JSONRpcResp, error) {
+func (r *RPCClient) Popblock(height uint32) (string, error) {
+	return r.PopblockContext(context.Background(), height)
+}
+
+// PopblockContext is like Popblock but honours the deadline and cancellation of ctx.
+func (r *RPCClient) PopblockContext(ctx context.Context, height uint32) (string, error) {
 	cmd := "popblock"
 	positional := []interface{}{height}
 
 	optional := map[string]interface{}{}
 
 	args := append(positional, optional)
-	return r.doPost(r.Url, cmd, args)
+	var result string
+	err := r.call(ctx, cmd, args, "", &result)
+	return result, err
 }
 
 /*
@@ -376,7 +697,12 @@ func (r *RPCClient) Popblock(height uint32) (*JSONRpcResp, error) {
    :param: ACCOUNTNAME(std::string): Account name required.
    :param: ACCOUNTAUTH(std::string): Account password(authorization) required.
 */
-func (r *RPCClient) Listbalances(nozero bool, greater_equal uint64, lesser_equal uint64, ACCOUNTNAME string, ACCOUNTAUTH string) (*JSONRpcResp, error) {
+func (r *RPCClient) Listbalances(nozero bool, greater_equal Amount, lesser_equal Amount, ACCOUNTNAME string, ACCOUNTAUTH string) ([]AddressBalance, error) {
+	return r.ListbalancesContext(context.Background(), nozero, greater_equal, lesser_equal, ACCOUNTNAME, ACCOUNTAUTH)
+}
+
+// ListbalancesContext is like Listbalances but honours the deadline and cancellation of ctx.
+func (r *RPCClient) ListbalancesContext(ctx context.Context, nozero bool, greater_equal Amount, lesser_equal Amount, ACCOUNTNAME string, ACCOUNTAUTH string) ([]AddressBalance, error) {
 	cmd := "listbalances"
 	positional := []interface{}{ACCOUNTNAME, ACCOUNTAUTH}
 
@@ -391,7 +717,28 @@ func (r *RPCClient) Listbalances(nozero bool, greater_equal uint64, lesser_equal
 		optional["lesser_equal"] = lesser_equal
 	}
 	args := append(positional, optional)
-	return r.doPost(r.Url, cmd, args)
+	var result []AddressBalance
+	err := r.call(ctx, cmd, args, "balances", &result)
+	return result, err
+}
+
+// ListbalancesOptions holds the parameters of Listbalances that may be left out.
+type ListbalancesOptions struct {
+	// Defaults to false.
+	Nozero bool
+	// Greater than ETP bits.
+	GreaterEqual Amount
+	// Lesser than ETP bits.
+	LesserEqual Amount
+}
+
+// ListbalancesWithOptions is ListbalancesContext with the parameters that may be left out
+// in opts, which may be nil.
+func (r *RPCClient) ListbalancesWithOptions(ctx context.Context, ACCOUNTNAME string, ACCOUNTAUTH string, opts *ListbalancesOptions) ([]AddressBalance, error) {
+	if opts == nil {
+		opts = &ListbalancesOptions{}
+	}
+	return r.ListbalancesContext(ctx, opts.Nozero, opts.GreaterEqual, opts.LesserEqual, ACCOUNTNAME, ACCOUNTAUTH)
 }
 
 /*
@@ -404,7 +751,12 @@ func (r *RPCClient) Listbalances(nozero bool, greater_equal uint64, lesser_equal
    :param: decimalnumber(uint32_t): "The asset amount decimal number, defaults to 0."
    :param: description(std::string): "The asset data chuck, defaults to empty string."
 */
-func (r *RPCClient) Createasset(ACCOUNTNAME string, ACCOUNTAUTH string, rate int32, symbol string, issuer string, volume uint64, decimalnumber uint32, description string) (*JSONRpcResp, error) {
+func (r *RPCClient) Createasset(ACCOUNTNAME string, ACCOUNTAUTH string, rate int32, symbol string, issuer string, volume Amount, decimalnumber uint32, description string) (*Asset, error) {
+	return r.CreateassetContext(context.Background(), ACCOUNTNAME, ACCOUNTAUTH, rate, symbol, issuer, volume, decimalnumber, description)
+}
+
+// CreateassetContext is like Createasset but honours the deadline and cancellation of ctx.
+func (r *RPCClient) CreateassetContext(ctx context.Context, ACCOUNTNAME string, ACCOUNTAUTH string, rate int32, symbol string, issuer string, volume Amount, decimalnumber uint32, description string) (*Asset, error) {
 	cmd := "createasset"
 	positional := []interface{}{ACCOUNTNAME, ACCOUNTAUTH}
 
@@ -424,7 +776,28 @@ func (r *RPCClient) Createasset(ACCOUNTNAME string, ACCOUNTAUTH string, rate int
 		optional["description"] = description
 	}
 	args := append(positional, optional)
-	return r.doPost(r.Url, cmd, args)
+	var result *Asset
+	err := r.call(ctx, cmd, args, "asset", &result)
+	return result, err
+}
+
+// CreateassetOptions holds the parameters of Createasset that may be left out.
+type CreateassetOptions struct {
+	// The percent threshold value when you secondary issue.              0,  not allowed to secondary issue;              -1,  the asset can be secondary issue freely;             [1, 100], the asset can be secondary issue when own percentage greater than or equal to this value.             Defaults to 0.
+	Rate int32
+	// The asset amount decimal number, defaults to 0.
+	Decimalnumber uint32
+	// The asset data chuck, defaults to empty string.
+	Description string
+}
+
+// CreateassetWithOptions is CreateassetContext with the parameters that may be left out
+// in opts, which may be nil.
+func (r *RPCClient) CreateassetWithOptions(ctx context.Context, ACCOUNTNAME string, ACCOUNTAUTH string, symbol string, issuer string, volume Amount, opts *CreateassetOptions) (*Asset, error) {
+	if opts == nil {
+		opts = &CreateassetOptions{}
+	}
+	return r.CreateassetContext(ctx, ACCOUNTNAME, ACCOUNTAUTH, opts.Rate, symbol, issuer, volume, opts.Decimalnumber, opts.Description)
 }
 
 /*
@@ -435,7 +808,12 @@ func (r *RPCClient) Createasset(ACCOUNTNAME string, ACCOUNTAUTH string, rate int
    :param: memo(std::string): "Attached memo for this transaction."
    :param: fee(uint64_t): "Transaction fee. defaults to 10000 etp bits"
 */
-func (r *RPCClient) Send(ACCOUNTNAME string, ACCOUNTAUTH string, TOADDRESS string, AMOUNT uint64, memo string, fee uint64) (*JSONRpcResp, error) {
+func (r *RPCClient) Send(ACCOUNTNAME string, ACCOUNTAUTH string, TOADDRESS string, AMOUNT Amount, memo string, fee Amount) (*Transaction, error) {
+	return r.SendContext(context.Background(), ACCOUNTNAME, ACCOUNTAUTH, TOADDRESS, AMOUNT, memo, fee)
+}
+
+// SendContext is like Send but honours the deadline and cancellation of ctx.
+func (r *RPCClient) SendContext(ctx context.Context, ACCOUNTNAME string, ACCOUNTAUTH string, TOADDRESS string, AMOUNT Amount, memo string, fee Amount) (*Transaction, error) {
 	cmd := "send"
 	positional := []interface{}{ACCOUNTNAME, ACCOUNTAUTH, TOADDRESS, AMOUNT}
 
@@ -448,7 +826,26 @@ func (r *RPCClient) Send(ACCOUNTNAME string, ACCOUNTAUTH string, TOADDRESS strin
 		optional["fee"] = fee
 	}
 	args := append(positional, optional)
-	return r.doPost(r.Url, cmd, args)
+	var result *Transaction
+	err := r.call(ctx, cmd, args, "transaction", &result)
+	return result, err
+}
+
+// SendOptions holds the parameters of Send that may be left out.
+type SendOptions struct {
+	// Attached memo for this transaction.
+	Memo string
+	// Transaction fee. defaults to 10000 etp bits
+	Fee Amount
+}
+
+// SendWithOptions is SendContext with the parameters that may be left out
+// in opts, which may be nil.
+func (r *RPCClient) SendWithOptions(ctx context.Context, ACCOUNTNAME string, ACCOUNTAUTH string, TOADDRESS string, AMOUNT Amount, opts *SendOptions) (*Transaction, error) {
+	if opts == nil {
+		opts = &SendOptions{}
+	}
+	return r.SendContext(ctx, ACCOUNTNAME, ACCOUNTAUTH, TOADDRESS, AMOUNT, opts.Memo, opts.Fee)
 }
 
 /*
@@ -456,7 +853,12 @@ func (r *RPCClient) Send(ACCOUNTNAME string, ACCOUNTAUTH string, TOADDRESS strin
    :param: ACCOUNTAUTH(std::string): Account password(authorization) required.
    :param: password(std::string): "The new password."
 */
-func (r *RPCClient) Changepasswd(ACCOUNTNAME string, ACCOUNTAUTH string, password string) (*JSONRpcResp, error) {
+func (r *RPCClient) Changepasswd(ACCOUNTNAME string, ACCOUNTAUTH string, password string) (*AccountStatus, error) {
+	return r.ChangepasswdContext(context.Background(), ACCOUNTNAME, ACCOUNTAUTH, password)
+}
+
+// ChangepasswdContext is like Changepasswd but honours the deadline and cancellation of ctx.
+func (r *RPCClient) ChangepasswdContext(ctx context.Context, ACCOUNTNAME string, ACCOUNTAUTH string, password string) (*AccountStatus, error) {
 	cmd := "changepasswd"
 	positional := []interface{}{ACCOUNTNAME, ACCOUNTAUTH}
 
@@ -465,7 +867,9 @@ func (r *RPCClient) Changepasswd(ACCOUNTNAME string, ACCOUNTAUTH string, passwor
 	}
 
 	args := append(positional, optional)
-	return r.doPost(r.Url, cmd, args)
+	var result *AccountStatus
+	err := r.call(ctx, cmd, args, "", &result)
+	return result, err
 }
 
 /*
@@ -478,7 +882,12 @@ func (r *RPCClient) Changepasswd(ACCOUNTNAME string, ACCOUNTAUTH string, passwor
    :param: message(std::string): "Message/Information attached to this transaction"
    :param: fee(uint64_t): "Transaction fee. defaults to 10000 ETP bits"
 */
-func (r *RPCClient) Createrawtx(type_ uint16, senders []string, receivers []string, symbol string, deposit uint16, mychange string, message string, fee uint64) (*JSONRpcResp, error) {
+func (r *RPCClient) Createrawtx(type_ uint16, senders []string, receivers Receivers, symbol string, deposit uint16, mychange string, message string, fee Amount) (string, error) {
+	return r.CreaterawtxContext(context.Background(), type_, senders, receivers, symbol, deposit, mychange, message, fee)
+}
+
+// CreaterawtxContext is like Createrawtx but honours the deadline and cancellation of ctx.
+func (r *RPCClient) CreaterawtxContext(ctx context.Context, type_ uint16, senders []string, receivers Receivers, symbol string, deposit uint16, mychange string, message string, fee Amount) (string, error) {
 	cmd := "createrawtx"
 	positional := []interface{}{}
 
@@ -504,20 +913,52 @@ func (r *RPCClient) Createrawtx(type_ uint16, senders []string, receivers []stri
 		optional["fee"] = fee
 	}
 	args := append(positional, optional)
-	return r.doPost(r.Url, cmd, args)
+	var result string
+	err := r.call(ctx, cmd, args, "", &result)
+	return result, err
+}
+
+// CreaterawtxOptions holds the parameters of Createrawtx that may be left out.
+type CreaterawtxOptions struct {
+	// asset name, not specify this option for etp tx
+	Symbol string
+	// Deposits support [7, 30, 90, 182, 365] days. defaluts to 7 days
+	Deposit uint16
+	// Mychange to this address, includes etp and asset change
+	Mychange string
+	// Message/Information attached to this transaction
+	Message string
+	// Transaction fee. defaults to 10000 ETP bits
+	Fee Amount
+}
+
+// CreaterawtxWithOptions is CreaterawtxContext with the parameters that may be left out
+// in opts, which may be nil.
+func (r *RPCClient) CreaterawtxWithOptions(ctx context.Context, type_ uint16, senders []string, receivers Receivers, opts *CreaterawtxOptions) (string, error) {
+	if opts == nil {
+		opts = &CreaterawtxOptions{}
+	}
+	return r.CreaterawtxContext(ctx, type_, senders, receivers, opts.Symbol, opts.Deposit, opts.Mychange, opts.Message, opts.Fee)
 }
 
 /*
    :param: PAYMENT_ADDRESS(std::string): "Valid payment address. If not specified the address is read from STDIN."
 */
-func (r *RPCClient) Validateaddress(PAYMENT_ADDRESS string) (*JSONRpcResp, error) {
+func (r *RPCClient) Validateaddress(PAYMENT_ADDRESS string) (*AddressValidation, error) {
+	return r.ValidateaddressContext(context.Background(), PAYMENT_ADDRESS)
+}
+
+// ValidateaddressContext is like Validateaddress but honours the deadline and cancellation of ctx.
+func (r *RPCClient) ValidateaddressContext(ctx context.Context, PAYMENT_ADDRESS string) (*AddressValidation, error) {
 	cmd := "validateaddress"
 	positional := []interface{}{PAYMENT_ADDRESS}
 
 	optional := map[string]interface{}{}
 
 	args := append(positional, optional)
-	return r.doPost(r.Url, cmd, args)
+	var result *AddressValidation
+	err := r.call(ctx, cmd, args, "", &result)
+	return result, err
 }
 
 /*
@@ -529,7 +970,12 @@ func (r *RPCClient) Validateaddress(PAYMENT_ADDRESS string) (*JSONRpcResp, error
    :param: memo(std::string): "The memo to descript transaction"
    :param: fee(uint64_t): "Transaction fee. defaults to 10000 ETP bits"
 */
-func (r *RPCClient) Sendfrom(ACCOUNTNAME string, ACCOUNTAUTH string, FROMADDRESS string, TOADDRESS string, AMOUNT uint64, memo string, fee uint64) (*JSONRpcResp, error) {
+func (r *RPCClient) Sendfrom(ACCOUNTNAME string, ACCOUNTAUTH string, FROMADDRESS string, TOADDRESS string, AMOUNT Amount, memo string, fee Amount) (*Transaction, error) {
+	return r.SendfromContext(context.Background(), ACCOUNTNAME, ACCOUNTAUTH, FROMADDRESS, TOADDRESS, AMOUNT, memo, fee)
+}
+
+// SendfromContext is like Sendfrom but honours the deadline and cancellation of ctx.
+func (r *RPCClient) SendfromContext(ctx context.Context, ACCOUNTNAME string, ACCOUNTAUTH string, FROMADDRESS string, TOADDRESS string, AMOUNT Amount, memo string, fee Amount) (*Transaction, error) {
 	cmd := "sendfrom"
 	positional := []interface{}{ACCOUNTNAME, ACCOUNTAUTH, FROMADDRESS, TOADDRESS, AMOUNT}
 
@@ -542,7 +988,26 @@ func (r *RPCClient) Sendfrom(ACCOUNTNAME string, ACCOUNTAUTH string, FROMADDRESS
 		optional["fee"] = fee
 	}
 	args := append(positional, optional)
-	return r.doPost(r.Url, cmd, args)
+	var result *Transaction
+	err := r.call(ctx, cmd, args, "transaction", &result)
+	return result, err
+}
+
+// SendfromOptions holds the parameters of Sendfrom that may be left out.
+type SendfromOptions struct {
+	// The memo to descript transaction
+	Memo string
+	// Transaction fee. defaults to 10000 ETP bits
+	Fee Amount
+}
+
+// SendfromWithOptions is SendfromContext with the parameters that may be left out
+// in opts, which may be nil.
+func (r *RPCClient) SendfromWithOptions(ctx context.Context, ACCOUNTNAME string, ACCOUNTAUTH string, FROMADDRESS string, TOADDRESS string, AMOUNT Amount, opts *SendfromOptions) (*Transaction, error) {
+	if opts == nil {
+		opts = &SendfromOptions{}
+	}
+	return r.SendfromContext(ctx, ACCOUNTNAME, ACCOUNTAUTH, FROMADDRESS, TOADDRESS, AMOUNT, opts.Memo, opts.Fee)
 }
 
 /*
@@ -550,42 +1015,63 @@ func (r *RPCClient) Sendfrom(ACCOUNTNAME string, ACCOUNTAUTH string, FROMADDRESS
    :param: ACCOUNTAUTH(std::string): Account password(authorization) required.
    :param: ADDRESS(std::string): "The multisig script corresponding address."
 */
-func (r *RPCClient) Deletemultisig(ACCOUNTNAME string, ACCOUNTAUTH string, ADDRESS string) (*JSONRpcResp, error) {
+func (r *RPCClient) Deletemultisig(ACCOUNTNAME string, ACCOUNTAUTH string, ADDRESS string) (*Multisig, error) {
+	return r.DeletemultisigContext(context.Background(), ACCOUNTNAME, ACCOUNTAUTH, ADDRESS)
+}
+
+// DeletemultisigContext is like Deletemultisig but honours the deadline and cancellation of ctx.
+func (r *RPCClient) DeletemultisigContext(ctx context.Context, ACCOUNTNAME string, ACCOUNTAUTH string, ADDRESS string) (*Multisig, error) {
 	cmd := "deletemultisig"
 	positional := []interface{}{ACCOUNTNAME, ACCOUNTAUTH, ADDRESS}
 
 	optional := map[string]interface{}{}
 
 	args := append(positional, optional)
-	return r.doPost(r.Url, cmd, args)
+	var result *Multisig
+	err := r.call(ctx, cmd, args, "", &result)
+	return result, err
 }
 
 /*
    :param: ACCOUNTNAME(std::string): Account name required.
    :param: ACCOUNTAUTH(std::string): Account password(authorization) required.
 */
-func (r *RPCClient) Listdids(ACCOUNTNAME string, ACCOUNTAUTH string) (*JSONRpcResp, error) {
+func (r *RPCClient) Listdids(ACCOUNTNAME string, ACCOUNTAUTH string) ([]DID, error) {
+	return r.ListdidsContext(context.Background(), ACCOUNTNAME, ACCOUNTAUTH)
+}
+
+// ListdidsContext is like Listdids but honours the deadline and cancellation of ctx.
+func (r *RPCClient) ListdidsContext(ctx context.Context, ACCOUNTNAME string, ACCOUNTAUTH string) ([]DID, error) {
 	cmd := "listdids"
 	positional := []interface{}{ACCOUNTNAME, ACCOUNTAUTH}
 
 	optional := map[string]interface{}{}
 
 	args := append(positional, optional)
-	return r.doPost(r.Url, cmd, args)
+	var result []DID
+	err := r.call(ctx, cmd, args, "dids", &result)
+	return result, err
 }
 
 /*
    :param: ADMINNAME(std::string): Administrator required.(when administrator_required in mvs.conf is set true)
    :param: ADMINAUTH(std::string): Administrator password required.
 */
-func (r *RPCClient) Getheight(ADMINNAME string, ADMINAUTH string) (*JSONRpcResp, error) {
+func (r *RPCClient) Getheight(ADMINNAME string, ADMINAUTH string) (uint64, error) {
+	return r.GetheightContext(context.Background(), ADMINNAME, ADMINAUTH)
+}
+
+// GetheightContext is like Getheight but honours the deadline and cancellation of ctx.
+func (r *RPCClient) GetheightContext(ctx context.Context, ADMINNAME string, ADMINAUTH string) (uint64, error) {
 	cmd := "getheight"
 	positional := []interface{}{ADMINNAME, ADMINAUTH}
 
 	optional := map[string]interface{}{}
 
 	args := append(positional, optional)
-	return r.doPost(r.Url, cmd, args)
+	var result uint64
+	err := r.call(ctx, cmd, args, "", &result)
+	return result, err
 }
 
 /*
@@ -596,7 +1082,12 @@ func (r *RPCClient) Getheight(ADMINNAME string, ADMINAUTH string) (*JSONRpcResp,
    :param: memo(std::string): "Attached memo for this transaction."
    :param: fee(uint64_t): "Transaction fee. defaults to 10000 etp bits"
 */
-func (r *RPCClient) Didsend(ACCOUNTNAME string, ACCOUNTAUTH string, TO_ string, AMOUNT uint64, memo string, fee uint64) (*JSONRpcResp, error) {
+func (r *RPCClient) Didsend(ACCOUNTNAME string, ACCOUNTAUTH string, TO_ string, AMOUNT Amount, memo string, fee Amount) (*Transaction, error) {
+	return r.DidsendContext(context.Background(), ACCOUNTNAME, ACCOUNTAUTH, TO_, AMOUNT, memo, fee)
+}
+
+// DidsendContext is like Didsend but honours the deadline and cancellation of ctx.
+func (r *RPCClient) DidsendContext(ctx context.Context, ACCOUNTNAME string, ACCOUNTAUTH string, TO_ string, AMOUNT Amount, memo string, fee Amount) (*Transaction, error) {
 	cmd := "didsend"
 	positional := []interface{}{ACCOUNTNAME, ACCOUNTAUTH, TO_, AMOUNT}
 
@@ -609,7 +1100,26 @@ func (r *RPCClient) Didsend(ACCOUNTNAME string, ACCOUNTAUTH string, TO_ string,
 		optional["fee"] = fee
 	}
 	args := append(positional, optional)
-	return r.doPost(r.Url, cmd, args)
+	var result *Transaction
+	err := r.call(ctx, cmd, args, "transaction", &result)
+	return result, err
+}
+
+// DidsendOptions holds the parameters of Didsend that may be left out.
+type DidsendOptions struct {
+	// Attached memo for this transaction.
+	Memo string
+	// Transaction fee. defaults to 10000 etp bits
+	Fee Amount
+}
+
+// DidsendWithOptions is DidsendContext with the parameters that may be left out
+// in opts, which may be nil.
+func (r *RPCClient) DidsendWithOptions(ctx context.Context, ACCOUNTNAME string, ACCOUNTAUTH string, TO_ string, AMOUNT Amount, opts *DidsendOptions) (*Transaction, error) {
+	if opts == nil {
+		opts = &DidsendOptions{}
+	}
+	return r.DidsendContext(ctx, ACCOUNTNAME, ACCOUNTAUTH, TO_, AMOUNT, opts.Memo, opts.Fee)
 }
 
 /*
@@ -620,7 +1130,12 @@ func (r *RPCClient) Didsend(ACCOUNTNAME string, ACCOUNTAUTH string, TO_ string,
    :param: CERT(std::string): "Asset cert type name. eg. ISSUE, DOMAIN or NAMING"
    :param: fee(uint64_t): "Transaction fee. defaults to 10000 ETP bits"
 */
-func (r *RPCClient) Transfercert(ACCOUNTNAME string, ACCOUNTAUTH string, TODID string, SYMBOL string, CERT string, fee uint64) (*JSONRpcResp, error) {
+func (r *RPCClient) Transfercert(ACCOUNTNAME string, ACCOUNTAUTH string, TODID string, SYMBOL string, CERT string, fee Amount) (*Transaction, error) {
+	return r.TransfercertContext(context.Background(), ACCOUNTNAME, ACCOUNTAUTH, TODID, SYMBOL, CERT, fee)
+}
+
+// TransfercertContext is like Transfercert but honours the deadline and cancellation of ctx.
+func (r *RPCClient) TransfercertContext(ctx context.Context, ACCOUNTNAME string, ACCOUNTAUTH string, TODID string, SYMBOL string, CERT string, fee Amount) (*Transaction, error) {
 	cmd := "transfercert"
 	positional := []interface{}{ACCOUNTNAME, ACCOUNTAUTH, TODID, SYMBOL, CERT}
 
@@ -630,14 +1145,36 @@ func (r *RPCClient) Transfercert(ACCOUNTNAME string, ACCOUNTAUTH string, TODID s
 		optional["fee"] = fee
 	}
 	args := append(positional, optional)
-	return r.doPost(r.Url, cmd, args)
+	var result *Transaction
+	err := r.call(ctx, cmd, args, "transaction", &result)
+	return result, err
+}
+
+// TransfercertOptions holds the parameters of Transfercert that may be left out.
+type TransfercertOptions struct {
+	// Transaction fee. defaults to 10000 ETP bits
+	Fee Amount
+}
+
+// TransfercertWithOptions is TransfercertContext with the parameters that may be left out
+// in opts, which may be nil.
+func (r *RPCClient) TransfercertWithOptions(ctx context.Context, ACCOUNTNAME string, ACCOUNTAUTH string, TODID string, SYMBOL string, CERT string, opts *TransfercertOptions) (*Transaction, error) {
+	if opts == nil {
+		opts = &TransfercertOptions{}
+	}
+	return r.TransfercertContext(ctx, ACCOUNTNAME, ACCOUNTAUTH, TODID, SYMBOL, CERT, opts.Fee)
 }
 
 /*
    :param: TRANSACTION(string of hexcode): "The input Base16 transaction to broadcast."
    :param: fee(uint64_t): "The max tx fee. default_value 10 etp"
 */
-func (r *RPCClient) Sendrawtx(TRANSACTION string, fee uint64) (*JSONRpcResp, error) {
+func (r *RPCClient) Sendrawtx(TRANSACTION string, fee Amount) (string, error) {
+	return r.SendrawtxContext(context.Background(), TRANSACTION, fee)
+}
+
+// SendrawtxContext is like Sendrawtx but honours the deadline and cancellation of ctx.
+func (r *RPCClient) SendrawtxContext(ctx context.Context, TRANSACTION string, fee Amount) (string, error) {
 	cmd := "sendrawtx"
 	positional := []interface{}{TRANSACTION}
 
@@ -647,7 +1184,24 @@ func (r *RPCClient) Sendrawtx(TRANSACTION string, fee uint64) (*JSONRpcResp, err
 		optional["fee"] = fee
 	}
 	args := append(positional, optional)
-	return r.doPost(r.Url, cmd, args)
+	var result string
+	err := r.call(ctx, cmd, args, "hash", &result)
+	return result, err
+}
+
+// SendrawtxOptions holds the parameters of Sendrawtx that may be left out.
+type SendrawtxOptions struct {
+	// The max tx fee. default_value 10 etp
+	Fee Amount
+}
+
+// SendrawtxWithOptions is SendrawtxContext with the parameters that may be left out
+// in opts, which may be nil.
+func (r *RPCClient) SendrawtxWithOptions(ctx context.Context, TRANSACTION string, opts *SendrawtxOptions) (string, error) {
+	if opts == nil {
+		opts = &SendrawtxOptions{}
+	}
+	return r.SendrawtxContext(ctx, TRANSACTION, opts.Fee)
 }
 
 /*
@@ -658,7 +1212,12 @@ func (r *RPCClient) Sendrawtx(TRANSACTION string, fee uint64) (*JSONRpcResp, err
    :param: CERT(std::string): "Asset cert type name can be: ISSUE: cert of issuing asset, generated by issuing asset and used in secondaryissue asset.  DOMAIN: cert of domain, generated by issuing asset, the symbol is same as asset symbol(if it does not contain dot) or the prefix part(that before the first dot) of asset symbol. NAMING: cert of naming right of domain. The owner of domain cert can issue this type of cert by issuecert with symbol like “domain.XYZ”(domain is the symbol of domain cert)."
    :param: fee(uint64_t): "Transaction fee. defaults to 10000 ETP bits"
 */
-func (r *RPCClient) Issuecert(ACCOUNTNAME string, ACCOUNTAUTH string, TODID string, SYMBOL string, CERT string, fee uint64) (*JSONRpcResp, error) {
+func (r *RPCClient) Issuecert(ACCOUNTNAME string, ACCOUNTAUTH string, TODID string, SYMBOL string, CERT string, fee Amount) (*Transaction, error) {
+	return r.IssuecertContext(context.Background(), ACCOUNTNAME, ACCOUNTAUTH, TODID, SYMBOL, CERT, fee)
+}
+
+// IssuecertContext is like Issuecert but honours the deadline and cancellation of ctx.
+func (r *RPCClient) IssuecertContext(ctx context.Context, ACCOUNTNAME string, ACCOUNTAUTH string, TODID string, SYMBOL string, CERT string, fee Amount) (*Transaction, error) {
 	cmd := "issuecert"
 	positional := []interface{}{ACCOUNTNAME, ACCOUNTAUTH, TODID, SYMBOL, CERT}
 
@@ -668,7 +1227,24 @@ func (r *RPCClient) Issuecert(ACCOUNTNAME string, ACCOUNTAUTH string, TODID stri
 		optional["fee"] = fee
 	}
 	args := append(positional, optional)
-	return r.doPost(r.Url, cmd, args)
+	var result *Transaction
+	err := r.call(ctx, cmd, args, "transaction", &result)
+	return result, err
+}
+
+// IssuecertOptions holds the parameters of Issuecert that may be left out.
+type IssuecertOptions struct {
+	// Transaction fee. defaults to 10000 ETP bits
+	Fee Amount
+}
+
+// IssuecertWithOptions is IssuecertContext with the parameters that may be left out
+// in opts, which may be nil.
+func (r *RPCClient) IssuecertWithOptions(ctx context.Context, ACCOUNTNAME string, ACCOUNTAUTH string, TODID string, SYMBOL string, CERT string, opts *IssuecertOptions) (*Transaction, error) {
+	if opts == nil {
+		opts = &IssuecertOptions{}
+	}
+	return r.IssuecertContext(ctx, ACCOUNTNAME, ACCOUNTAUTH, TODID, SYMBOL, CERT, opts.Fee)
 }
 
 /*
@@ -676,14 +1252,21 @@ func (r *RPCClient) Issuecert(ACCOUNTNAME string, ACCOUNTAUTH string, TODID stri
    :param: ACCOUNTAUTH(std::string): Account password(authorization) required.
    :param: NUMBER(std::string): "Block number, or earliest, latest or pending"
 */
-func (r *RPCClient) Fetchheaderext(ACCOUNTNAME string, ACCOUNTAUTH string, NUMBER string) (*JSONRpcResp, error) {
+func (r *RPCClient) Fetchheaderext(ACCOUNTNAME string, ACCOUNTAUTH string, NUMBER string) (*BlockHeader, error) {
+	return r.FetchheaderextContext(context.Background(), ACCOUNTNAME, ACCOUNTAUTH, NUMBER)
+}
+
+// FetchheaderextContext is like Fetchheaderext but honours the deadline and cancellation of ctx.
+func (r *RPCClient) FetchheaderextContext(ctx context.Context, ACCOUNTNAME string, ACCOUNTAUTH string, NUMBER string) (*BlockHeader, error) {
 	cmd := "fetchheaderext"
 	positional := []interface{}{ACCOUNTNAME, ACCOUNTAUTH, NUMBER}
 
 	optional := map[string]interface{}{}
 
 	args := append(positional, optional)
-	return r.doPost(r.Url, cmd, args)
+	var result *BlockHeader
+	err := r.call(ctx, cmd, args, "", &result)
+	return result, err
 }
 
 /*
@@ -701,10 +1284,15 @@ func (r *RPCClient) Fetchheaderext(ACCOUNTNAME string, ACCOUNTAUTH string, NUMBE
    eg:
        TYPE=1;LQ=9000;LP=60000;UN=3
        TYPE=2;LQ=9000;LP=60000;UN=3;UC=20000,20000,20000;UQ=3000,3000,3000
-   defaults to disable.
+   defaults to disable. AttenuationModel builds and checks it.
    :param: fee(uint64_t): "Transaction fee. defaults to 10000 ETP bits"
 */
-func (r *RPCClient) Didsendassetfrom(ACCOUNTNAME string, ACCOUNTAUTH string, FROM_ string, TO_ string, SYMBOL string, AMOUNT uint64, model string, fee uint64) (*JSONRpcResp, error) {
+func (r *RPCClient) Didsendassetfrom(ACCOUNTNAME string, ACCOUNTAUTH string, FROM_ string, TO_ string, SYMBOL string, AMOUNT Amount, model string, fee Amount) (*Transaction, error) {
+	return r.DidsendassetfromContext(context.Background(), ACCOUNTNAME, ACCOUNTAUTH, FROM_, TO_, SYMBOL, AMOUNT, model, fee)
+}
+
+// DidsendassetfromContext is like Didsendassetfrom but honours the deadline and cancellation of ctx.
+func (r *RPCClient) DidsendassetfromContext(ctx context.Context, ACCOUNTNAME string, ACCOUNTAUTH string, FROM_ string, TO_ string, SYMBOL string, AMOUNT Amount, model string, fee Amount) (*Transaction, error) {
 	cmd := "didsendassetfrom"
 	positional := []interface{}{ACCOUNTNAME, ACCOUNTAUTH, FROM_, TO_, SYMBOL, AMOUNT}
 
@@ -717,7 +1305,26 @@ func (r *RPCClient) Didsendassetfrom(ACCOUNTNAME string, ACCOUNTAUTH string, FRO
 		optional["fee"] = fee
 	}
 	args := append(positional, optional)
-	return r.doPost(r.Url, cmd, args)
+	var result *Transaction
+	err := r.call(ctx, cmd, args, "transaction", &result)
+	return result, err
+}
+
+// DidsendassetfromOptions holds the parameters of Didsendassetfrom that may be left out.
+type DidsendassetfromOptions struct {
+	// The token offering model by block height.
+	Model string
+	// Transaction fee. defaults to 10000 ETP bits
+	Fee Amount
+}
+
+// DidsendassetfromWithOptions is DidsendassetfromContext with the parameters that may be left out
+// in opts, which may be nil.
+func (r *RPCClient) DidsendassetfromWithOptions(ctx context.Context, ACCOUNTNAME string, ACCOUNTAUTH string, FROM_ string, TO_ string, SYMBOL string, AMOUNT Amount, opts *DidsendassetfromOptions) (*Transaction, error) {
+	if opts == nil {
+		opts = &DidsendassetfromOptions{}
+	}
+	return r.DidsendassetfromContext(ctx, ACCOUNTNAME, ACCOUNTAUTH, FROM_, TO_, SYMBOL, AMOUNT, opts.Model, opts.Fee)
 }
 
 /*
@@ -727,7 +1334,12 @@ func (r *RPCClient) Didsendassetfrom(ACCOUNTNAME string, ACCOUNTAUTH string, FRO
    :param: mychange(std::string): "Mychange to this did/address"
    :param: fee(uint64_t): "Transaction fee. defaults to 10000 ETP bits"
 */
-func (r *RPCClient) Didsendmore(ACCOUNTNAME string, ACCOUNTAUTH string, receivers []string, mychange string, fee uint64) (*JSONRpcResp, error) {
+func (r *RPCClient) Didsendmore(ACCOUNTNAME string, ACCOUNTAUTH string, receivers Receivers, mychange string, fee Amount) (*Transaction, error) {
+	return r.DidsendmoreContext(context.Background(), ACCOUNTNAME, ACCOUNTAUTH, receivers, mychange, fee)
+}
+
+// DidsendmoreContext is like Didsendmore but honours the deadline and cancellation of ctx.
+func (r *RPCClient) DidsendmoreContext(ctx context.Context, ACCOUNTNAME string, ACCOUNTAUTH string, receivers Receivers, mychange string, fee Amount) (*Transaction, error) {
 	cmd := "didsendmore"
 	positional := []interface{}{ACCOUNTNAME, ACCOUNTAUTH}
 
@@ -742,7 +1354,26 @@ func (r *RPCClient) Didsendmore(ACCOUNTNAME string, ACCOUNTAUTH string, receiver
 		optional["fee"] = fee
 	}
 	args := append(positional, optional)
-	return r.doPost(r.Url, cmd, args)
+	var result *Transaction
+	err := r.call(ctx, cmd, args, "transaction", &result)
+	return result, err
+}
+
+// DidsendmoreOptions holds the parameters of Didsendmore that may be left out.
+type DidsendmoreOptions struct {
+	// Mychange to this did/address
+	Mychange string
+	// Transaction fee. defaults to 10000 ETP bits
+	Fee Amount
+}
+
+// DidsendmoreWithOptions is DidsendmoreContext with the parameters that may be left out
+// in opts, which may be nil.
+func (r *RPCClient) DidsendmoreWithOptions(ctx context.Context, ACCOUNTNAME string, ACCOUNTAUTH string, receivers Receivers, opts *DidsendmoreOptions) (*Transaction, error) {
+	if opts == nil {
+		opts = &DidsendmoreOptions{}
+	}
+	return r.DidsendmoreContext(ctx, ACCOUNTNAME, ACCOUNTAUTH, receivers, opts.Mychange, opts.Fee)
 }
 
 /*
@@ -752,7 +1383,12 @@ func (r *RPCClient) Didsendmore(ACCOUNTNAME string, ACCOUNTAUTH string, receiver
    :param: mychange(std::string): "Mychange to this address"
    :param: fee(uint64_t): "Transaction fee. defaults to 10000 ETP bits"
 */
-func (r *RPCClient) Sendmore(ACCOUNTNAME string, ACCOUNTAUTH string, receivers []string, mychange string, fee uint64) (*JSONRpcResp, error) {
+func (r *RPCClient) Sendmore(ACCOUNTNAME string, ACCOUNTAUTH string, receivers Receivers, mychange string, fee Amount) (*Transaction, error) {
+	return r.SendmoreContext(context.Background(), ACCOUNTNAME, ACCOUNTAUTH, receivers, mychange, fee)
+}
+
+// SendmoreContext is like Sendmore but honours the deadline and cancellation of ctx.
+func (r *RPCClient) SendmoreContext(ctx context.Context, ACCOUNTNAME string, ACCOUNTAUTH string, receivers Receivers, mychange string, fee Amount) (*Transaction, error) {
 	cmd := "sendmore"
 	positional := []interface{}{ACCOUNTNAME, ACCOUNTAUTH}
 
@@ -767,7 +1403,26 @@ func (r *RPCClient) Sendmore(ACCOUNTNAME string, ACCOUNTAUTH string, receivers [
 		optional["fee"] = fee
 	}
 	args := append(positional, optional)
-	return r.doPost(r.Url, cmd, args)
+	var result *Transaction
+	err := r.call(ctx, cmd, args, "transaction", &result)
+	return result, err
+}
+
+// SendmoreOptions holds the parameters of Sendmore that may be left out.
+type SendmoreOptions struct {
+	// Mychange to this address
+	Mychange string
+	// Transaction fee. defaults to 10000 ETP bits
+	Fee Amount
+}
+
+// SendmoreWithOptions is SendmoreContext with the parameters that may be left out
+// in opts, which may be nil.
+func (r *RPCClient) SendmoreWithOptions(ctx context.Context, ACCOUNTNAME string, ACCOUNTAUTH string, receivers Receivers, opts *SendmoreOptions) (*Transaction, error) {
+	if opts == nil {
+		opts = &SendmoreOptions{}
+	}
+	return r.SendmoreContext(ctx, ACCOUNTNAME, ACCOUNTAUTH, receivers, opts.Mychange, opts.Fee)
 }
 
 /*
@@ -775,7 +1430,12 @@ func (r *RPCClient) Sendmore(ACCOUNTNAME string, ACCOUNTAUTH string, receivers [
    :param: ACCOUNTAUTH(std::string): Account password(authorization) required.
    :param: symbol(std::string): "The asset symbol/name. Global unique."
 */
-func (r *RPCClient) Deletelocalasset(ACCOUNTNAME string, ACCOUNTAUTH string, symbol string) (*JSONRpcResp, error) {
+func (r *RPCClient) Deletelocalasset(ACCOUNTNAME string, ACCOUNTAUTH string, symbol string) (string, error) {
+	return r.DeletelocalassetContext(context.Background(), ACCOUNTNAME, ACCOUNTAUTH, symbol)
+}
+
+// DeletelocalassetContext is like Deletelocalasset but honours the deadline and cancellation of ctx.
+func (r *RPCClient) DeletelocalassetContext(ctx context.Context, ACCOUNTNAME string, ACCOUNTAUTH string, symbol string) (string, error) {
 	cmd := "deletelocalasset"
 	positional := []interface{}{ACCOUNTNAME, ACCOUNTAUTH}
 
@@ -784,7 +1444,9 @@ func (r *RPCClient) Deletelocalasset(ACCOUNTNAME string, ACCOUNTAUTH string, sym
 	}
 
 	args := append(positional, optional)
-	return r.doPost(r.Url, cmd, args)
+	var result string
+	err := r.call(ctx, cmd, args, "status", &result)
+	return result, err
 }
 
 /*
@@ -796,7 +1458,12 @@ func (r *RPCClient) Deletelocalasset(ACCOUNTNAME string, ACCOUNTAUTH string, sym
    :param: limit(uint64_t): "Transaction count per page."
    :param: index(uint64_t): "Page index."
 */
-func (r *RPCClient) Listtxs(ACCOUNTNAME string, ACCOUNTAUTH string, address string, height [2]uint64, symbol string, limit uint64, index uint64) (*JSONRpcResp, error) {
+func (r *RPCClient) Listtxs(ACCOUNTNAME string, ACCOUNTAUTH string, address string, height [2]uint64, symbol string, limit uint64, index uint64) (*TxPage, error) {
+	return r.ListtxsContext(context.Background(), ACCOUNTNAME, ACCOUNTAUTH, address, height, symbol, limit, index)
+}
+
+// ListtxsContext is like Listtxs but honours the deadline and cancellation of ctx.
+func (r *RPCClient) ListtxsContext(ctx context.Context, ACCOUNTNAME string, ACCOUNTAUTH string, address string, height [2]uint64, symbol string, limit uint64, index uint64) (*TxPage, error) {
 	cmd := "listtxs"
 	positional := []interface{}{ACCOUNTNAME, ACCOUNTAUTH}
 
@@ -818,7 +1485,32 @@ func (r *RPCClient) Listtxs(ACCOUNTNAME string, ACCOUNTAUTH string, address stri
 		optional["index"] = index
 	}
 	args := append(positional, optional)
-	return r.doPost(r.Url, cmd, args)
+	var result *TxPage
+	err := r.call(ctx, cmd, args, "", &result)
+	return result, err
+}
+
+// ListtxsOptions holds the parameters of Listtxs that may be left out.
+type ListtxsOptions struct {
+	// Address.
+	Address string
+	// Get tx according height eg: -e start-height:end-height will return tx between [start-height, end-height)
+	Height [2]uint64
+	// Asset symbol.
+	Symbol string
+	// Transaction count per page.
+	Limit uint64
+	// Page index.
+	Index uint64
+}
+
+// ListtxsWithOptions is ListtxsContext with the parameters that may be left out
+// in opts, which may be nil.
+func (r *RPCClient) ListtxsWithOptions(ctx context.Context, ACCOUNTNAME string, ACCOUNTAUTH string, opts *ListtxsOptions) (*TxPage, error) {
+	if opts == nil {
+		opts = &ListtxsOptions{}
+	}
+	return r.ListtxsContext(ctx, ACCOUNTNAME, ACCOUNTAUTH, opts.Address, opts.Height, opts.Symbol, opts.Limit, opts.Index)
 }
 
 /*
@@ -828,7 +1520,12 @@ func (r *RPCClient) Listtxs(ACCOUNTNAME string, ACCOUNTAUTH string, address stri
    :param: index(uint32_t): "Page index."
    :param: current(bool): "If specified then show the lastest information of specified MIT. Default is not specified."
 */
-func (r *RPCClient) Getmit(SYMBOL string, trace bool, limit uint32, index uint32, current bool) (*JSONRpcResp, error) {
+func (r *RPCClient) Getmit(SYMBOL string, trace bool, limit uint32, index uint32, current bool) ([]MIT, error) {
+	return r.GetmitContext(context.Background(), SYMBOL, trace, limit, index, current)
+}
+
+// GetmitContext is like Getmit but honours the deadline and cancellation of ctx.
+func (r *RPCClient) GetmitContext(ctx context.Context, SYMBOL string, trace bool, limit uint32, index uint32, current bool) ([]MIT, error) {
 	cmd := "getmit"
 	positional := []interface{}{SYMBOL}
 
@@ -846,7 +1543,30 @@ func (r *RPCClient) Getmit(SYMBOL string, trace bool, limit uint32, index uint32
 		optional["index"] = index
 	}
 	args := append(positional, optional)
-	return r.doPost(r.Url, cmd, args)
+	var result []MIT
+	err := r.call(ctx, cmd, args, "mits", &result)
+	return result, err
+}
+
+// GetmitOptions holds the parameters of Getmit that may be left out.
+type GetmitOptions struct {
+	// If specified then trace the history. Default is not specified.
+	Trace bool
+	// MIT count per page.
+	Limit uint32
+	// Page index.
+	Index uint32
+	// If specified then show the lastest information of specified MIT. Default is not specified.
+	Current bool
+}
+
+// GetmitWithOptions is GetmitContext with the parameters that may be left out
+// in opts, which may be nil.
+func (r *RPCClient) GetmitWithOptions(ctx context.Context, SYMBOL string, opts *GetmitOptions) ([]MIT, error) {
+	if opts == nil {
+		opts = &GetmitOptions{}
+	}
+	return r.GetmitContext(ctx, SYMBOL, opts.Trace, opts.Limit, opts.Index, opts.Current)
 }
 
 /*
@@ -854,7 +1574,12 @@ func (r *RPCClient) Getmit(SYMBOL string, trace bool, limit uint32, index uint32
    :param: ACCOUNTNAME(std::string): Account name required.
    :param: ACCOUNTAUTH(std::string): Account password(authorization) required.
 */
-func (r *RPCClient) Getnewaccount(language string, ACCOUNTNAME string, ACCOUNTAUTH string) (*JSONRpcResp, error) {
+func (r *RPCClient) Getnewaccount(language string, ACCOUNTNAME string, ACCOUNTAUTH string) (*Account, error) {
+	return r.GetnewaccountContext(context.Background(), language, ACCOUNTNAME, ACCOUNTAUTH)
+}
+
+// GetnewaccountContext is like Getnewaccount but honours the deadline and cancellation of ctx.
+func (r *RPCClient) GetnewaccountContext(ctx context.Context, language string, ACCOUNTNAME string, ACCOUNTAUTH string) (*Account, error) {
 	cmd := "getnewaccount"
 	positional := []interface{}{ACCOUNTNAME, ACCOUNTAUTH}
 
@@ -864,35 +1589,66 @@ func (r *RPCClient) Getnewaccount(language string, ACCOUNTNAME string, ACCOUNTAU
 		optional["language"] = language
 	}
 	args := append(positional, optional)
-	return r.doPost(r.Url, cmd, args)
+	var result *Account
+	err := r.call(ctx, cmd, args, "", &result)
+	return result, err
+}
+
+// GetnewaccountOptions holds the parameters of Getnewaccount that may be left out.
+type GetnewaccountOptions struct {
+	// Options are 'en', 'es', 'ja', 'zh_Hans', 'zh_Hant' and 'any', defaults to 'en'.
+	Language string
+}
+
+// GetnewaccountWithOptions is GetnewaccountContext with the parameters that may be left out
+// in opts, which may be nil.
+func (r *RPCClient) GetnewaccountWithOptions(ctx context.Context, ACCOUNTNAME string, ACCOUNTAUTH string, opts *GetnewaccountOptions) (*Account, error) {
+	if opts == nil {
+		opts = &GetnewaccountOptions{}
+	}
+	return r.GetnewaccountContext(ctx, opts.Language, ACCOUNTNAME, ACCOUNTAUTH)
 }
 
 /*
    :param: ACCOUNTNAME(std::string): Account name required.
    :param: ACCOUNTAUTH(std::string): Account password(authorization) required.
 */
-func (r *RPCClient) Listmits(ACCOUNTNAME string, ACCOUNTAUTH string) (*JSONRpcResp, error) {
+func (r *RPCClient) Listmits(ACCOUNTNAME string, ACCOUNTAUTH string) ([]MIT, error) {
+	return r.ListmitsContext(context.Background(), ACCOUNTNAME, ACCOUNTAUTH)
+}
+
+// ListmitsContext is like Listmits but honours the deadline and cancellation of ctx.
+func (r *RPCClient) ListmitsContext(ctx context.Context, ACCOUNTNAME string, ACCOUNTAUTH string) ([]MIT, error) {
 	cmd := "listmits"
 	positional := []interface{}{ACCOUNTNAME, ACCOUNTAUTH}
 
 	optional := map[string]interface{}{}
 
 	args := append(positional, optional)
-	return r.doPost(r.Url, cmd, args)
+	var result []MIT
+	err := r.call(ctx, cmd, args, "mits", &result)
+	return result, err
 }
 
 /*
    :param: ADMINNAME(std::string): "admin name."
    :param: ADMINAUTH(std::string): "admin password/authorization."
 */
-func (r *RPCClient) Shutdown(ADMINNAME string, ADMINAUTH string) (*JSONRpcResp, error) {
+func (r *RPCClient) Shutdown(ADMINNAME string, ADMINAUTH string) (string, error) {
+	return r.ShutdownContext(context.Background(), ADMINNAME, ADMINAUTH)
+}
+
+// ShutdownContext is like Shutdown but honours the deadline and cancellation of ctx.
+func (r *RPCClient) ShutdownContext(ctx context.Context, ADMINNAME string, ADMINAUTH string) (string, error) {
 	cmd := "shutdown"
 	positional := []interface{}{ADMINNAME, ADMINAUTH}
 
 	optional := map[string]interface{}{}
 
 	args := append(positional, optional)
-	return r.doPost(r.Url, cmd, args)
+	var result string
+	err := r.call(ctx, cmd, args, "", &result)
+	return result, err
 }
 
 /*
@@ -900,14 +1656,21 @@ func (r *RPCClient) Shutdown(ADMINNAME string, ADMINAUTH string) (*JSONRpcResp,
    :param: ACCOUNTAUTH(std::string): Account password(authorization) required.
    :param: TRANSACTION(string of hexcode): "The input Base16 transaction to sign."
 */
-func (r *RPCClient) Signrawtx(ACCOUNTNAME string, ACCOUNTAUTH string, TRANSACTION string) (*JSONRpcResp, error) {
+func (r *RPCClient) Signrawtx(ACCOUNTNAME string, ACCOUNTAUTH string, TRANSACTION string) (*SignedTx, error) {
+	return r.SignrawtxContext(context.Background(), ACCOUNTNAME, ACCOUNTAUTH, TRANSACTION)
+}
+
+// SignrawtxContext is like Signrawtx but honours the deadline and cancellation of ctx.
+func (r *RPCClient) SignrawtxContext(ctx context.Context, ACCOUNTNAME string, ACCOUNTAUTH string, TRANSACTION string) (*SignedTx, error) {
 	cmd := "signrawtx"
 	positional := []interface{}{ACCOUNTNAME, ACCOUNTAUTH, TRANSACTION}
 
 	optional := map[string]interface{}{}
 
 	args := append(positional, optional)
-	return r.doPost(r.Url, cmd, args)
+	var result *SignedTx
+	err := r.call(ctx, cmd, args, "", &result)
+	return result, err
 }
 
 /*
@@ -915,7 +1678,12 @@ func (r *RPCClient) Signrawtx(ACCOUNTNAME string, ACCOUNTAUTH string, TRANSACTIO
    :param: ADMINNAME(std::string): Administrator required.(when administrator_required in mvs.conf is set true)
    :param: ADMINAUTH(std::string): Administrator password required.
 */
-func (r *RPCClient) Getmemorypool(json bool, ADMINNAME string, ADMINAUTH string) (*JSONRpcResp, error) {
+func (r *RPCClient) Getmemorypool(json bool, ADMINNAME string, ADMINAUTH string) ([]Transaction, error) {
+	return r.GetmemorypoolContext(context.Background(), json, ADMINNAME, ADMINAUTH)
+}
+
+// GetmemorypoolContext is like Getmemorypool but honours the deadline and cancellation of ctx.
+func (r *RPCClient) GetmemorypoolContext(ctx context.Context, json bool, ADMINNAME string, ADMINAUTH string) ([]Transaction, error) {
 	cmd := "getmemorypool"
 	positional := []interface{}{ADMINNAME, ADMINAUTH}
 
@@ -925,14 +1693,36 @@ func (r *RPCClient) Getmemorypool(json bool, ADMINNAME string, ADMINAUTH string)
 		optional["json"] = json
 	}
 	args := append(positional, optional)
-	return r.doPost(r.Url, cmd, args)
+	var result []Transaction
+	err := r.call(ctx, cmd, args, "transactions", &result)
+	return result, err
+}
+
+// GetmemorypoolOptions holds the parameters of Getmemorypool that may be left out.
+type GetmemorypoolOptions struct {
+	// Json format or Raw format, default is Json(true).
+	Json bool
+}
+
+// GetmemorypoolWithOptions is GetmemorypoolContext with the parameters that may be left out
+// in opts, which may be nil.
+func (r *RPCClient) GetmemorypoolWithOptions(ctx context.Context, ADMINNAME string, ADMINAUTH string, opts *GetmemorypoolOptions) ([]Transaction, error) {
+	if opts == nil {
+		opts = &GetmemorypoolOptions{}
+	}
+	return r.GetmemorypoolContext(ctx, opts.Json, ADMINNAME, ADMINAUTH)
 }
 
 /*
    :param: hash(string of hash256): "The Base16 block hash."
    :param: height(uint32_t): "The block height."
 */
-func (r *RPCClient) Getblockheader(hash string, height uint32) (*JSONRpcResp, error) {
+func (r *RPCClient) Getblockheader(hash string, height uint32) (*BlockHeader, error) {
+	return r.GetblockheaderContext(context.Background(), hash, height)
+}
+
+// GetblockheaderContext is like Getblockheader but honours the deadline and cancellation of ctx.
+func (r *RPCClient) GetblockheaderContext(ctx context.Context, hash string, height uint32) (*BlockHeader, error) {
 	cmd := "getblockheader"
 	positional := []interface{}{}
 
@@ -945,7 +1735,26 @@ func (r *RPCClient) Getblockheader(hash string, height uint32) (*JSONRpcResp, er
 		optional["height"] = height
 	}
 	args := append(positional, optional)
-	return r.doPost(r.Url, cmd, args)
+	var result *BlockHeader
+	err := r.call(ctx, cmd, args, "", &result)
+	return result, err
+}
+
+// GetblockheaderOptions holds the parameters of Getblockheader that may be left out.
+type GetblockheaderOptions struct {
+	// The Base16 block hash.
+	Hash string
+	// The block height.
+	Height uint32
+}
+
+// GetblockheaderWithOptions is GetblockheaderContext with the parameters that may be left out
+// in opts, which may be nil.
+func (r *RPCClient) GetblockheaderWithOptions(ctx context.Context, opts *GetblockheaderOptions) (*BlockHeader, error) {
+	if opts == nil {
+		opts = &GetblockheaderOptions{}
+	}
+	return r.GetblockheaderContext(ctx, opts.Hash, opts.Height)
 }
 
 /*
@@ -953,7 +1762,12 @@ func (r *RPCClient) Getblockheader(hash string, height uint32) (*JSONRpcResp, er
    :param: ACCOUNTAUTH(std::string): Account password(authorization) required.
    :param: cert(bool): "If specified, then only get related asset cert. Default is not specified."
 */
-func (r *RPCClient) Listassets(ACCOUNTNAME string, ACCOUNTAUTH string, cert bool) (*JSONRpcResp, error) {
+func (r *RPCClient) Listassets(ACCOUNTNAME string, ACCOUNTAUTH string, cert bool) ([]AssetBalance, error) {
+	return r.ListassetsContext(context.Background(), ACCOUNTNAME, ACCOUNTAUTH, cert)
+}
+
+// ListassetsContext is like Listassets but honours the deadline and cancellation of ctx.
+func (r *RPCClient) ListassetsContext(ctx context.Context, ACCOUNTNAME string, ACCOUNTAUTH string, cert bool) ([]AssetBalance, error) {
 	cmd := "listassets"
 	positional := []interface{}{ACCOUNTNAME, ACCOUNTAUTH}
 
@@ -963,7 +1777,24 @@ func (r *RPCClient) Listassets(ACCOUNTNAME string, ACCOUNTAUTH string, cert bool
 	}
 
 	args := append(positional, optional)
-	return r.doPost(r.Url, cmd, args)
+	var result []AssetBalance
+	err := r.call(ctx, cmd, args, "assets", &result)
+	return result, err
+}
+
+// ListassetsOptions holds the parameters of Listassets that may be left out.
+type ListassetsOptions struct {
+	// If specified, then only get related asset cert. Default is not specified.
+	Cert bool
+}
+
+// ListassetsWithOptions is ListassetsContext with the parameters that may be left out
+// in opts, which may be nil.
+func (r *RPCClient) ListassetsWithOptions(ctx context.Context, ACCOUNTNAME string, ACCOUNTAUTH string, opts *ListassetsOptions) ([]AssetBalance, error) {
+	if opts == nil {
+		opts = &ListassetsOptions{}
+	}
+	return r.ListassetsContext(ctx, ACCOUNTNAME, ACCOUNTAUTH, opts.Cert)
 }
 
 /*
@@ -981,10 +1812,15 @@ func (r *RPCClient) Listassets(ACCOUNTNAME string, ACCOUNTAUTH string, cert bool
    eg:
        TYPE=1;LQ=9000;LP=60000;UN=3
        TYPE=2;LQ=9000;LP=60000;UN=3;UC=20000,20000,20000;UQ=3000,3000,3000
-   defaults to disable.
+   defaults to disable. AttenuationModel builds and checks it.
    :param: fee(uint64_t): "Transaction fee. defaults to 10000 ETP bits"
 */
-func (r *RPCClient) Sendassetfrom(ACCOUNTNAME string, ACCOUNTAUTH string, FROMADDRESS string, TOADDRESS string, SYMBOL string, AMOUNT uint64, model string, fee uint64) (*JSONRpcResp, error) {
+func (r *RPCClient) Sendassetfrom(ACCOUNTNAME string, ACCOUNTAUTH string, FROMADDRESS string, TOADDRESS string, SYMBOL string, AMOUNT Amount, model string, fee Amount) (*Transaction, error) {
+	return r.SendassetfromContext(context.Background(), ACCOUNTNAME, ACCOUNTAUTH, FROMADDRESS, TOADDRESS, SYMBOL, AMOUNT, model, fee)
+}
+
+// SendassetfromContext is like Sendassetfrom but honours the deadline and cancellation of ctx.
+func (r *RPCClient) SendassetfromContext(ctx context.Context, ACCOUNTNAME string, ACCOUNTAUTH string, FROMADDRESS string, TOADDRESS string, SYMBOL string, AMOUNT Amount, model string, fee Amount) (*Transaction, error) {
 	cmd := "sendassetfrom"
 	positional := []interface{}{ACCOUNTNAME, ACCOUNTAUTH, FROMADDRESS, TOADDRESS, SYMBOL, AMOUNT}
 
@@ -997,14 +1833,38 @@ func (r *RPCClient) Sendassetfrom(ACCOUNTNAME string, ACCOUNTAUTH string, FROMAD
 		optional["fee"] = fee
 	}
 	args := append(positional, optional)
-	return r.doPost(r.Url, cmd, args)
+	var result *Transaction
+	err := r.call(ctx, cmd, args, "transaction", &result)
+	return result, err
+}
+
+// SendassetfromOptions holds the parameters of Sendassetfrom that may be left out.
+type SendassetfromOptions struct {
+	// The token offering model by block height.
+	Model string
+	// Transaction fee. defaults to 10000 ETP bits
+	Fee Amount
+}
+
+// SendassetfromWithOptions is SendassetfromContext with the parameters that may be left out
+// in opts, which may be nil.
+func (r *RPCClient) SendassetfromWithOptions(ctx context.Context, ACCOUNTNAME string, ACCOUNTAUTH string, FROMADDRESS string, TOADDRESS string, SYMBOL string, AMOUNT Amount, opts *SendassetfromOptions) (*Transaction, error) {
+	if opts == nil {
+		opts = &SendassetfromOptions{}
+	}
+	return r.SendassetfromContext(ctx, ACCOUNTNAME, ACCOUNTAUTH, FROMADDRESS, TOADDRESS, SYMBOL, AMOUNT, opts.Model, opts.Fee)
 }
 
 /*
    :param: SYMBOL(std::string): "Asset symbol. If not specified, will show whole network asset symbols."
    :param: cert(bool): "If specified, then only get related asset cert. Default is not specified."
 */
-func (r *RPCClient) Getasset(SYMBOL string, cert bool) (*JSONRpcResp, error) {
+func (r *RPCClient) Getasset(SYMBOL string, cert bool) ([]Asset, error) {
+	return r.GetassetContext(context.Background(), SYMBOL, cert)
+}
+
+// GetassetContext is like Getasset but honours the deadline and cancellation of ctx.
+func (r *RPCClient) GetassetContext(ctx context.Context, SYMBOL string, cert bool) ([]Asset, error) {
 	cmd := "getasset"
 	positional := []interface{}{SYMBOL}
 
@@ -1014,21 +1874,45 @@ func (r *RPCClient) Getasset(SYMBOL string, cert bool) (*JSONRpcResp, error) {
 	}
 
 	args := append(positional, optional)
-	return r.doPost(r.Url, cmd, args)
+	var result []Asset
+	err := r.call(ctx, cmd, args, "assets", &result)
+	return result, err
+}
+
+// GetassetOptions holds the parameters of Getasset that may be left out.
+type GetassetOptions struct {
+	// If specified, then only get related asset cert. Default is not specified.
+	Cert bool
+}
+
+// GetassetWithOptions is GetassetContext with the parameters that may be left out
+// in opts, which may be nil.
+func (r *RPCClient) GetassetWithOptions(ctx context.Context, SYMBOL string, opts *GetassetOptions) ([]Asset, error) {
+	if opts == nil {
+		opts = &GetassetOptions{}
+	}
+	return r.GetassetContext(ctx, SYMBOL, opts.Cert)
 }
 
 /*
    :param: ADMINNAME(std::string): Administrator required.(when administrator_required in mvs.conf is set true)
    :param: ADMINAUTH(std::string): Administrator password required.
 */
-func (r *RPCClient) Getinfo(ADMINNAME string, ADMINAUTH string) (*JSONRpcResp, error) {
+func (r *RPCClient) Getinfo(ADMINNAME string, ADMINAUTH string) (*Info, error) {
+	return r.GetinfoContext(context.Background(), ADMINNAME, ADMINAUTH)
+}
+
+// GetinfoContext is like Getinfo but honours the deadline and cancellation of ctx.
+func (r *RPCClient) GetinfoContext(ctx context.Context, ADMINNAME string, ADMINAUTH string) (*Info, error) {
 	cmd := "getinfo"
 	positional := []interface{}{ADMINNAME, ADMINAUTH}
 
 	optional := map[string]interface{}{}
 
 	args := append(positional, optional)
-	return r.doPost(r.Url, cmd, args)
+	var result *Info
+	err := r.call(ctx, cmd, args, "", &result)
+	return result, err
 }
 
 /*
@@ -1045,10 +1929,15 @@ func (r *RPCClient) Getinfo(ADMINNAME string, ADMINAUTH string) (*JSONRpcResp, e
    eg:
        TYPE=1;LQ=9000;LP=60000;UN=3
        TYPE=2;LQ=9000;LP=60000;UN=3;UC=20000,20000,20000;UQ=3000,3000,3000
-   defaults to disable.
+   defaults to disable. AttenuationModel builds and checks it.
    :param: fee(uint64_t): "The fee of tx. default_value 10000 ETP bits"
 */
-func (r *RPCClient) Secondaryissue(ACCOUNTNAME string, ACCOUNTAUTH string, TODID string, SYMBOL string, VOLUME uint64, model string, fee uint64) (*JSONRpcResp, error) {
+func (r *RPCClient) Secondaryissue(ACCOUNTNAME string, ACCOUNTAUTH string, TODID string, SYMBOL string, VOLUME Amount, model string, fee Amount) (*Transaction, error) {
+	return r.SecondaryissueContext(context.Background(), ACCOUNTNAME, ACCOUNTAUTH, TODID, SYMBOL, VOLUME, model, fee)
+}
+
+// SecondaryissueContext is like Secondaryissue but honours the deadline and cancellation of ctx.
+func (r *RPCClient) SecondaryissueContext(ctx context.Context, ACCOUNTNAME string, ACCOUNTAUTH string, TODID string, SYMBOL string, VOLUME Amount, model string, fee Amount) (*Transaction, error) {
 	cmd := "secondaryissue"
 	positional := []interface{}{ACCOUNTNAME, ACCOUNTAUTH, TODID, SYMBOL, VOLUME}
 
@@ -1061,14 +1950,38 @@ func (r *RPCClient) Secondaryissue(ACCOUNTNAME string, ACCOUNTAUTH string, TODID
 		optional["fee"] = fee
 	}
 	args := append(positional, optional)
-	return r.doPost(r.Url, cmd, args)
+	var result *Transaction
+	err := r.call(ctx, cmd, args, "transaction", &result)
+	return result, err
+}
+
+// SecondaryissueOptions holds the parameters of Secondaryissue that may be left out.
+type SecondaryissueOptions struct {
+	// The token offering model by block height.
+	Model string
+	// The fee of tx. default_value 10000 ETP bits
+	Fee Amount
+}
+
+// SecondaryissueWithOptions is SecondaryissueContext with the parameters that may be left out
+// in opts, which may be nil.
+func (r *RPCClient) SecondaryissueWithOptions(ctx context.Context, ACCOUNTNAME string, ACCOUNTAUTH string, TODID string, SYMBOL string, VOLUME Amount, opts *SecondaryissueOptions) (*Transaction, error) {
+	if opts == nil {
+		opts = &SecondaryissueOptions{}
+	}
+	return r.SecondaryissueContext(ctx, ACCOUNTNAME, ACCOUNTAUTH, TODID, SYMBOL, VOLUME, opts.Model, opts.Fee)
 }
 
 /*
    :param: ADDRESS(std::string): "address"
    :param: cert(bool): "If specified, then only get related asset cert. Default is not specified."
 */
-func (r *RPCClient) Getaddressasset(ADDRESS string, cert bool) (*JSONRpcResp, error) {
+func (r *RPCClient) Getaddressasset(ADDRESS string, cert bool) ([]AssetBalance, error) {
+	return r.GetaddressassetContext(context.Background(), ADDRESS, cert)
+}
+
+// GetaddressassetContext is like Getaddressasset but honours the deadline and cancellation of ctx.
+func (r *RPCClient) GetaddressassetContext(ctx context.Context, ADDRESS string, cert bool) ([]AssetBalance, error) {
 	cmd := "getaddressasset"
 	positional := []interface{}{ADDRESS}
 
@@ -1078,7 +1991,24 @@ func (r *RPCClient) Getaddressasset(ADDRESS string, cert bool) (*JSONRpcResp, er
 	}
 
 	args := append(positional, optional)
-	return r.doPost(r.Url, cmd, args)
+	var result []AssetBalance
+	err := r.call(ctx, cmd, args, "assets", &result)
+	return result, err
+}
+
+// GetaddressassetOptions holds the parameters of Getaddressasset that may be left out.
+type GetaddressassetOptions struct {
+	// If specified, then only get related asset cert. Default is not specified.
+	Cert bool
+}
+
+// GetaddressassetWithOptions is GetaddressassetContext with the parameters that may be left out
+// in opts, which may be nil.
+func (r *RPCClient) GetaddressassetWithOptions(ctx context.Context, ADDRESS string, opts *GetaddressassetOptions) ([]AssetBalance, error) {
+	if opts == nil {
+		opts = &GetaddressassetOptions{}
+	}
+	return r.GetaddressassetContext(ctx, ADDRESS, opts.Cert)
 }
 
 /*
@@ -1086,7 +2016,12 @@ func (r *RPCClient) Getaddressasset(ADDRESS string, cert bool) (*JSONRpcResp, er
    :param: ACCOUNTAUTH(std::string): Account password(authorization) required.
    :param: number(std::uint32_t): "The number of addresses to be generated, defaults to 1."
 */
-func (r *RPCClient) Getnewaddress(ACCOUNTNAME string, ACCOUNTAUTH string, number uint32) (*JSONRpcResp, error) {
+func (r *RPCClient) Getnewaddress(ACCOUNTNAME string, ACCOUNTAUTH string, number uint32) ([]string, error) {
+	return r.GetnewaddressContext(context.Background(), ACCOUNTNAME, ACCOUNTAUTH, number)
+}
+
+// GetnewaddressContext is like Getnewaddress but honours the deadline and cancellation of ctx.
+func (r *RPCClient) GetnewaddressContext(ctx context.Context, ACCOUNTNAME string, ACCOUNTAUTH string, number uint32) ([]string, error) {
 	cmd := "getnewaddress"
 	positional := []interface{}{ACCOUNTNAME, ACCOUNTAUTH}
 
@@ -1096,21 +2031,45 @@ func (r *RPCClient) Getnewaddress(ACCOUNTNAME string, ACCOUNTAUTH string, number
 		optional["number"] = number
 	}
 	args := append(positional, optional)
-	return r.doPost(r.Url, cmd, args)
+	var result []string
+	err := r.call(ctx, cmd, args, "addresses", &result)
+	return result, err
+}
+
+// GetnewaddressOptions holds the parameters of Getnewaddress that may be left out.
+type GetnewaddressOptions struct {
+	// The number of addresses to be generated, defaults to 1.
+	Number uint32
+}
+
+// GetnewaddressWithOptions is GetnewaddressContext with the parameters that may be left out
+// in opts, which may be nil.
+func (r *RPCClient) GetnewaddressWithOptions(ctx context.Context, ACCOUNTNAME string, ACCOUNTAUTH string, opts *GetnewaddressOptions) ([]string, error) {
+	if opts == nil {
+		opts = &GetnewaddressOptions{}
+	}
+	return r.GetnewaddressContext(ctx, ACCOUNTNAME, ACCOUNTAUTH, opts.Number)
 }
 
 /*
    :param: ACCOUNTNAME(std::string): Account name required.
    :param: ACCOUNTAUTH(std::string): Account password(authorization) required.
 */
-func (r *RPCClient) Getbalance(ACCOUNTNAME string, ACCOUNTAUTH string) (*JSONRpcResp, error) {
+func (r *RPCClient) Getbalance(ACCOUNTNAME string, ACCOUNTAUTH string) (*Balance, error) {
+	return r.GetbalanceContext(context.Background(), ACCOUNTNAME, ACCOUNTAUTH)
+}
+
+// GetbalanceContext is like Getbalance but honours the deadline and cancellation of ctx.
+func (r *RPCClient) GetbalanceContext(ctx context.Context, ACCOUNTNAME string, ACCOUNTAUTH string) (*Balance, error) {
 	cmd := "getbalance"
 	positional := []interface{}{ACCOUNTNAME, ACCOUNTAUTH}
 
 	optional := map[string]interface{}{}
 
 	args := append(positional, optional)
-	return r.doPost(r.Url, cmd, args)
+	var result *Balance
+	err := r.call(ctx, cmd, args, "", &result)
+	return result, err
 }
 
 /*
@@ -1122,7 +2081,12 @@ func (r *RPCClient) Getbalance(ACCOUNTNAME string, ACCOUNTAUTH string) (*JSONRpc
    :param: publickey(list of string): "cosigner public key used for multisig"
    :param: description(std::string): "multisig record description."
 */
-func (r *RPCClient) Getnewmultisig(ACCOUNTNAME string, ACCOUNTAUTH string, signaturenum uint16, publickeynum uint16, selfpublickey string, publickey []string, description string) (*JSONRpcResp, error) {
+func (r *RPCClient) Getnewmultisig(ACCOUNTNAME string, ACCOUNTAUTH string, signaturenum uint16, publickeynum uint16, selfpublickey string, publickey []string, description string) (*Multisig, error) {
+	return r.GetnewmultisigContext(context.Background(), ACCOUNTNAME, ACCOUNTAUTH, signaturenum, publickeynum, selfpublickey, publickey, description)
+}
+
+// GetnewmultisigContext is like Getnewmultisig but honours the deadline and cancellation of ctx.
+func (r *RPCClient) GetnewmultisigContext(ctx context.Context, ACCOUNTNAME string, ACCOUNTAUTH string, signaturenum uint16, publickeynum uint16, selfpublickey string, publickey []string, description string) (*Multisig, error) {
 	cmd := "getnewmultisig"
 	positional := []interface{}{ACCOUNTNAME, ACCOUNTAUTH}
 
@@ -1139,7 +2103,26 @@ func (r *RPCClient) Getnewmultisig(ACCOUNTNAME string, ACCOUNTAUTH string, signa
 		optional["description"] = description
 	}
 	args := append(positional, optional)
-	return r.doPost(r.Url, cmd, args)
+	var result *Multisig
+	err := r.call(ctx, cmd, args, "", &result)
+	return result, err
+}
+
+// GetnewmultisigOptions holds the parameters of Getnewmultisig that may be left out.
+type GetnewmultisigOptions struct {
+	// cosigner public key used for multisig
+	Publickey []string
+	// multisig record description.
+	Description string
+}
+
+// GetnewmultisigWithOptions is GetnewmultisigContext with the parameters that may be left out
+// in opts, which may be nil.
+func (r *RPCClient) GetnewmultisigWithOptions(ctx context.Context, ACCOUNTNAME string, ACCOUNTAUTH string, signaturenum uint16, publickeynum uint16, selfpublickey string, opts *GetnewmultisigOptions) (*Multisig, error) {
+	if opts == nil {
+		opts = &GetnewmultisigOptions{}
+	}
+	return r.GetnewmultisigContext(ctx, ACCOUNTNAME, ACCOUNTAUTH, signaturenum, publickeynum, selfpublickey, opts.Publickey, opts.Description)
 }
 
 /*
@@ -1149,7 +2132,12 @@ func (r *RPCClient) Getnewmultisig(ACCOUNTNAME string, ACCOUNTAUTH string, signa
    :param: SYMBOL(std::string): "Asset MIT symbol"
    :param: fee(uint64_t): "Transaction fee. defaults to 10000 ETP bits"
 */
-func (r *RPCClient) Transfermit(ACCOUNTNAME string, ACCOUNTAUTH string, TODID string, SYMBOL string, fee uint64) (*JSONRpcResp, error) {
+func (r *RPCClient) Transfermit(ACCOUNTNAME string, ACCOUNTAUTH string, TODID string, SYMBOL string, fee Amount) (*Transaction, error) {
+	return r.TransfermitContext(context.Background(), ACCOUNTNAME, ACCOUNTAUTH, TODID, SYMBOL, fee)
+}
+
+// TransfermitContext is like Transfermit but honours the deadline and cancellation of ctx.
+func (r *RPCClient) TransfermitContext(ctx context.Context, ACCOUNTNAME string, ACCOUNTAUTH string, TODID string, SYMBOL string, fee Amount) (*Transaction, error) {
 	cmd := "transfermit"
 	positional := []interface{}{ACCOUNTNAME, ACCOUNTAUTH, TODID, SYMBOL}
 
@@ -1159,7 +2147,24 @@ func (r *RPCClient) Transfermit(ACCOUNTNAME string, ACCOUNTAUTH string, TODID st
 		optional["fee"] = fee
 	}
 	args := append(positional, optional)
-	return r.doPost(r.Url, cmd, args)
+	var result *Transaction
+	err := r.call(ctx, cmd, args, "transaction", &result)
+	return result, err
+}
+
+// TransfermitOptions holds the parameters of Transfermit that may be left out.
+type TransfermitOptions struct {
+	// Transaction fee. defaults to 10000 ETP bits
+	Fee Amount
+}
+
+// TransfermitWithOptions is TransfermitContext with the parameters that may be left out
+// in opts, which may be nil.
+func (r *RPCClient) TransfermitWithOptions(ctx context.Context, ACCOUNTNAME string, ACCOUNTAUTH string, TODID string, SYMBOL string, opts *TransfermitOptions) (*Transaction, error) {
+	if opts == nil {
+		opts = &TransfermitOptions{}
+	}
+	return r.TransfermitContext(ctx, ACCOUNTNAME, ACCOUNTAUTH, TODID, SYMBOL, opts.Fee)
 }
 
 /*
@@ -1167,41 +2172,62 @@ func (r *RPCClient) Transfermit(ACCOUNTNAME string, ACCOUNTAUTH string, TODID st
    :param: ACCOUNTAUTH(std::string): Account password(authorization) required.
    :param: LASTWORD(std::string): "The last word of your private-key phrase."
 */
-func (r *RPCClient) Deleteaccount(ACCOUNTNAME string, ACCOUNTAUTH string, LASTWORD string) (*JSONRpcResp, error) {
+func (r *RPCClient) Deleteaccount(ACCOUNTNAME string, ACCOUNTAUTH string, LASTWORD string) (*AccountStatus, error) {
+	return r.DeleteaccountContext(context.Background(), ACCOUNTNAME, ACCOUNTAUTH, LASTWORD)
+}
+
+// DeleteaccountContext is like Deleteaccount but honours the deadline and cancellation of ctx.
+func (r *RPCClient) DeleteaccountContext(ctx context.Context, ACCOUNTNAME string, ACCOUNTAUTH string, LASTWORD string) (*AccountStatus, error) {
 	cmd := "deleteaccount"
 	positional := []interface{}{ACCOUNTNAME, ACCOUNTAUTH, LASTWORD}
 
 	optional := map[string]interface{}{}
 
 	args := append(positional, optional)
-	return r.doPost(r.Url, cmd, args)
+	var result *AccountStatus
+	err := r.call(ctx, cmd, args, "", &result)
+	return result, err
 }
 
 /*
    :param: ACCOUNTNAME(std::string): Account name required.
    :param: ACCOUNTAUTH(std::string): Account password(authorization) required.
 */
-func (r *RPCClient) Listmultisig(ACCOUNTNAME string, ACCOUNTAUTH string) (*JSONRpcResp, error) {
+func (r *RPCClient) Listmultisig(ACCOUNTNAME string, ACCOUNTAUTH string) ([]Multisig, error) {
+	return r.ListmultisigContext(context.Background(), ACCOUNTNAME, ACCOUNTAUTH)
+}
+
+// ListmultisigContext is like Listmultisig but honours the deadline and cancellation of ctx.
+func (r *RPCClient) ListmultisigContext(ctx context.Context, ACCOUNTNAME string, ACCOUNTAUTH string) ([]Multisig, error) {
 	cmd := "listmultisig"
 	positional := []interface{}{ACCOUNTNAME, ACCOUNTAUTH}
 
 	optional := map[string]interface{}{}
 
 	args := append(positional, optional)
-	return r.doPost(r.Url, cmd, args)
+	var result []Multisig
+	err := r.call(ctx, cmd, args, "multisig", &result)
+	return result, err
 }
 
 /*
    :param: DidOrAddress(std::string): "Did symbol or standard address; If no input parameters, then display whole network DIDs."
 */
-func (r *RPCClient) Getdid(DidOrAddress string) (*JSONRpcResp, error) {
+func (r *RPCClient) Getdid(DidOrAddress string) (*DID, error) {
+	return r.GetdidContext(context.Background(), DidOrAddress)
+}
+
+// GetdidContext is like Getdid but honours the deadline and cancellation of ctx.
+func (r *RPCClient) GetdidContext(ctx context.Context, DidOrAddress string) (*DID, error) {
 	cmd := "getdid"
 	positional := []interface{}{DidOrAddress}
 
 	optional := map[string]interface{}{}
 
 	args := append(positional, optional)
-	return r.doPost(r.Url, cmd, args)
+	var result *DID
+	err := r.call(ctx, cmd, args, "", &result)
+	return result, err
 }
 
 /*
@@ -1210,7 +2236,12 @@ func (r *RPCClient) Getdid(DidOrAddress string) (*JSONRpcResp, error) {
    :param: address(std::string): "The mining target address. Defaults to empty, means a new address will be generated."
    :param: number(uint16_t): "The number of mining blocks, useful for testing. Defaults to 0, means no limit."
 */
-func (r *RPCClient) Startmining(ACCOUNTNAME string, ACCOUNTAUTH string, address string, number uint16) (*JSONRpcResp, error) {
+func (r *RPCClient) Startmining(ACCOUNTNAME string, ACCOUNTAUTH string, address string, number uint16) (string, error) {
+	return r.StartminingContext(context.Background(), ACCOUNTNAME, ACCOUNTAUTH, address, number)
+}
+
+// StartminingContext is like Startmining but honours the deadline and cancellation of ctx.
+func (r *RPCClient) StartminingContext(ctx context.Context, ACCOUNTNAME string, ACCOUNTAUTH string, address string, number uint16) (string, error) {
 	cmd := "startmining"
 	positional := []interface{}{ACCOUNTNAME, ACCOUNTAUTH}
 
@@ -1223,21 +2254,47 @@ func (r *RPCClient) Startmining(ACCOUNTNAME string, ACCOUNTAUTH string, address
 		optional["number"] = number
 	}
 	args := append(positional, optional)
-	return r.doPost(r.Url, cmd, args)
+	var result string
+	err := r.call(ctx, cmd, args, "", &result)
+	return result, err
+}
+
+// StartminingOptions holds the parameters of Startmining that may be left out.
+type StartminingOptions struct {
+	// The mining target address. Defaults to empty, means a new address will be generated.
+	Address string
+	// The number of mining blocks, useful for testing. Defaults to 0, means no limit.
+	Number uint16
+}
+
+// StartminingWithOptions is StartminingContext with the parameters that may be left out
+// in opts, which may be nil.
+func (r *RPCClient) StartminingWithOptions(ctx context.Context, ACCOUNTNAME string, ACCOUNTAUTH string, opts *StartminingOptions) (string, error) {
+	if opts == nil {
+		opts = &StartminingOptions{}
+	}
+	return r.StartminingContext(ctx, ACCOUNTNAME, ACCOUNTAUTH, opts.Address, opts.Number)
 }
 
 /*
    :param: ADMINNAME(std::string): Administrator required.(when administrator_required in mvs.conf is set true)
    :param: ADMINAUTH(std::string): Administrator password required.
 */
-func (r *RPCClient) Getwork(ADMINNAME string, ADMINAUTH string) (*JSONRpcResp, error) {
+func (r *RPCClient) Getwork(ADMINNAME string, ADMINAUTH string) ([]string, error) {
+	return r.GetworkContext(context.Background(), ADMINNAME, ADMINAUTH)
+}
+
+// GetworkContext is like Getwork but honours the deadline and cancellation of ctx.
+func (r *RPCClient) GetworkContext(ctx context.Context, ADMINNAME string, ADMINAUTH string) ([]string, error) {
 	cmd := "getwork"
 	positional := []interface{}{ADMINNAME, ADMINAUTH}
 
 	optional := map[string]interface{}{}
 
 	args := append(positional, optional)
-	return r.doPost(r.Url, cmd, args)
+	var result []string
+	err := r.call(ctx, cmd, args, "", &result)
+	return result, err
 }
 
 /*
@@ -1246,27 +2303,41 @@ func (r *RPCClient) Getwork(ADMINNAME string, ADMINAUTH string) (*JSONRpcResp, e
    :param: FILE(string of file path): "key file path."
    :param: FILECONTENT(std::string): "key file content. this will omit the FILE argument if specified."
 */
-func (r *RPCClient) Importkeyfile(ACCOUNTNAME string, ACCOUNTAUTH string, FILE string, FILECONTENT string) (*JSONRpcResp, error) {
+func (r *RPCClient) Importkeyfile(ACCOUNTNAME string, ACCOUNTAUTH string, FILE string, FILECONTENT string) (string, error) {
+	return r.ImportkeyfileContext(context.Background(), ACCOUNTNAME, ACCOUNTAUTH, FILE, FILECONTENT)
+}
+
+// ImportkeyfileContext is like Importkeyfile but honours the deadline and cancellation of ctx.
+func (r *RPCClient) ImportkeyfileContext(ctx context.Context, ACCOUNTNAME string, ACCOUNTAUTH string, FILE string, FILECONTENT string) (string, error) {
 	cmd := "importkeyfile"
 	positional := []interface{}{ACCOUNTNAME, ACCOUNTAUTH, FILE, FILECONTENT}
 
 	optional := map[string]interface{}{}
 
 	args := append(positional, optional)
-	return r.doPost(r.Url, cmd, args)
+	var result string
+	err := r.call(ctx, cmd, args, "", &result)
+	return result, err
 }
 
 /*
    :param: TRANSACTION(string of hexcode): "The input Base16 transaction to sign."
 */
-func (r *RPCClient) Decoderawtx(TRANSACTION string) (*JSONRpcResp, error) {
+func (r *RPCClient) Decoderawtx(TRANSACTION string) (*Transaction, error) {
+	return r.DecoderawtxContext(context.Background(), TRANSACTION)
+}
+
+// DecoderawtxContext is like Decoderawtx but honours the deadline and cancellation of ctx.
+func (r *RPCClient) DecoderawtxContext(ctx context.Context, TRANSACTION string) (*Transaction, error) {
 	cmd := "decoderawtx"
 	positional := []interface{}{TRANSACTION}
 
 	optional := map[string]interface{}{}
 
 	args := append(positional, optional)
-	return r.doPost(r.Url, cmd, args)
+	var result *Transaction
+	err := r.call(ctx, cmd, args, "transaction", &result)
+	return result, err
 }
 
 /*
@@ -1283,10 +2354,15 @@ func (r *RPCClient) Decoderawtx(TRANSACTION string) (*JSONRpcResp, error) {
    eg:
        TYPE=1;LQ=9000;LP=60000;UN=3
        TYPE=2;LQ=9000;LP=60000;UN=3;UC=20000,20000,20000;UQ=3000,3000,3000
-   defaults to disable.
+   defaults to disable. AttenuationModel builds and checks it.
    :param: fee(uint64_t): "Transaction fee. defaults to 10000 ETP bits"
 */
-func (r *RPCClient) Sendasset(ACCOUNTNAME string, ACCOUNTAUTH string, ADDRESS string, SYMBOL string, AMOUNT uint64, model string, fee uint64) (*JSONRpcResp, error) {
+func (r *RPCClient) Sendasset(ACCOUNTNAME string, ACCOUNTAUTH string, ADDRESS string, SYMBOL string, AMOUNT Amount, model string, fee Amount) (*Transaction, error) {
+	return r.SendassetContext(context.Background(), ACCOUNTNAME, ACCOUNTAUTH, ADDRESS, SYMBOL, AMOUNT, model, fee)
+}
+
+// SendassetContext is like Sendasset but honours the deadline and cancellation of ctx.
+func (r *RPCClient) SendassetContext(ctx context.Context, ACCOUNTNAME string, ACCOUNTAUTH string, ADDRESS string, SYMBOL string, AMOUNT Amount, model string, fee Amount) (*Transaction, error) {
 	cmd := "sendasset"
 	positional := []interface{}{ACCOUNTNAME, ACCOUNTAUTH, ADDRESS, SYMBOL, AMOUNT}
 
@@ -1299,7 +2375,26 @@ func (r *RPCClient) Sendasset(ACCOUNTNAME string, ACCOUNTAUTH string, ADDRESS st
 		optional["fee"] = fee
 	}
 	args := append(positional, optional)
-	return r.doPost(r.Url, cmd, args)
+	var result *Transaction
+	err := r.call(ctx, cmd, args, "transaction", &result)
+	return result, err
+}
+
+// SendassetOptions holds the parameters of Sendasset that may be left out.
+type SendassetOptions struct {
+	// The token offering model by block height.
+	Model string
+	// Transaction fee. defaults to 10000 ETP bits
+	Fee Amount
+}
+
+// SendassetWithOptions is SendassetContext with the parameters that may be left out
+// in opts, which may be nil.
+func (r *RPCClient) SendassetWithOptions(ctx context.Context, ACCOUNTNAME string, ACCOUNTAUTH string, ADDRESS string, SYMBOL string, AMOUNT Amount, opts *SendassetOptions) (*Transaction, error) {
+	if opts == nil {
+		opts = &SendassetOptions{}
+	}
+	return r.SendassetContext(ctx, ACCOUNTNAME, ACCOUNTAUTH, ADDRESS, SYMBOL, AMOUNT, opts.Model, opts.Fee)
 }
 
 /*
@@ -1307,55 +2402,83 @@ func (r *RPCClient) Sendasset(ACCOUNTNAME string, ACCOUNTAUTH string, ADDRESS st
    :param: HEADERHASH(std::string): "header hash. with leading 0x"
    :param: MIXHASH(std::string): "mix hash. with leading 0x"
 */
-func (r *RPCClient) Submitwork(NONCE string, HEADERHASH string, MIXHASH string) (*JSONRpcResp, error) {
+func (r *RPCClient) Submitwork(NONCE string, HEADERHASH string, MIXHASH string) (bool, error) {
+	return r.SubmitworkContext(context.Background(), NONCE, HEADERHASH, MIXHASH)
+}
+
+// SubmitworkContext is like Submitwork but honours the deadline and cancellation of ctx.
+func (r *RPCClient) SubmitworkContext(ctx context.Context, NONCE string, HEADERHASH string, MIXHASH string) (bool, error) {
 	cmd := "submitwork"
 	positional := []interface{}{NONCE, HEADERHASH, MIXHASH}
 
 	optional := map[string]interface{}{}
 
 	args := append(positional, optional)
-	return r.doPost(r.Url, cmd, args)
+	var result bool
+	err := r.call(ctx, cmd, args, "", &result)
+	return result, err
 }
 
 /*
    :param: PAYMENT_ADDRESS(string of Base58-encoded public key address): "The payment address. If not specified the address is read from STDIN."
 */
-func (r *RPCClient) Getaddressetp(PAYMENT_ADDRESS string) (*JSONRpcResp, error) {
+func (r *RPCClient) Getaddressetp(PAYMENT_ADDRESS string) (*AddressBalance, error) {
+	return r.GetaddressetpContext(context.Background(), PAYMENT_ADDRESS)
+}
+
+// GetaddressetpContext is like Getaddressetp but honours the deadline and cancellation of ctx.
+func (r *RPCClient) GetaddressetpContext(ctx context.Context, PAYMENT_ADDRESS string) (*AddressBalance, error) {
 	cmd := "getaddressetp"
 	positional := []interface{}{PAYMENT_ADDRESS}
 
 	optional := map[string]interface{}{}
 
 	args := append(positional, optional)
-	return r.doPost(r.Url, cmd, args)
+	var result *AddressBalance
+	err := r.call(ctx, cmd, args, "balance", &result)
+	return result, err
 }
 
 /*
    :param: json(bool): "Json/Raw format, default is '--json=true'."
    :param: HASH(string of hash256): "The Base16 transaction hash of the transaction to get. If not specified the transaction hash is read from STDIN."
 */
-func (r *RPCClient) Gettx(json bool, HASH string) (*JSONRpcResp, error) {
+func (r *RPCClient) Gettx(json bool, HASH string) (*Transaction, error) {
+	return r.GettxContext(context.Background(), json, HASH)
+}
+
+// GettxContext is like Gettx but honours the deadline and cancellation of ctx.
+func (r *RPCClient) GettxContext(ctx context.Context, json bool, HASH string) (*Transaction, error) {
 	cmd := "gettx"
 	positional := []interface{}{json, HASH}
 
 	optional := map[string]interface{}{}
 
 	args := append(positional, optional)
-	return r.doPost(r.Url, cmd, args)
+	var result *Transaction
+	err := r.call(ctx, cmd, args, "transaction", &result)
+	return result, err
 }
 
 /*
    :param: ADMINNAME(std::string): Administrator required.(when administrator_required in mvs.conf is set true)
    :param: ADMINAUTH(std::string): Administrator password required.
 */
-func (r *RPCClient) Getmininginfo(ADMINNAME string, ADMINAUTH string) (*JSONRpcResp, error) {
+func (r *RPCClient) Getmininginfo(ADMINNAME string, ADMINAUTH string) (*MiningInfo, error) {
+	return r.GetmininginfoContext(context.Background(), ADMINNAME, ADMINAUTH)
+}
+
+// GetmininginfoContext is like Getmininginfo but honours the deadline and cancellation of ctx.
+func (r *RPCClient) GetmininginfoContext(ctx context.Context, ADMINNAME string, ADMINAUTH string) (*MiningInfo, error) {
 	cmd := "getmininginfo"
 	positional := []interface{}{ADMINNAME, ADMINAUTH}
 
 	optional := map[string]interface{}{}
 
 	args := append(positional, optional)
-	return r.doPost(r.Url, cmd, args)
+	var result *MiningInfo
+	err := r.call(ctx, cmd, args, "", &result)
+	return result, err
 }
 
 /*
@@ -1367,7 +2490,12 @@ func (r *RPCClient) Getmininginfo(ADMINNAME string, ADMINAUTH string) (*JSONRpcR
    :param: mits(list of string): "List of symbol and content pair. Symbol and content are separated by a ':'"
    :param: fee(uint64_t): "Transaction fee. defaults to 10000 ETP bits"
 */
-func (r *RPCClient) Registermit(ACCOUNTNAME string, ACCOUNTAUTH string, TODID string, SYMBOL string, content string, mits []string, fee uint64) (*JSONRpcResp, error) {
+func (r *RPCClient) Registermit(ACCOUNTNAME string, ACCOUNTAUTH string, TODID string, SYMBOL string, content string, mits []string, fee Amount) (*Transaction, error) {
+	return r.RegistermitContext(context.Background(), ACCOUNTNAME, ACCOUNTAUTH, TODID, SYMBOL, content, mits, fee)
+}
+
+// RegistermitContext is like Registermit but honours the deadline and cancellation of ctx.
+func (r *RPCClient) RegistermitContext(ctx context.Context, ACCOUNTNAME string, ACCOUNTAUTH string, TODID string, SYMBOL string, content string, mits []string, fee Amount) (*Transaction, error) {
 	cmd := "registermit"
 	positional := []interface{}{ACCOUNTNAME, ACCOUNTAUTH, TODID}
 	if SYMBOL != "" {
@@ -1385,7 +2513,30 @@ func (r *RPCClient) Registermit(ACCOUNTNAME string, ACCOUNTAUTH string, TODID st
 		optional["fee"] = fee
 	}
 	args := append(positional, optional)
-	return r.doPost(r.Url, cmd, args)
+	var result *Transaction
+	err := r.call(ctx, cmd, args, "transaction", &result)
+	return result, err
+}
+
+// RegistermitOptions holds the parameters of Registermit that may be left out.
+type RegistermitOptions struct {
+	// MIT symbol
+	Symbol string
+	// Content of MIT
+	Content string
+	// List of symbol and content pair. Symbol and content are separated by a ':'
+	Mits []string
+	// Transaction fee. defaults to 10000 ETP bits
+	Fee Amount
+}
+
+// RegistermitWithOptions is RegistermitContext with the parameters that may be left out
+// in opts, which may be nil.
+func (r *RPCClient) RegistermitWithOptions(ctx context.Context, ACCOUNTNAME string, ACCOUNTAUTH string, TODID string, opts *RegistermitOptions) (*Transaction, error) {
+	if opts == nil {
+		opts = &RegistermitOptions{}
+	}
+	return r.RegistermitContext(ctx, ACCOUNTNAME, ACCOUNTAUTH, TODID, opts.Symbol, opts.Content, opts.Mits, opts.Fee)
 }
 
 /*
@@ -1393,28 +2544,42 @@ func (r *RPCClient) Registermit(ACCOUNTNAME string, ACCOUNTAUTH string, TODID st
    :param: ACCOUNTAUTH(std::string): Account password(authorization) required.
    :param: PAYMENT_ADDRESS(string of Base58-encoded public key address): "the payment address of this account."
 */
-func (r *RPCClient) Setminingaccount(ACCOUNTNAME string, ACCOUNTAUTH string, PAYMENT_ADDRESS string) (*JSONRpcResp, error) {
+func (r *RPCClient) Setminingaccount(ACCOUNTNAME string, ACCOUNTAUTH string, PAYMENT_ADDRESS string) (string, error) {
+	return r.SetminingaccountContext(context.Background(), ACCOUNTNAME, ACCOUNTAUTH, PAYMENT_ADDRESS)
+}
+
+// SetminingaccountContext is like Setminingaccount but honours the deadline and cancellation of ctx.
+func (r *RPCClient) SetminingaccountContext(ctx context.Context, ACCOUNTNAME string, ACCOUNTAUTH string, PAYMENT_ADDRESS string) (string, error) {
 	cmd := "setminingaccount"
 	positional := []interface{}{ACCOUNTNAME, ACCOUNTAUTH, PAYMENT_ADDRESS}
 
 	optional := map[string]interface{}{}
 
 	args := append(positional, optional)
-	return r.doPost(r.Url, cmd, args)
+	var result string
+	err := r.call(ctx, cmd, args, "", &result)
+	return result, err
 }
 
 /*
    :param: ACCOUNTNAME(std::string): Account name required.
    :param: ACCOUNTAUTH(std::string): Account password(authorization) required.
 */
-func (r *RPCClient) Listaddresses(ACCOUNTNAME string, ACCOUNTAUTH string) (*JSONRpcResp, error) {
+func (r *RPCClient) Listaddresses(ACCOUNTNAME string, ACCOUNTAUTH string) ([]string, error) {
+	return r.ListaddressesContext(context.Background(), ACCOUNTNAME, ACCOUNTAUTH)
+}
+
+// ListaddressesContext is like Listaddresses but honours the deadline and cancellation of ctx.
+func (r *RPCClient) ListaddressesContext(ctx context.Context, ACCOUNTNAME string, ACCOUNTAUTH string) ([]string, error) {
 	cmd := "listaddresses"
 	positional := []interface{}{ACCOUNTNAME, ACCOUNTAUTH}
 
 	optional := map[string]interface{}{}
 
 	args := append(positional, optional)
-	return r.doPost(r.Url, cmd, args)
+	var result []string
+	err := r.call(ctx, cmd, args, "addresses", &result)
+	return result, err
 }
 
 /*
@@ -1424,7 +2589,12 @@ func (r *RPCClient) Listaddresses(ACCOUNTNAME string, ACCOUNTAUTH string) (*JSON
    :param: DESTINATION(string of file path): "The keyfile storage path to."
    :param: data(bool): "If specified, the keyfile content will be append to the report, rather than to local file specified by DESTINATION."
 */
-func (r *RPCClient) Dumpkeyfile(ACCOUNTNAME string, ACCOUNTAUTH string, LASTWORD string, DESTINATION string, data bool) (*JSONRpcResp, error) {
+func (r *RPCClient) Dumpkeyfile(ACCOUNTNAME string, ACCOUNTAUTH string, LASTWORD string, DESTINATION string, data bool) (*KeyFile, error) {
+	return r.DumpkeyfileContext(context.Background(), ACCOUNTNAME, ACCOUNTAUTH, LASTWORD, DESTINATION, data)
+}
+
+// DumpkeyfileContext is like Dumpkeyfile but honours the deadline and cancellation of ctx.
+func (r *RPCClient) DumpkeyfileContext(ctx context.Context, ACCOUNTNAME string, ACCOUNTAUTH string, LASTWORD string, DESTINATION string, data bool) (*KeyFile, error) {
 	cmd := "dumpkeyfile"
 	positional := []interface{}{ACCOUNTNAME, ACCOUNTAUTH, LASTWORD}
 	if DESTINATION != "" {
@@ -1436,21 +2606,47 @@ func (r *RPCClient) Dumpkeyfile(ACCOUNTNAME string, ACCOUNTAUTH string, LASTWORD
 	}
 
 	args := append(positional, optional)
-	return r.doPost(r.Url, cmd, args)
+	var result *KeyFile
+	err := r.call(ctx, cmd, args, "", &result)
+	return result, err
+}
+
+// DumpkeyfileOptions holds the parameters of Dumpkeyfile that may be left out.
+type DumpkeyfileOptions struct {
+	// The keyfile storage path to.
+	Destination string
+	// If specified, the keyfile content will be append to the report, rather than to local file specified by DESTINATION.
+	Data bool
+}
+
+// DumpkeyfileWithOptions is DumpkeyfileContext with the parameters that may be left out
+// in opts, which may be nil.
+func (r *RPCClient) DumpkeyfileWithOptions(ctx context.Context, ACCOUNTNAME string, ACCOUNTAUTH string, LASTWORD string, opts *DumpkeyfileOptions) (*KeyFile, error) {
+	if opts == nil {
+		opts = &DumpkeyfileOptions{}
+	}
+	return r.DumpkeyfileContext(ctx, ACCOUNTNAME, ACCOUNTAUTH, LASTWORD, opts.Destination, opts.Data)
 }
 
 /*
    :param: ADMINNAME(std::string): Administrator required.(when administrator_required in mvs.conf is set true)
    :param: ADMINAUTH(std::string): Administrator password required.
 */
-func (r *RPCClient) Getpeerinfo(ADMINNAME string, ADMINAUTH string) (*JSONRpcResp, error) {
+func (r *RPCClient) Getpeerinfo(ADMINNAME string, ADMINAUTH string) ([]string, error) {
+	return r.GetpeerinfoContext(context.Background(), ADMINNAME, ADMINAUTH)
+}
+
+// GetpeerinfoContext is like Getpeerinfo but honours the deadline and cancellation of ctx.
+func (r *RPCClient) GetpeerinfoContext(ctx context.Context, ADMINNAME string, ADMINAUTH string) ([]string, error) {
 	cmd := "getpeerinfo"
 	positional := []interface{}{ADMINNAME, ADMINAUTH}
 
 	optional := map[string]interface{}{}
 
 	args := append(positional, optional)
-	return r.doPost(r.Url, cmd, args)
+	var result []string
+	err := r.call(ctx, cmd, args, "peers", &result)
+	return result, err
 }
 
 /*
@@ -1462,7 +2658,12 @@ func (r *RPCClient) Getpeerinfo(ADMINNAME string, ADMINAUTH string) (*JSONRpcRes
    :param: memo(std::string): "The memo to descript transaction"
    :param: fee(uint64_t): "Transaction fee. defaults to 10000 ETP bits"
 */
-func (r *RPCClient) Didsendfrom(ACCOUNTNAME string, ACCOUNTAUTH string, FROM_ string, TO_ string, AMOUNT uint64, memo string, fee uint64) (*JSONRpcResp, error) {
+func (r *RPCClient) Didsendfrom(ACCOUNTNAME string, ACCOUNTAUTH string, FROM_ string, TO_ string, AMOUNT Amount, memo string, fee Amount) (*Transaction, error) {
+	return r.DidsendfromContext(context.Background(), ACCOUNTNAME, ACCOUNTAUTH, FROM_, TO_, AMOUNT, memo, fee)
+}
+
+// DidsendfromContext is like Didsendfrom but honours the deadline and cancellation of ctx.
+func (r *RPCClient) DidsendfromContext(ctx context.Context, ACCOUNTNAME string, ACCOUNTAUTH string, FROM_ string, TO_ string, AMOUNT Amount, memo string, fee Amount) (*Transaction, error) {
 	cmd := "didsendfrom"
 	positional := []interface{}{ACCOUNTNAME, ACCOUNTAUTH, FROM_, TO_, AMOUNT}
 
@@ -1475,7 +2676,26 @@ func (r *RPCClient) Didsendfrom(ACCOUNTNAME string, ACCOUNTAUTH string, FROM_ st
 		optional["fee"] = fee
 	}
 	args := append(positional, optional)
-	return r.doPost(r.Url, cmd, args)
+	var result *Transaction
+	err := r.call(ctx, cmd, args, "transaction", &result)
+	return result, err
+}
+
+// DidsendfromOptions holds the parameters of Didsendfrom that may be left out.
+type DidsendfromOptions struct {
+	// The memo to descript transaction
+	Memo string
+	// Transaction fee. defaults to 10000 ETP bits
+	Fee Amount
+}
+
+// DidsendfromWithOptions is DidsendfromContext with the parameters that may be left out
+// in opts, which may be nil.
+func (r *RPCClient) DidsendfromWithOptions(ctx context.Context, ACCOUNTNAME string, ACCOUNTAUTH string, FROM_ string, TO_ string, AMOUNT Amount, opts *DidsendfromOptions) (*Transaction, error) {
+	if opts == nil {
+		opts = &DidsendfromOptions{}
+	}
+	return r.DidsendfromContext(ctx, ACCOUNTNAME, ACCOUNTAUTH, FROM_, TO_, AMOUNT, opts.Memo, opts.Fee)
 }
 
 /*
@@ -1483,14 +2703,21 @@ func (r *RPCClient) Didsendfrom(ACCOUNTNAME string, ACCOUNTAUTH string, FROM_ st
    :param: ACCOUNTAUTH(std::string): Account password(authorization) required.
    :param: LASTWORD(std::string): "The last word of your backup words."
 */
-func (r *RPCClient) Getaccount(ACCOUNTNAME string, ACCOUNTAUTH string, LASTWORD string) (*JSONRpcResp, error) {
+func (r *RPCClient) Getaccount(ACCOUNTNAME string, ACCOUNTAUTH string, LASTWORD string) (*Account, error) {
+	return r.GetaccountContext(context.Background(), ACCOUNTNAME, ACCOUNTAUTH, LASTWORD)
+}
+
+// GetaccountContext is like Getaccount but honours the deadline and cancellation of ctx.
+func (r *RPCClient) GetaccountContext(ctx context.Context, ACCOUNTNAME string, ACCOUNTAUTH string, LASTWORD string) (*Account, error) {
 	cmd := "getaccount"
 	positional := []interface{}{ACCOUNTNAME, ACCOUNTAUTH, LASTWORD}
 
 	optional := map[string]interface{}{}
 
 	args := append(positional, optional)
-	return r.doPost(r.Url, cmd, args)
+	var result *Account
+	err := r.call(ctx, cmd, args, "", &result)
+	return result, err
 }
 
 /*
@@ -1499,7 +2726,12 @@ func (r *RPCClient) Getaccount(ACCOUNTNAME string, ACCOUNTAUTH string, LASTWORD
    :param: ADMINAUTH(std::string): "admin password/authorization."
    :param: operation(std::string): "The operation[ add|ban ] to the target node address. default: add."
 */
-func (r *RPCClient) Addnode(NODEADDRESS string, ADMINNAME string, ADMINAUTH string, operation string) (*JSONRpcResp, error) {
+func (r *RPCClient) Addnode(NODEADDRESS string, ADMINNAME string, ADMINAUTH string, operation string) (string, error) {
+	return r.AddnodeContext(context.Background(), NODEADDRESS, ADMINNAME, ADMINAUTH, operation)
+}
+
+// AddnodeContext is like Addnode but honours the deadline and cancellation of ctx.
+func (r *RPCClient) AddnodeContext(ctx context.Context, NODEADDRESS string, ADMINNAME string, ADMINAUTH string, operation string) (string, error) {
 	cmd := "addnode"
 	positional := []interface{}{NODEADDRESS, ADMINNAME, ADMINAUTH}
 
@@ -1509,7 +2741,24 @@ func (r *RPCClient) Addnode(NODEADDRESS string, ADMINNAME string, ADMINAUTH stri
 		optional["operation"] = operation
 	}
 	args := append(positional, optional)
-	return r.doPost(r.Url, cmd, args)
+	var result string
+	err := r.call(ctx, cmd, args, "", &result)
+	return result, err
+}
+
+// AddnodeOptions holds the parameters of Addnode that may be left out.
+type AddnodeOptions struct {
+	// The operation[ add|ban ] to the target node address. default: add.
+	Operation string
+}
+
+// AddnodeWithOptions is AddnodeContext with the parameters that may be left out
+// in opts, which may be nil.
+func (r *RPCClient) AddnodeWithOptions(ctx context.Context, NODEADDRESS string, ADMINNAME string, ADMINAUTH string, opts *AddnodeOptions) (string, error) {
+	if opts == nil {
+		opts = &AddnodeOptions{}
+	}
+	return r.AddnodeContext(ctx, NODEADDRESS, ADMINNAME, ADMINAUTH, opts.Operation)
 }
 
 /*
@@ -1517,12 +2766,19 @@ func (r *RPCClient) Addnode(NODEADDRESS string, ADMINNAME string, ADMINAUTH stri
    :param: json(bool): "Json/Raw format, default is '--json=true'."
    :param: tx_json(bool): "Json/Raw format for txs, default is '--tx_json=true'."
 */
-func (r *RPCClient) Getblock(HASH_OR_HEIGH string, json bool, tx_json bool) (*JSONRpcResp, error) {
+func (r *RPCClient) Getblock(HASH_OR_HEIGH string, json bool, tx_json bool) (*Block, error) {
+	return r.GetblockContext(context.Background(), HASH_OR_HEIGH, json, tx_json)
+}
+
+// GetblockContext is like Getblock but honours the deadline and cancellation of ctx.
+func (r *RPCClient) GetblockContext(ctx context.Context, HASH_OR_HEIGH string, json bool, tx_json bool) (*Block, error) {
 	cmd := "getblock"
 	positional := []interface{}{HASH_OR_HEIGH, json, tx_json}
 
 	optional := map[string]interface{}{}
 
 	args := append(positional, optional)
-	return r.doPost(r.Url, cmd, args)
+	var result *Block
+	err := r.call(ctx, cmd, args, "", &result)
+	return result, err
 }
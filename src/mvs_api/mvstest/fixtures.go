@@ -0,0 +1,214 @@
+package mvstest
+
+// Sample values used by the fixtures.
+const (
+	Account  = "Alice"
+	Address  = "MLasJFxZQnA49XEvhTHmRKi2qstkj9ppjo"
+	Multisig = "359mjCL3V8PaxLUzU9mJSNtLSEXHFJmzfA"
+	TxHash   = "2a845dfa63a7c20d40dbc4b15c3e970ef36332b367500fd89307053cb4c1a2c1"
+	Height   = 1270000
+)
+
+const (
+	pubKey = "0380990a7312b87abda80e5857ee6ebf798a2bf62041b07111287d19926c429d11"
+
+	transactionJSON = `{
+		"hash": "` + TxHash + `",
+		"height": 1270000,
+		"timestamp": 1540000000,
+		"direction": "send",
+		"memo": "",
+		"version": 4,
+		"lock_time": 0,
+		"inputs": [{
+			"address": "` + Address + `",
+			"previous_output": {"hash": "b8e7ea3c9ffb4fa4b5d6f7c0e3c6a1d0c5f0b2c7c2aab8f6f1e5d9e0a2b3c4d5", "index": 0},
+			"script": "[ 3045022100c3a8 ] [ ` + pubKey + ` ]",
+			"sequence": 4294967295
+		}],
+		"outputs": [{
+			"index": 0,
+			"address": "MAwLwVGwJyFsTBfNj2j5nCUrQXGVRvHzPh",
+			"script": "dup hash160 [ 1f9d8d1b2bd8e6c4ee2e6d48a9c8bfbcdd0a6e2b ] equalverify checksig",
+			"value": 100000000,
+			"locked_height_range": 0,
+			"attachment": {"type": "etp"}
+		}, {
+			"index": 1,
+			"address": "` + Address + `",
+			"script": "dup hash160 [ 7c3dbea6a4e4cba3de7e01b6e4dbe2e4a4bd2c33 ] equalverify checksig",
+			"value": 899990000,
+			"locked_height_range": 0,
+			"attachment": {"type": "etp"}
+		}]
+	}`
+
+	headerFields = `
+		"hash": "aa6db2a6b1a2e8e2bf2e3d0a0c7ab1b23ad1f8a5ee0e4d0f6a9f9d5f8b6a1c2d",
+		"bits": "5126138697",
+		"merkle_tree_hash": "9cf6d7d6fb1ba5d0c6e5c4d7a0e7e1b0aa64f8c2f7b0ad64a4a7a4b2a4f9b5f3",
+		"mixhash": "4fbb9fb6b7fcf3bb0fbd3f9c0d2fb1fd9a7bd8b8d9fa4db6e5b3f1f7c2b9e3c0",
+		"nonce": "4865481893298393163",
+		"number": 1270000,
+		"previous_block_hash": "5bd6f7d0a64aba9e1a8e7f7dd2bd2d3bc0f0e1d7b3ff8b5a42c7a1e6b8d5d9f0",
+		"time_stamp": 1540000000,
+		"transaction_count": 1,
+		"version": 1`
+
+	headerJSON = `{` + headerFields + `
+	}`
+
+	blockJSON = `{` + headerFields + `,
+		"transactions": [` + transactionJSON + `]
+	}`
+
+	accountJSON = `{
+		"name": "` + Account + `",
+		"mnemonic": "notice judge certain company novel quality plunge list blind library ride uncover fold wink biology original aim whale stand coach hire clinic fame robot",
+		"hd_index": 1,
+		"default-address": "` + Address + `",
+		"addresses": ["` + Address + `"]
+	}`
+
+	assetBalanceJSON = `{
+		"symbol": "MVS.ZGC",
+		"address": "` + Address + `",
+		"issuer": "` + Account + `",
+		"quantity": 1000000,
+		"locked_quantity": 0,
+		"decimal_number": 4,
+		"status": "unspent"
+	}`
+
+	assetJSON = `{
+		"symbol": "MVS.ZGC",
+		"address": "` + Address + `",
+		"issuer": "` + Account + `",
+		"description": "MVS test asset",
+		"decimal_number": 4,
+		"maximum_supply": 100000000,
+		"secondaryissue_threshold": 0,
+		"is_secondaryissue": false,
+		"status": "issued"
+	}`
+
+	addressBalanceJSON = `{
+		"address": "` + Address + `",
+		"confirmed": 900000000,
+		"received": 1000000000,
+		"unspent": 900000000,
+		"available": 900000000,
+		"frozen": 0
+	}`
+
+	didJSON = `{
+		"symbol": "BIAM",
+		"address": "` + Address + `",
+		"status": "registered",
+		"addresses": [{"address": "` + Address + `", "status": "current", "height": 1200000, "timestamp": 1530000000}]
+	}`
+
+	mitJSON = `{
+		"symbol": "MIT.TEST",
+		"content": "test content",
+		"address": "` + Address + `",
+		"status": "registered",
+		"height": 1200000,
+		"time_stamp": 1530000000
+	}`
+
+	multisigJSON = `{
+		"address": "` + Multisig + `",
+		"description": "test mvs api",
+		"index": 1,
+		"m": 2,
+		"n": 3,
+		"public-keys": [
+			"02578ad340083e85c739f379bbe6c6937c5da2ced52e09ac1eec43dc4c64846573",
+			"` + pubKey + `",
+			"03af3a99f1c3279dbe1c22fd767fb98b5dbd138f6e0511c2fc11128e44c0373cad"
+		],
+		"self-publickey": "` + pubKey + `",
+		"multisig-script": "2 [ 02578ad340083e85c739f379bbe6c6937c5da2ced52e09ac1eec43dc4c64846573 ] [ ` + pubKey + ` ] [ 03af3a99f1c3279dbe1c22fd767fb98b5dbd138f6e0511c2fc11128e44c0373cad ] 3 checkmultisig"
+	}`
+
+	rawtx = "0400000001c4b3a2e0d9e5f1f6b8aac2c7c2b2f0c5d0a1c6e3c0f7d6b5a44ffb9f3ceae7b80000000000ffffffff0100e1f505000000001976a9141f9d8d1b2bd8e6c4ee2e6d48a9c8bfbcdd0a6e2b88ac010000000000000000e1f5050000000000000000"
+
+	sentTx = `{"transaction": ` + transactionJSON + `}`
+)
+
+// Fixtures holds the result every method answers with by default, in the
+// shape mvsd sends it.
+var Fixtures = map[string]string{
+	"addnode":          `"success"`,
+	"burn":             sentTx,
+	"changepasswd":     `{"name": "` + Account + `", "status": "changed successfully"}`,
+	"createasset":      `{"asset": ` + assetJSON + `}`,
+	"createmultisigtx": `"` + rawtx + `"`,
+	"createrawtx":      `"` + rawtx + `"`,
+	"decoderawtx":      sentTx,
+	"deleteaccount":    `{"name": "` + Account + `", "status": "removed successfully"}`,
+	"deletelocalasset": `{"symbol": "MVS.ZGC", "operation": "delete", "result": "success", "status": "success"}`,
+	"deletemultisig":   multisigJSON,
+	"deposit":          sentTx,
+	"didchangeaddress": sentTx,
+	"didsend":          sentTx,
+	"didsendasset":     sentTx,
+	"didsendassetfrom": sentTx,
+	"didsendfrom":      sentTx,
+	"didsendmore":      sentTx,
+	"dumpkeyfile":      `"/home/alice/.metaverse/keys/mvs_keystore_Alice.json"`,
+	"fetchheaderext":   headerJSON,
+	"getaccount":       accountJSON,
+	"getaccountasset":  `{"assets": [` + assetBalanceJSON + `]}`,
+	"getaddressasset":  `{"assets": [` + assetBalanceJSON + `]}`,
+	"getaddressetp":    `{"balance": ` + addressBalanceJSON + `}`,
+	"getasset":         `{"assets": [` + assetJSON + `]}`,
+	"getbalance":       `{"total_confirmed": 900000000, "total_received": 1000000000, "total_unspent": 900000000, "total_available": 900000000, "total_frozen": 0}`,
+	"getblock":         blockJSON,
+	"getblockheader":   headerJSON,
+	"getdid":           didJSON,
+	"getheight":        `1270000`,
+	"getinfo":          `{"protocol-version": 70012, "wallet-version": "0.8.4", "database-version": "0.8.4", "testnet": false, "peers": 8, "network-assets-count": 120, "wallet-account-count": 1, "height": 1270000, "difficulty": "6170580513", "is-mining": false, "hash-rate": 0}`,
+	"getmemorypool":    `{"transactions": [` + transactionJSON + `]}`,
+	"getmininginfo":    `{"is-mining": false, "height": 1270000, "rate": 0, "difficulty": "6170580513"}`,
+	"getmit":           `{"mits": [` + mitJSON + `]}`,
+	"getnewaccount":    accountJSON,
+	"getnewaddress":    `{"addresses": ["` + Address + `"]}`,
+	"getnewmultisig":   multisigJSON,
+	"getpeerinfo":      `{"peers": ["10.0.0.1:5251", "10.0.0.2:5251"]}`,
+	"getpublickey":     `{"address": "` + Address + `", "public-key": "` + pubKey + `"}`,
+	"gettx":            sentTx,
+	"getwork":          `["0x2a845dfa63a7c20d40dbc4b15c3e970ef36332b367500fd89307053cb4c1a2c1", "0x0000000000000000000000000000000000000000000000000000000000000000", "0x00000000002d5a4b1c7e84b9a0a5e0d8e3e0f1b6f2c7a9e6b3d8c1f0a2e4b6d8"]`,
+	"importaccount":    accountJSON,
+	"importkeyfile":    `"` + Account + `"`,
+	"issue":            sentTx,
+	"issuecert":        sentTx,
+	"listaddresses":    `{"addresses": ["` + Address + `"]}`,
+	"listassets":       `{"assets": [` + assetBalanceJSON + `]}`,
+	"listbalances":     `{"balances": [{"balance": ` + addressBalanceJSON + `}]}`,
+	"listdids":         `{"dids": [` + didJSON + `]}`,
+	"listmits":         `{"mits": [` + mitJSON + `]}`,
+	"listmultisig":     `{"multisig": [` + multisigJSON + `]}`,
+	"listtxs":          `{"current_page": 1, "total_page": 1, "transactions": [` + transactionJSON + `]}`,
+	"popblock":         `"pop block from 1270000 finished."`,
+	"registerdid":      sentTx,
+	"registermit":      sentTx,
+	"secondaryissue":   sentTx,
+	"send":             sentTx,
+	"sendasset":        sentTx,
+	"sendassetfrom":    sentTx,
+	"sendfrom":         sentTx,
+	"sendmore":         sentTx,
+	"sendrawtx":        `{"hash": "` + TxHash + `"}`,
+	"setminingaccount": `"setting address [` + Address + `] successfully."`,
+	"shutdown":         `"sending SIGTERM to mvsd."`,
+	"signmultisigtx":   `"` + rawtx + `"`,
+	"signrawtx":        `{"hash": "` + TxHash + `", "rawtx": "` + rawtx + `"}`,
+	"startmining":      `"solo mining started at ` + Address + `"`,
+	"stopmining":       `"signal STOP sent."`,
+	"submitwork":       `true`,
+	"transfercert":     sentTx,
+	"transfermit":      sentTx,
+	"validateaddress":  `{"address": "` + Address + `", "address-type": "p2pkh", "is-valid": true, "testnet": false, "message": "valid address "}`,
+}
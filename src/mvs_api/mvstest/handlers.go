@@ -0,0 +1,964 @@
+package mvstest
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"mvs_api"
+	"mvs_api/address"
+	"mvs_api/hdwallet"
+)
+
+// handlers returns the methods Node emulates. They run with n.mu held.
+func (n *Node) handlers() map[string]Handler {
+	return map[string]Handler{
+		"burn":             n.burn,
+		"createasset":      n.createasset,
+		"didsend":          n.send,
+		"didsendasset":     n.sendasset,
+		"didsendassetfrom": n.sendassetfrom,
+		"didsendfrom":      n.sendfrom,
+		"didsendmore":      n.sendmore,
+		"getaccount":       n.getaccount,
+		"getaccountasset":  n.getaccountasset,
+		"getaddressasset":  n.getaddressasset,
+		"getaddressetp":    n.getaddressetp,
+		"getasset":         n.getasset,
+		"getbalance":       n.getbalance,
+		"getblock":         n.getblock,
+		"getblockheader":   n.getblockheader,
+		"getdid":           n.getdid,
+		"getheight":        n.getheight,
+		"getinfo":          n.getinfo,
+		"getmemorypool":    n.getmemorypool,
+		"getmininginfo":    n.getmininginfo,
+		"getmit":           n.getmit,
+		"getnewaccount":    n.getnewaccount,
+		"getnewaddress":    n.getnewaddress,
+		"gettx":            n.gettx,
+		"importaccount":    n.importaccount,
+		"issue":            n.issue,
+		"issuecert":        n.issuecert,
+		"listaddresses":    n.listaddresses,
+		"listassets":       n.listassets,
+		"listbalances":     n.listbalances,
+		"listdids":         n.listdids,
+		"listmits":         n.listmits,
+		"listtxs":          n.listtxs,
+		"registerdid":      n.registerdid,
+		"registermit":      n.registermit,
+		"send":             n.send,
+		"sendasset":        n.sendasset,
+		"sendassetfrom":    n.sendassetfrom,
+		"sendfrom":         n.sendfrom,
+		"sendmore":         n.sendmore,
+		"setminingaccount": n.setminingaccount,
+		"startmining":      n.startmining,
+		"stopmining":       n.stopmining,
+		"transfercert":     n.transfercert,
+		"transfermit":      n.transfermit,
+		"validateaddress":  n.validateaddress,
+	}
+}
+
+func (c Call) arg(i int) string {
+	if i >= len(c.Positional) {
+		return ""
+	}
+	return text(c.Positional[i])
+}
+
+func (c Call) number(i int) uint64 {
+	v, _ := strconv.ParseUint(c.arg(i), 10, 64)
+	return v
+}
+
+func (c Call) opt(key string) string {
+	v, ok := c.Optional[key]
+	if !ok {
+		return ""
+	}
+	return text(v)
+}
+
+func (c Call) optNumber(key string, def uint64) uint64 {
+	v, err := strconv.ParseUint(c.opt(key), 10, 64)
+	if err != nil {
+		return def
+	}
+	return v
+}
+
+func (c Call) flag(name string) bool {
+	for _, p := range c.Positional {
+		if p == "--"+name {
+			return true
+		}
+	}
+	return false
+}
+
+func text(v interface{}) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	}
+	return fmt.Sprint(v)
+}
+
+// login checks the account name and password in the first two positional
+// params.
+func (n *Node) login(c Call) (*account, error) {
+	a, ok := n.accounts[c.arg(0)]
+	if !ok {
+		return nil, rpcError(1000, "account %s does not exist", c.arg(0))
+	}
+	if a.password != c.arg(1) {
+		return nil, rpcError(1002, "account password authority failed")
+	}
+	return a, nil
+}
+
+func (n *Node) ownAddress(a *account, address string) error {
+	if n.owners[address] != a {
+		return rpcError(4010, "address %s does not belong to account %s", address, a.name)
+	}
+	return nil
+}
+
+func (n *Node) ownDID(a *account, did string) (string, error) {
+	address, ok := n.dids[did]
+	if !ok {
+		return "", rpcError(7006, "did symbol %s does not exist", did)
+	}
+	if err := n.ownAddress(a, address); err != nil {
+		return "", err
+	}
+	return address, nil
+}
+
+func amount(s string) (uint64, error) {
+	v, err := strconv.ParseUint(s, 10, 64)
+	if err != nil || v == 0 {
+		return 0, rpcError(1021, "invalid amount %q", s)
+	}
+	return v, nil
+}
+
+func transaction(r *record, err error) (interface{}, error) {
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{"transaction": r.tx}, nil
+}
+
+func (n *Node) getnewaccount(c Call) (interface{}, error) {
+	name := c.arg(0)
+	if _, ok := n.accounts[name]; ok {
+		return nil, rpcError(1001, "account %s already exist", name)
+	}
+	lang := c.opt("language")
+	if lang == "" {
+		lang = hdwallet.English
+	}
+	a, err := n.newAccount(name, c.arg(1), nil, lang, 1)
+	if err != nil {
+		return nil, err
+	}
+	return n.account(a), nil
+}
+
+func (n *Node) importaccount(c Call) (interface{}, error) {
+	name := c.opt("accountname")
+	if _, ok := n.accounts[name]; ok {
+		return nil, rpcError(1001, "account %s already exist", name)
+	}
+	a, err := n.newAccount(name, c.opt("password"), strings.Fields(c.arg(0)), "", int(c.optNumber("hd_index", 1)))
+	if err != nil {
+		return nil, err
+	}
+	return n.account(a), nil
+}
+
+func (n *Node) account(a *account) *mvs_api.Account {
+	return &mvs_api.Account{
+		Name:           a.name,
+		Mnemonic:       a.mnemonic,
+		HdIndex:        uint32(len(a.addresses)),
+		DefaultAddress: a.addresses[0],
+		Addresses:      a.addresses,
+	}
+}
+
+func (n *Node) getaccount(c Call) (interface{}, error) {
+	a, err := n.login(c)
+	if err != nil {
+		return nil, err
+	}
+	words := strings.Fields(a.mnemonic)
+	if c.arg(2) != words[len(words)-1] {
+		return nil, rpcError(1002, "last word not matching")
+	}
+	return n.account(a), nil
+}
+
+func (n *Node) getnewaddress(c Call) (interface{}, error) {
+	a, err := n.login(c)
+	if err != nil {
+		return nil, err
+	}
+	addresses := []string{}
+	for i := uint64(0); i < c.optNumber("number", 1); i++ {
+		addresses = append(addresses, n.newAddress(a))
+	}
+	return map[string]interface{}{"addresses": addresses}, nil
+}
+
+func (n *Node) listaddresses(c Call) (interface{}, error) {
+	a, err := n.login(c)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{"addresses": a.addresses}, nil
+}
+
+func (n *Node) addressBalance(address string) *mvs_api.AddressBalance {
+	balance := n.balance(address, "")
+	return &mvs_api.AddressBalance{
+		Address:   address,
+		Confirmed: balance,
+		Received:  n.received(address, ""),
+		Unspent:   balance,
+		Available: balance,
+	}
+}
+
+func (n *Node) getbalance(c Call) (interface{}, error) {
+	a, err := n.login(c)
+	if err != nil {
+		return nil, err
+	}
+	var total mvs_api.Balance
+	for _, address := range a.addresses {
+		b := n.addressBalance(address)
+		total.TotalConfirmed += b.Confirmed
+		total.TotalReceived += b.Received
+		total.TotalUnspent += b.Unspent
+		total.TotalAvailable += b.Available
+	}
+	return &total, nil
+}
+
+func (n *Node) getaddressetp(c Call) (interface{}, error) {
+	return map[string]interface{}{"balance": n.addressBalance(c.arg(0))}, nil
+}
+
+func (n *Node) listbalances(c Call) (interface{}, error) {
+	a, err := n.login(c)
+	if err != nil {
+		return nil, err
+	}
+	greater := c.optNumber("greater_equal", 0)
+	lesser := c.optNumber("lesser_equal", 0)
+	balances := []interface{}{}
+	for _, address := range a.addresses {
+		b := n.addressBalance(address)
+		if (c.flag("nozero") && b.Unspent == 0) || b.Unspent < greater || (lesser != 0 && b.Unspent > lesser) {
+			continue
+		}
+		balances = append(balances, map[string]interface{}{"balance": b})
+	}
+	return map[string]interface{}{"balances": balances}, nil
+}
+
+func (n *Node) validateaddress(c Call) (interface{}, error) {
+	v := &mvs_api.AddressValidation{Address: c.arg(0)}
+	a, err := address.Decode(c.arg(0))
+	if err != nil {
+		v.Message = "invalid address!"
+		return v, nil
+	}
+	v.IsValid = true
+	v.Testnet = a.Testnet()
+	v.AddressType = "p2pkh"
+	if a.Kind() == address.ScriptHash {
+		v.AddressType = "p2sh(multi-signature)"
+	}
+	v.Message = "valid address "
+	return v, nil
+}
+
+func (n *Node) send(c Call) (interface{}, error) {
+	a, err := n.login(c)
+	if err != nil {
+		return nil, err
+	}
+	to, err := n.payee(c, c.arg(2))
+	if err != nil {
+		return nil, err
+	}
+	value, err := amount(c.arg(3))
+	if err != nil {
+		return nil, err
+	}
+	return transaction(n.pay(a.addresses, "", value, c.optNumber("fee", DefaultFee), []movement{{address: to, amount: value}}, c.opt("memo")))
+}
+
+func (n *Node) sendfrom(c Call) (interface{}, error) {
+	a, err := n.login(c)
+	if err != nil {
+		return nil, err
+	}
+	from, err := n.payee(c, c.arg(2))
+	if err != nil {
+		return nil, err
+	}
+	if err := n.ownAddress(a, from); err != nil {
+		return nil, err
+	}
+	to, err := n.payee(c, c.arg(3))
+	if err != nil {
+		return nil, err
+	}
+	value, err := amount(c.arg(4))
+	if err != nil {
+		return nil, err
+	}
+	return transaction(n.pay([]string{from}, "", value, c.optNumber("fee", DefaultFee), []movement{{address: to, amount: value}}, c.opt("memo")))
+}
+
+func (n *Node) sendmore(c Call) (interface{}, error) {
+	a, err := n.login(c)
+	if err != nil {
+		return nil, err
+	}
+	receivers, _ := c.Optional["receivers"].([]interface{})
+	if len(receivers) == 0 {
+		return nil, rpcError(1021, "no receivers")
+	}
+	var total uint64
+	var outs []movement
+	for _, receiver := range receivers {
+		i := strings.LastIndex(text(receiver), ":")
+		if i < 0 {
+			return nil, rpcError(1021, "invalid receiver %q", text(receiver))
+		}
+		to, err := n.payee(c, text(receiver)[:i])
+		if err != nil {
+			return nil, err
+		}
+		value, err := amount(text(receiver)[i+1:])
+		if err != nil {
+			return nil, err
+		}
+		if total, err = sum(total, value); err != nil {
+			return nil, err
+		}
+		outs = append(outs, movement{address: to, amount: value})
+	}
+	return transaction(n.pay(a.addresses, "", total, c.optNumber("fee", DefaultFee), outs, ""))
+}
+
+func (n *Node) createasset(c Call) (interface{}, error) {
+	a, err := n.login(c)
+	if err != nil {
+		return nil, err
+	}
+	symbol := strings.ToUpper(c.opt("symbol"))
+	if symbol == "" {
+		return nil, rpcError(1021, "asset symbol is required")
+	}
+	if _, ok := n.assets[symbol]; ok {
+		return nil, rpcError(5011, "asset symbol %s already exist", symbol)
+	}
+	if _, ok := n.created[symbol]; ok {
+		return nil, rpcError(5011, "asset symbol %s already exist", symbol)
+	}
+	volume, err := amount(c.opt("volume"))
+	if err != nil {
+		return nil, err
+	}
+	issuer := c.opt("issuer")
+	if issuer == "" {
+		issuer = a.name
+	}
+	created := &createdAsset{owner: a, asset: mvs_api.Asset{
+		Symbol:        symbol,
+		Issuer:        issuer,
+		Description:   c.opt("description"),
+		DecimalNumber: uint32(c.optNumber("decimalnumber", 0)),
+		MaximumSupply: volume,
+		Status:        "unissued",
+	}}
+	n.created[symbol] = created
+	return map[string]interface{}{"asset": created.asset}, nil
+}
+
+func (n *Node) issue(c Call) (interface{}, error) {
+	a, err := n.login(c)
+	if err != nil {
+		return nil, err
+	}
+	symbol := strings.ToUpper(c.arg(2))
+	created, ok := n.created[symbol]
+	if !ok || created.owner != a {
+		return nil, rpcError(3009, "asset symbol %s does not exist", symbol)
+	}
+	asset := created.asset
+	asset.Address = a.addresses[0]
+	asset.Status = "issued"
+	domain := strings.Split(symbol, ".")[0]
+	outs := []movement{
+		{address: asset.Address, symbol: symbol, amount: asset.MaximumSupply, attachment: mvs_api.Attachment{
+			Type:          "asset-issue",
+			Symbol:        symbol,
+			Quantity:      asset.MaximumSupply,
+			DecimalNumber: asset.DecimalNumber,
+			MaximumSupply: asset.MaximumSupply,
+			Issuer:        asset.Issuer,
+			Address:       asset.Address,
+			Description:   asset.Description,
+		}},
+		{address: asset.Address, attachment: mvs_api.Attachment{Type: "asset-cert", Symbol: symbol, Cert: "issue", Owner: asset.Issuer}},
+	}
+	if _, ok := n.certs[certKey{domain, "domain"}]; !ok {
+		outs = append(outs, movement{address: asset.Address, attachment: mvs_api.Attachment{Type: "asset-cert", Symbol: domain, Cert: "domain", Owner: asset.Issuer}})
+	}
+	r, err := n.pay(a.addresses, "", 0, c.optNumber("fee", DefaultFee), outs, "")
+	if err != nil {
+		return nil, err
+	}
+	delete(n.created, symbol)
+	n.assets[symbol] = &asset
+	n.certs[certKey{symbol, "issue"}] = asset.Address
+	if _, ok := n.certs[certKey{domain, "domain"}]; !ok {
+		n.certs[certKey{domain, "domain"}] = asset.Address
+	}
+	return transaction(r, nil)
+}
+
+func (n *Node) getasset(c Call) (interface{}, error) {
+	symbol := strings.ToUpper(c.arg(0))
+	if c.flag("cert") {
+		return map[string]interface{}{"assets": n.certBalances(nil, symbol)}, nil
+	}
+	assets := []*mvs_api.Asset{}
+	for _, s := range n.sortedAssets() {
+		if symbol == "" || s == symbol {
+			assets = append(assets, n.assets[s])
+		}
+	}
+	if symbol != "" && len(assets) == 0 {
+		return nil, rpcError(3009, "asset symbol %s does not exist", symbol)
+	}
+	return map[string]interface{}{"assets": assets}, nil
+}
+
+func (n *Node) getaccountasset(c Call) (interface{}, error) {
+	a, err := n.login(c)
+	if err != nil {
+		return nil, err
+	}
+	symbol := strings.ToUpper(c.arg(2))
+	if c.flag("cert") {
+		return map[string]interface{}{"assets": n.certBalances(a.addresses, symbol)}, nil
+	}
+	return map[string]interface{}{"assets": n.assetBalances(a.addresses, symbol)}, nil
+}
+
+func (n *Node) listassets(c Call) (interface{}, error) {
+	a, err := n.login(c)
+	if err != nil {
+		return nil, err
+	}
+	if c.flag("cert") {
+		return map[string]interface{}{"assets": n.certBalances(a.addresses, "")}, nil
+	}
+	return map[string]interface{}{"assets": n.assetBalances(a.addresses, "")}, nil
+}
+
+func (n *Node) getaddressasset(c Call) (interface{}, error) {
+	addresses := []string{c.arg(0)}
+	if c.flag("cert") {
+		return map[string]interface{}{"assets": n.certBalances(addresses, "")}, nil
+	}
+	return map[string]interface{}{"assets": n.assetBalances(addresses, "")}, nil
+}
+
+// sendAsset pays quantity of symbol from the from addresses to the address
+// to, which the caller has resolved.
+func (n *Node) sendAsset(c Call, from []string, to, symbol, quantity string) (interface{}, error) {
+	symbol = strings.ToUpper(symbol)
+	if _, ok := n.assets[symbol]; !ok {
+		return nil, rpcError(3009, "asset symbol %s does not exist", symbol)
+	}
+	value, err := amount(quantity)
+	if err != nil {
+		return nil, err
+	}
+	return transaction(n.pay(from, symbol, value, c.optNumber("fee", DefaultFee), []movement{{address: to, symbol: symbol, amount: value}}, ""))
+}
+
+func (n *Node) sendasset(c Call) (interface{}, error) {
+	a, err := n.login(c)
+	if err != nil {
+		return nil, err
+	}
+	to, err := n.payee(c, c.arg(2))
+	if err != nil {
+		return nil, err
+	}
+	return n.sendAsset(c, a.addresses, to, c.arg(3), c.arg(4))
+}
+
+func (n *Node) sendassetfrom(c Call) (interface{}, error) {
+	a, err := n.login(c)
+	if err != nil {
+		return nil, err
+	}
+	from, err := n.payee(c, c.arg(2))
+	if err != nil {
+		return nil, err
+	}
+	if err := n.ownAddress(a, from); err != nil {
+		return nil, err
+	}
+	to, err := n.payee(c, c.arg(3))
+	if err != nil {
+		return nil, err
+	}
+	return n.sendAsset(c, []string{from}, to, c.arg(4), c.arg(5))
+}
+
+func (n *Node) burn(c Call) (interface{}, error) {
+	a, err := n.login(c)
+	if err != nil {
+		return nil, err
+	}
+	return n.sendAsset(c, a.addresses, address.Blackhole, c.arg(2), c.arg(3))
+}
+
+func (n *Node) registerdid(c Call) (interface{}, error) {
+	a, err := n.login(c)
+	if err != nil {
+		return nil, err
+	}
+	address, symbol := c.arg(2), c.arg(3)
+	if err := n.ownAddress(a, address); err != nil {
+		return nil, err
+	}
+	if _, ok := n.dids[symbol]; ok {
+		return nil, rpcError(7001, "did symbol %s already exist", symbol)
+	}
+	if did := n.didOf(address); did != "" {
+		return nil, rpcError(7002, "address %s already has did %s", address, did)
+	}
+	outs := []movement{{address: address, attachment: mvs_api.Attachment{Type: "did-register", Symbol: symbol, Address: address}}}
+	r, err := n.pay(a.addresses, "", 0, c.optNumber("fee", DefaultFee), outs, "")
+	if err != nil {
+		return nil, err
+	}
+	n.dids[symbol] = address
+	return transaction(r, nil)
+}
+
+func (n *Node) getdid(c Call) (interface{}, error) {
+	symbol := c.arg(0)
+	if _, ok := n.dids[symbol]; !ok {
+		symbol = n.didOf(c.arg(0))
+	}
+	if symbol == "" {
+		return nil, rpcError(7006, "did symbol %s does not exist", c.arg(0))
+	}
+	return n.did(symbol), nil
+}
+
+func (n *Node) listdids(c Call) (interface{}, error) {
+	a, err := n.login(c)
+	if err != nil {
+		return nil, err
+	}
+	dids := []*mvs_api.DID{}
+	for _, address := range a.addresses {
+		if symbol := n.didOf(address); symbol != "" {
+			dids = append(dids, n.did(symbol))
+		}
+	}
+	return map[string]interface{}{"dids": dids}, nil
+}
+
+func (n *Node) transfercert(c Call) (interface{}, error) {
+	a, err := n.login(c)
+	if err != nil {
+		return nil, err
+	}
+	to, err := n.didAddress(c.arg(2))
+	if err != nil {
+		return nil, err
+	}
+	key := certKey{strings.ToUpper(c.arg(3)), c.arg(4)}
+	owner, ok := n.certs[key]
+	if !ok {
+		return nil, rpcError(5020, "no %s cert for %s", key.cert, key.symbol)
+	}
+	if err := n.ownAddress(a, owner); err != nil {
+		return nil, err
+	}
+	outs := []movement{{address: to, attachment: mvs_api.Attachment{Type: "asset-cert", Symbol: key.symbol, Cert: key.cert, Owner: c.arg(2)}}}
+	r, err := n.pay(a.addresses, "", 0, c.optNumber("fee", DefaultFee), outs, "")
+	if err != nil {
+		return nil, err
+	}
+	n.certs[key] = to
+	return transaction(r, nil)
+}
+
+func (n *Node) issuecert(c Call) (interface{}, error) {
+	a, err := n.login(c)
+	if err != nil {
+		return nil, err
+	}
+	to, err := n.didAddress(c.arg(2))
+	if err != nil {
+		return nil, err
+	}
+	symbol, cert := strings.ToUpper(c.arg(3)), c.arg(4)
+	if cert != "naming" {
+		return nil, rpcError(5021, "cannot issue %s cert", cert)
+	}
+	domain := strings.Split(symbol, ".")[0]
+	owner, ok := n.certs[certKey{domain, "domain"}]
+	if !ok {
+		return nil, rpcError(5020, "no domain cert for %s", domain)
+	}
+	if err := n.ownAddress(a, owner); err != nil {
+		return nil, err
+	}
+	key := certKey{symbol, cert}
+	if _, ok := n.certs[key]; ok {
+		return nil, rpcError(5022, "naming cert %s already exist", symbol)
+	}
+	outs := []movement{{address: to, attachment: mvs_api.Attachment{Type: "asset-cert", Symbol: symbol, Cert: cert, Owner: c.arg(2)}}}
+	r, err := n.pay(a.addresses, "", 0, c.optNumber("fee", DefaultFee), outs, "")
+	if err != nil {
+		return nil, err
+	}
+	n.certs[key] = to
+	return transaction(r, nil)
+}
+
+func (n *Node) registermit(c Call) (interface{}, error) {
+	a, err := n.login(c)
+	if err != nil {
+		return nil, err
+	}
+	did := c.arg(2)
+	address, err := n.ownDID(a, did)
+	if err != nil {
+		return nil, err
+	}
+	contents := map[string]string{}
+	var symbols []string
+	if symbol := c.arg(3); symbol != "" {
+		contents[symbol] = c.opt("content")
+		symbols = append(symbols, symbol)
+	}
+	pairs, _ := c.Optional["mits"].([]interface{})
+	for _, pair := range pairs {
+		parts := strings.SplitN(text(pair), ":", 2)
+		if len(parts) == 2 {
+			contents[parts[0]] = parts[1]
+		} else {
+			contents[parts[0]] = ""
+		}
+		symbols = append(symbols, parts[0])
+	}
+	if len(symbols) == 0 {
+		return nil, rpcError(1021, "mit symbol is required")
+	}
+
+	var outs []movement
+	for _, symbol := range symbols {
+		if _, ok := n.mits[symbol]; ok {
+			return nil, rpcError(7011, "mit symbol %s already exist", symbol)
+		}
+		outs = append(outs, movement{address: address, attachment: mvs_api.Attachment{Type: "mit", Symbol: symbol, Content: contents[symbol], Address: address, Status: "registered", ToDid: did}})
+	}
+	r, err := n.pay(a.addresses, "", 0, c.optNumber("fee", DefaultFee), outs, "")
+	if err != nil {
+		return nil, err
+	}
+	for _, symbol := range symbols {
+		n.mits[symbol] = &mvs_api.MIT{
+			Symbol:    symbol,
+			Content:   contents[symbol],
+			Address:   address,
+			Status:    "registered",
+			Height:    n.height(),
+			TimeStamp: n.tip().header.TimeStamp,
+			ToDid:     did,
+		}
+	}
+	return transaction(r, nil)
+}
+
+func (n *Node) transfermit(c Call) (interface{}, error) {
+	a, err := n.login(c)
+	if err != nil {
+		return nil, err
+	}
+	to, err := n.didAddress(c.arg(2))
+	if err != nil {
+		return nil, err
+	}
+	mit, ok := n.mits[c.arg(3)]
+	if !ok {
+		return nil, rpcError(7006, "mit symbol %s does not exist", c.arg(3))
+	}
+	if err := n.ownAddress(a, mit.Address); err != nil {
+		return nil, err
+	}
+	outs := []movement{{address: to, attachment: mvs_api.Attachment{Type: "mit", Symbol: mit.Symbol, Address: to, Status: "transfered", FromDid: n.didOf(mit.Address), ToDid: c.arg(2)}}}
+	r, err := n.pay(a.addresses, "", 0, c.optNumber("fee", DefaultFee), outs, "")
+	if err != nil {
+		return nil, err
+	}
+	transferred := *mit
+	transferred.Address = to
+	transferred.Status = "transfered"
+	transferred.FromDid = n.didOf(mit.Address)
+	transferred.ToDid = c.arg(2)
+	n.mits[mit.Symbol] = &transferred
+	return transaction(r, nil)
+}
+
+func (n *Node) getmit(c Call) (interface{}, error) {
+	symbol := c.arg(0)
+	if symbol == "" {
+		return map[string]interface{}{"mits": n.listMITs(nil)}, nil
+	}
+	mit, ok := n.mits[symbol]
+	if !ok {
+		return nil, rpcError(7006, "mit symbol %s does not exist", symbol)
+	}
+	return map[string]interface{}{"mits": []*mvs_api.MIT{mit}}, nil
+}
+
+func (n *Node) listmits(c Call) (interface{}, error) {
+	a, err := n.login(c)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{"mits": n.listMITs(a)}, nil
+}
+
+func (n *Node) listMITs(a *account) []*mvs_api.MIT {
+	var symbols []string
+	for symbol, mit := range n.mits {
+		if a == nil || n.owners[mit.Address] == a {
+			symbols = append(symbols, symbol)
+		}
+	}
+	sort.Strings(symbols)
+	mits := []*mvs_api.MIT{}
+	for _, symbol := range symbols {
+		mits = append(mits, n.mits[symbol])
+	}
+	return mits
+}
+
+func (n *Node) getheight(c Call) (interface{}, error) {
+	return n.height(), nil
+}
+
+func (n *Node) blockJSON(b *block) *mvs_api.Block {
+	out := &mvs_api.Block{BlockHeader: b.header, Transactions: []mvs_api.Transaction{}}
+	for _, r := range b.txs {
+		out.Transactions = append(out.Transactions, r.tx)
+	}
+	return out
+}
+
+// raw stands in for the serialized form of v when json=false is asked for.
+func raw(v interface{}) string {
+	data, _ := json.Marshal(v)
+	return hex.EncodeToString(data)
+}
+
+func (n *Node) findBlock(hashOrHeight string) (*block, error) {
+	if height, err := strconv.ParseUint(hashOrHeight, 10, 64); err == nil {
+		if height <= n.height() {
+			return n.blocks[height], nil
+		}
+	}
+	for _, b := range n.blocks {
+		if b.header.Hash == hashOrHeight {
+			return b, nil
+		}
+	}
+	return nil, rpcError(5101, "block %s not found", hashOrHeight)
+}
+
+func (n *Node) getblock(c Call) (interface{}, error) {
+	b, err := n.findBlock(c.arg(0))
+	if err != nil {
+		return nil, err
+	}
+	if c.arg(1) == "false" {
+		return raw(n.blockJSON(b)), nil
+	}
+	return n.blockJSON(b), nil
+}
+
+func (n *Node) getblockheader(c Call) (interface{}, error) {
+	b := n.tip()
+	if hash := c.opt("hash"); hash != "" {
+		var err error
+		if b, err = n.findBlock(hash); err != nil {
+			return nil, err
+		}
+	} else if height := c.opt("height"); height != "" {
+		var err error
+		if b, err = n.findBlock(height); err != nil {
+			return nil, err
+		}
+	}
+	return &b.header, nil
+}
+
+func (n *Node) gettx(c Call) (interface{}, error) {
+	r := n.findRecord(c.arg(1))
+	if r == nil {
+		return nil, rpcError(5306, "transaction %s does not exist", c.arg(1))
+	}
+	if c.arg(0) == "false" {
+		return map[string]interface{}{"transaction": raw(r.tx)}, nil
+	}
+	return map[string]interface{}{"transaction": r.tx}, nil
+}
+
+func (n *Node) getmemorypool(c Call) (interface{}, error) {
+	txs := []mvs_api.Transaction{}
+	for _, r := range n.mempool {
+		txs = append(txs, r.tx)
+	}
+	return map[string]interface{}{"transactions": txs}, nil
+}
+
+func (n *Node) listtxs(c Call) (interface{}, error) {
+	a, err := n.login(c)
+	if err != nil {
+		return nil, err
+	}
+	addresses := map[string]bool{}
+	if address := c.opt("address"); address != "" {
+		if err := n.ownAddress(a, address); err != nil {
+			return nil, err
+		}
+		addresses[address] = true
+	} else {
+		for _, address := range a.addresses {
+			addresses[address] = true
+		}
+	}
+	var from, to uint64
+	if height := c.opt("height"); height != "" {
+		parts := strings.SplitN(height, ":", 2)
+		from, _ = strconv.ParseUint(parts[0], 10, 64)
+		if len(parts) == 2 {
+			to, _ = strconv.ParseUint(parts[1], 10, 64)
+		}
+	}
+	symbol := strings.ToUpper(c.opt("symbol"))
+
+	txs := []mvs_api.Transaction{}
+	for i := len(n.records) - 1; i >= 0; i-- {
+		r := n.records[i]
+		if !r.touches(addresses, symbol) {
+			continue
+		}
+		if to != 0 && (r.tx.Height < from || r.tx.Height >= to) {
+			continue
+		}
+		txs = append(txs, r.view(addresses))
+	}
+
+	limit := c.optNumber("limit", 100)
+	index := c.optNumber("index", 1)
+	if limit == 0 {
+		limit = 100
+	}
+	if index == 0 {
+		index = 1
+	}
+	page := &mvs_api.TxPage{CurrentPage: index, TotalPage: (uint64(len(txs)) + limit - 1) / limit, Transactions: []mvs_api.Transaction{}}
+	if start := (index - 1) * limit; start < uint64(len(txs)) {
+		end := start + limit
+		if end > uint64(len(txs)) {
+			end = uint64(len(txs))
+		}
+		page.Transactions = txs[start:end]
+	}
+	return page, nil
+}
+
+func (n *Node) getinfo(c Call) (interface{}, error) {
+	return &mvs_api.Info{
+		ProtocolVersion:    70012,
+		WalletVersion:      "0.8.4",
+		DatabaseVersion:    "0.8.4",
+		NetworkAssetsCount: uint32(len(n.assets)),
+		WalletAccountCount: uint32(len(n.accounts)),
+		Height:             n.height(),
+		Difficulty:         "1",
+		IsMining:           n.miner != "",
+	}, nil
+}
+
+func (n *Node) getmininginfo(c Call) (interface{}, error) {
+	return &mvs_api.MiningInfo{IsMining: n.miner != "", Height: n.height(), Difficulty: "1"}, nil
+}
+
+func (n *Node) setminingaccount(c Call) (interface{}, error) {
+	a, err := n.login(c)
+	if err != nil {
+		return nil, err
+	}
+	if err := n.ownAddress(a, c.arg(2)); err != nil {
+		return nil, err
+	}
+	n.miner = c.arg(2)
+	return fmt.Sprintf("setting address [%s] successfully.", n.miner), nil
+}
+
+func (n *Node) startmining(c Call) (interface{}, error) {
+	a, err := n.login(c)
+	if err != nil {
+		return nil, err
+	}
+	address := c.opt("address")
+	if address == "" {
+		address = a.addresses[0]
+	}
+	if err := n.ownAddress(a, address); err != nil {
+		return nil, err
+	}
+	n.miner = address
+	if number := c.optNumber("number", 0); number > 0 {
+		for i := uint64(0); i < number; i++ {
+			n.mine()
+		}
+	}
+	return "solo mining started at " + address, nil
+}
+
+func (n *Node) stopmining(c Call) (interface{}, error) {
+	n.miner = ""
+	return "signal STOP sent.", nil
+}
@@ -0,0 +1,533 @@
+package mvstest
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"mvs_api"
+	"mvs_api/address"
+	"mvs_api/hdwallet"
+)
+
+const (
+	// DefaultFee is charged by every transaction that names no fee.
+	DefaultFee = 10000
+	// BlockReward is paid to the mining address, if one is set, for every
+	// mined block.
+	BlockReward = 300000000
+
+	genesisTime   = 1540000000
+	blockInterval = 15
+)
+
+// Node is a Server that keeps state like mvsd does: accounts and their
+// addresses, ETP and asset balances, certs, DIDs, MITs, a mempool and a
+// chain. Transactions enter the mempool and change balances once mined with
+// Mine; ownership of symbols, DIDs, certs and MITs changes as soon as the
+// transaction is accepted. Methods it does not emulate answer with their
+// fixture as on a plain Server.
+//
+//	node := mvstest.NewNode()
+//	c := node.Client()
+//	account, _ := c.Getnewaccount("", "Alice", "A123456")
+//	node.Fund(account.DefaultAddress, 10*mvs_api.ETP)
+//	node.Mine(1)
+//
+// Accounts get real mnemonics and the addresses mvsd would derive from them,
+// so hdwallet.PredictAddresses agrees with Listaddresses; hashes and raw
+// transactions only look like the real ones.
+type Node struct {
+	*Server
+	mu       sync.Mutex
+	accounts map[string]*account
+	owners   map[string]*account
+	records  []*record
+	mempool  []*record
+	blocks   []*block
+	created  map[string]*createdAsset
+	assets   map[string]*mvs_api.Asset
+	certs    map[certKey]string
+	dids     map[string]string
+	mits     map[string]*mvs_api.MIT
+	miner    string
+	seq      uint64
+}
+
+type account struct {
+	name      string
+	password  string
+	mnemonic  string
+	master    *hdwallet.Key
+	addresses []string
+}
+
+type createdAsset struct {
+	asset mvs_api.Asset
+	owner *account
+}
+
+type certKey struct {
+	symbol string
+	cert   string
+}
+
+// movement is value leaving (an input) or reaching (an output) an address.
+// symbol is "" for ETP.
+type movement struct {
+	address    string
+	symbol     string
+	amount     uint64
+	attachment mvs_api.Attachment
+}
+
+type record struct {
+	tx   mvs_api.Transaction
+	ins  []movement
+	outs []movement
+}
+
+type block struct {
+	header mvs_api.BlockHeader
+	txs    []*record
+}
+
+// NewNode starts an emulated node holding only the genesis block.
+func NewNode() *Node {
+	n := &Node{Server: NewServer()}
+	n.init()
+	return n
+}
+
+// Reset forgets all state and calls, leaving the genesis block only.
+func (n *Node) Reset() {
+	n.Server.Reset()
+	n.init()
+}
+
+func (n *Node) init() {
+	n.mu.Lock()
+	n.accounts = map[string]*account{}
+	n.owners = map[string]*account{}
+	n.records = nil
+	n.mempool = nil
+	n.blocks = nil
+	n.created = map[string]*createdAsset{}
+	n.assets = map[string]*mvs_api.Asset{}
+	n.certs = map[certKey]string{}
+	n.dids = map[string]string{}
+	n.mits = map[string]*mvs_api.MIT{}
+	n.miner = ""
+	n.seq = 0
+	n.mine()
+	n.mu.Unlock()
+
+	for method, h := range n.handlers() {
+		n.Handle(method, n.locked(h))
+	}
+}
+
+func (n *Node) locked(h Handler) Handler {
+	return func(call Call) (interface{}, error) {
+		n.mu.Lock()
+		defer n.mu.Unlock()
+		return h(call)
+	}
+}
+
+// Mine adds count blocks holding the mempool and returns the new height.
+func (n *Node) Mine(count int) uint64 {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	for i := 0; i < count; i++ {
+		n.mine()
+	}
+	return n.height()
+}
+
+// Fund puts a transaction paying amount to address in the mempool, as if
+// sent from outside the wallet, and returns its hash.
+func (n *Node) Fund(address string, amount mvs_api.Amount) string {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	r := n.submit(nil, []movement{{address: address, amount: uint64(amount)}}, "")
+	return r.tx.Hash
+}
+
+// Height returns the height of the last block.
+func (n *Node) Height() uint64 {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.height()
+}
+
+func (n *Node) height() uint64 {
+	return uint64(len(n.blocks) - 1)
+}
+
+func (n *Node) tip() *block {
+	return n.blocks[len(n.blocks)-1]
+}
+
+func (n *Node) mine() {
+	height := uint64(len(n.blocks))
+	txs := n.mempool
+	n.mempool = nil
+	if n.miner != "" {
+		coinbase := n.newRecord(nil, []movement{{address: n.miner, amount: BlockReward}}, "")
+		n.records = append(n.records, coinbase)
+		txs = append([]*record{coinbase}, txs...)
+	}
+
+	b := &block{txs: txs}
+	b.header = mvs_api.BlockHeader{
+		Hash:             n.newHash(),
+		Bits:             "1",
+		MerkleTreeHash:   n.newHash(),
+		Mixhash:          n.newHash(),
+		Nonce:            fmt.Sprint(n.seq),
+		Number:           height,
+		TimeStamp:        genesisTime + height*blockInterval,
+		TransactionCount: uint64(len(txs)),
+		Version:          1,
+	}
+	if height > 0 {
+		b.header.PreviousBlockHash = n.tip().header.Hash
+	} else {
+		b.header.PreviousBlockHash = strings.Repeat("0", 64)
+	}
+	for _, r := range txs {
+		r.tx.Height = height
+		r.tx.Timestamp = b.header.TimeStamp
+	}
+	n.blocks = append(n.blocks, b)
+}
+
+func (n *Node) newHash() string {
+	n.seq++
+	sum := sha256.Sum256([]byte(fmt.Sprintf("mvstest hash %d", n.seq)))
+	return hex.EncodeToString(sum[:])
+}
+
+func (n *Node) newAddress(a *account) string {
+	child, err := a.master.Child(uint32(len(a.addresses)))
+	if err != nil {
+		panic(err)
+	}
+	s := child.PrivateKey().PublicKey().Address(false)
+	a.addresses = append(a.addresses, s)
+	n.owners[s] = a
+	return s
+}
+
+// newAccount creates an account for words, a new mnemonic of lang if nil,
+// with the addresses of hd indexes below count.
+func (n *Node) newAccount(name, password string, words []string, lang string, count int) (*account, error) {
+	if words == nil {
+		n.seq++
+		sum := sha256.Sum256([]byte(fmt.Sprintf("mvstest mnemonic %d", n.seq)))
+		var err error
+		if words, err = hdwallet.NewMnemonic(sum[:], lang); err != nil {
+			return nil, rpcError(1021, "%v", err)
+		}
+	}
+	master, err := hdwallet.AccountKey(words)
+	if err != nil {
+		return nil, rpcError(1021, "%v", err)
+	}
+	a := &account{name: name, password: password, mnemonic: strings.Join(words, " "), master: master}
+	for i := 0; i < count; i++ {
+		n.newAddress(a)
+	}
+	n.accounts[name] = a
+	return a, nil
+}
+
+// newRecord builds a transaction spending ins and paying outs.
+func (n *Node) newRecord(ins, outs []movement, memo string) *record {
+	r := &record{ins: ins, outs: outs}
+	r.tx = mvs_api.Transaction{
+		Hash:      n.newHash(),
+		Timestamp: n.tip().header.TimeStamp,
+		Memo:      memo,
+		Version:   4,
+		Inputs:    []mvs_api.TxInput{},
+		Outputs:   []mvs_api.TxOutput{},
+	}
+	for _, in := range ins {
+		r.tx.Inputs = append(r.tx.Inputs, mvs_api.TxInput{
+			Address:        in.address,
+			PreviousOutput: mvs_api.OutputPoint{Hash: strings.Repeat("0", 64), Index: uint32(len(r.tx.Inputs))},
+			Script:         "[ ] [ ]",
+			Sequence:       0xffffffff,
+		})
+	}
+	for _, out := range outs {
+		output := mvs_api.TxOutput{
+			Index:      uint32(len(r.tx.Outputs)),
+			Address:    out.address,
+			Script:     "dup hash160 [ ] equalverify checksig",
+			Attachment: out.attachment,
+		}
+		switch {
+		case out.attachment.Type != "":
+		case out.symbol == "":
+			output.Value = out.amount
+			output.Attachment.Type = "etp"
+		default:
+			output.Attachment = mvs_api.Attachment{Type: "asset-transfer", Symbol: out.symbol, Quantity: out.amount}
+		}
+		r.tx.Outputs = append(r.tx.Outputs, output)
+	}
+	return r
+}
+
+func (n *Node) submit(ins, outs []movement, memo string) *record {
+	r := n.newRecord(ins, outs, memo)
+	n.records = append(n.records, r)
+	n.mempool = append(n.mempool, r)
+	return r
+}
+
+// balance is what address holds of symbol: mined outputs less all inputs,
+// so that mempool spends cannot be spent twice.
+func (n *Node) balance(address, symbol string) uint64 {
+	var spent uint64
+	for _, r := range n.records {
+		for _, in := range r.ins {
+			if in.address == address && in.symbol == symbol {
+				spent += in.amount
+			}
+		}
+	}
+	received := n.received(address, symbol)
+	if spent > received {
+		// take never spends more than there is.
+		panic(fmt.Sprintf("mvstest: %s spent %d of %d received", address, spent, received))
+	}
+	return received - spent
+}
+
+func (n *Node) received(address, symbol string) uint64 {
+	var received uint64
+	for _, r := range n.records {
+		if r.tx.Height == 0 {
+			continue
+		}
+		for _, out := range r.outs {
+			if out.address == address && out.symbol == symbol {
+				received += out.amount
+			}
+		}
+	}
+	return received
+}
+
+// take draws amount of symbol from the given addresses in turn.
+func (n *Node) take(addresses []string, symbol string, amount uint64) ([]movement, error) {
+	var ins []movement
+	for _, address := range addresses {
+		if amount == 0 {
+			break
+		}
+		balance := n.balance(address, symbol)
+		if balance == 0 {
+			continue
+		}
+		if balance > amount {
+			balance = amount
+		}
+		ins = append(ins, movement{address: address, symbol: symbol, amount: balance})
+		amount -= balance
+	}
+	if amount > 0 {
+		if symbol == "" {
+			return nil, errNotEnough
+		}
+		return nil, rpcError(3001, "not enough asset %s balance", symbol)
+	}
+	return ins, nil
+}
+
+// pay draws value and the fee from addresses and submits a transaction
+// paying outs.
+func (n *Node) pay(addresses []string, symbol string, value, fee uint64, outs []movement, memo string) (*record, error) {
+	var ins []movement
+	if symbol != "" {
+		assetIns, err := n.take(addresses, symbol, value)
+		if err != nil {
+			return nil, err
+		}
+		ins = assetIns
+		value = 0
+	}
+	total, err := sum(value, fee)
+	if err != nil {
+		return nil, err
+	}
+	etpIns, err := n.take(addresses, "", total)
+	if err != nil {
+		return nil, err
+	}
+	return n.submit(append(ins, etpIns...), outs, memo), nil
+}
+
+var errNotEnough = rpcError(1008, "not enough balance")
+
+// sum adds amounts; more than a uint64 holds is more than anyone has.
+func sum(amounts ...uint64) (uint64, error) {
+	var total uint64
+	for _, a := range amounts {
+		if total+a < total {
+			return 0, errNotEnough
+		}
+		total += a
+	}
+	return total, nil
+}
+
+func (n *Node) findRecord(hash string) *record {
+	for _, r := range n.records {
+		if r.tx.Hash == hash {
+			return r
+		}
+	}
+	return nil
+}
+
+// touches reports whether r moves value from or to one of addresses.
+func (r *record) touches(addresses map[string]bool, symbol string) bool {
+	for _, m := range append(append([]movement(nil), r.ins...), r.outs...) {
+		if addresses[m.address] && (symbol == "" || m.symbol == symbol || m.attachment.Symbol == symbol) {
+			return true
+		}
+	}
+	return false
+}
+
+// view returns the transaction as seen by the owner of addresses.
+func (r *record) view(addresses map[string]bool) mvs_api.Transaction {
+	tx := r.tx
+	tx.Direction = "receive"
+	for _, in := range r.ins {
+		if addresses[in.address] {
+			tx.Direction = "send"
+		}
+	}
+	return tx
+}
+
+// payee resolves the address s of a send. The did methods take a DID
+// symbol as well, the others an address only.
+func (n *Node) payee(c Call, s string) (string, error) {
+	if strings.HasPrefix(c.Method, "did") {
+		return n.didAddress(s)
+	}
+	if !address.Valid(s) {
+		return "", rpcError(4010, "invalid address %s", s)
+	}
+	return s, nil
+}
+
+func (n *Node) didAddress(didOrAddress string) (string, error) {
+	if owner, ok := n.dids[didOrAddress]; ok {
+		return owner, nil
+	}
+	if n.didOf(didOrAddress) != "" || address.Valid(didOrAddress) {
+		return didOrAddress, nil
+	}
+	return "", rpcError(7006, "did symbol %s does not exist", didOrAddress)
+}
+
+func (n *Node) didOf(address string) string {
+	for symbol, a := range n.dids {
+		if a == address {
+			return symbol
+		}
+	}
+	return ""
+}
+
+func (n *Node) did(symbol string) *mvs_api.DID {
+	address := n.dids[symbol]
+	return &mvs_api.DID{
+		Symbol:  symbol,
+		Address: address,
+		Status:  "registered",
+		Addresses: []mvs_api.DIDAddress{
+			{Address: address, Status: "current", Height: n.height(), Timestamp: n.tip().header.TimeStamp},
+		},
+	}
+}
+
+func (n *Node) assetBalances(addresses []string, symbol string) []mvs_api.AssetBalance {
+	balances := []mvs_api.AssetBalance{}
+	for _, s := range n.sortedAssets() {
+		if symbol != "" && s != symbol {
+			continue
+		}
+		asset := n.assets[s]
+		for _, address := range addresses {
+			quantity := n.balance(address, s)
+			if quantity == 0 {
+				continue
+			}
+			balances = append(balances, mvs_api.AssetBalance{
+				Symbol:        s,
+				Address:       address,
+				Issuer:        asset.Issuer,
+				Quantity:      quantity,
+				DecimalNumber: asset.DecimalNumber,
+				Status:        "unspent",
+			})
+		}
+	}
+	return balances
+}
+
+func (n *Node) certBalances(addresses []string, symbol string) []mvs_api.AssetBalance {
+	owned := map[string]bool{}
+	for _, address := range addresses {
+		owned[address] = true
+	}
+	var keys []certKey
+	for key, owner := range n.certs {
+		if (symbol == "" || key.symbol == symbol) && (addresses == nil || owned[owner]) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].symbol != keys[j].symbol {
+			return keys[i].symbol < keys[j].symbol
+		}
+		return keys[i].cert < keys[j].cert
+	})
+	certs := []mvs_api.AssetBalance{}
+	for _, key := range keys {
+		owner := n.certs[key]
+		certs = append(certs, mvs_api.AssetBalance{
+			Symbol:  key.symbol,
+			Address: owner,
+			Cert:    key.cert,
+			Owner:   n.didOf(owner),
+			Status:  "owned",
+		})
+	}
+	return certs
+}
+
+func (n *Node) sortedAssets() []string {
+	var symbols []string
+	for symbol := range n.assets {
+		symbols = append(symbols, symbol)
+	}
+	sort.Strings(symbols)
+	return symbols
+}
+
+func rpcError(code int, format string, args ...interface{}) *mvs_api.RPCError {
+	return &mvs_api.RPCError{Code: code, Message: fmt.Sprintf(format, args...)}
+}
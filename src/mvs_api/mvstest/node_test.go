@@ -0,0 +1,226 @@
+package mvstest_test
+
+import (
+	"errors"
+	"testing"
+
+	"mvs_api"
+	"mvs_api/address"
+	"mvs_api/mvstest"
+)
+
+// newNode returns a node where Alice holds 10 ETP and Bob nothing.
+func newNode(t *testing.T) (*mvstest.Node, *mvs_api.RPCClient, *mvs_api.Account, *mvs_api.Account) {
+	t.Helper()
+	node := mvstest.NewNode()
+	t.Cleanup(node.Close)
+	c := node.Client()
+	alice, err := c.Getnewaccount("", "Alice", "A123456")
+	if err != nil {
+		t.Fatal(err)
+	}
+	bob, err := c.Getnewaccount("", "Bob", "B123456")
+	if err != nil {
+		t.Fatal(err)
+	}
+	node.Fund(alice.DefaultAddress, 10*mvs_api.ETP)
+	node.Mine(1)
+	return node, c, alice, bob
+}
+
+func available(t *testing.T, c *mvs_api.RPCClient, address string) uint64 {
+	t.Helper()
+	b, err := c.Getaddressetp(address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b.Available
+}
+
+func TestNodeSend(t *testing.T) {
+	node, c, alice, bob := newNode(t)
+
+	tx, err := c.Send("Alice", "A123456", bob.DefaultAddress, mvs_api.ETP, "rent", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tx.Memo != "rent" || tx.Height != 0 {
+		t.Errorf("unmined tx = %+v", tx)
+	}
+	if got := available(t, c, bob.DefaultAddress); got != 0 {
+		t.Errorf("Bob has %d before the block", got)
+	}
+	node.Mine(1)
+	if got := available(t, c, bob.DefaultAddress); got != uint64(mvs_api.ETP) {
+		t.Errorf("Bob has %d, want %d", got, mvs_api.ETP)
+	}
+	if got, want := available(t, c, alice.DefaultAddress), uint64(9*mvs_api.ETP-mvstest.DefaultFee); got != want {
+		t.Errorf("Alice has %d, want %d", got, want)
+	}
+
+	if _, err := c.Send("Alice", "A123456", bob.DefaultAddress, 100*mvs_api.ETP, "", 0); !errors.Is(err, mvs_api.ErrInsufficientBalance) {
+		t.Errorf("overspend: err = %v", err)
+	}
+	if _, err := c.Send("Alice", "wrong", bob.DefaultAddress, 1, "", 0); !errors.Is(err, mvs_api.ErrBadPassword) {
+		t.Errorf("bad password: err = %v", err)
+	}
+
+	if _, err := c.Sendmore("Bob", "B123456", mvs_api.Receivers{{To: alice.DefaultAddress, Amount: 1000}, {To: alice.DefaultAddress, Amount: 2000}}, "", 0); err != nil {
+		t.Fatal(err)
+	}
+	node.Mine(1)
+	if got, want := available(t, c, bob.DefaultAddress), uint64(mvs_api.ETP-3000-mvstest.DefaultFee); got != want {
+		t.Errorf("Bob has %d after sendmore, want %d", got, want)
+	}
+}
+
+func TestNodeOverspend(t *testing.T) {
+	node, c, alice, bob := newNode(t)
+	const max = mvs_api.Amount(1<<64 - 1)
+	tests := []struct {
+		name string
+		send func() error
+	}{
+		{"balance", func() error {
+			_, err := c.Send("Alice", "A123456", bob.DefaultAddress, 10*mvs_api.ETP, "", 0)
+			return err
+		}},
+		{"amount and fee above 2^64", func() error {
+			_, err := c.Send("Alice", "A123456", bob.DefaultAddress, max, "", 0)
+			return err
+		}},
+		{"fee above 2^64", func() error {
+			_, err := c.Send("Alice", "A123456", bob.DefaultAddress, 1, "", max)
+			return err
+		}},
+		{"receivers above 2^64", func() error {
+			// The client refuses such receivers, so send them as the node
+			// would get them from another one.
+			receivers := []string{bob.DefaultAddress + ":18446744073709551615", bob.DefaultAddress + ":2"}
+			resp, err := c.Call("sendmore", []interface{}{"Alice", "A123456", map[string]interface{}{"receivers": receivers}})
+			if err == nil && resp.Error != nil {
+				err = resp.Error
+			}
+			return err
+		}},
+		{"from an empty address", func() error {
+			_, err := c.Sendfrom("Bob", "B123456", bob.DefaultAddress, alice.DefaultAddress, 1, "", 0)
+			return err
+		}},
+	}
+	for _, tt := range tests {
+		err := tt.send()
+		var rpcErr *mvs_api.RPCError
+		if !errors.As(err, &rpcErr) || rpcErr.Code != 1008 || !errors.Is(err, mvs_api.ErrInsufficientBalance) {
+			t.Errorf("%s: err = %v, want code 1008", tt.name, err)
+		}
+	}
+	node.Mine(1)
+	if got := available(t, c, alice.DefaultAddress); got != uint64(10*mvs_api.ETP) {
+		t.Errorf("Alice has %d after failed sends, want %d", got, 10*mvs_api.ETP)
+	}
+	if got := available(t, c, bob.DefaultAddress); got != 0 {
+		t.Errorf("Bob has %d after failed sends", got)
+	}
+}
+
+func TestNodeBurn(t *testing.T) {
+	node, c, alice, _ := newNode(t)
+	if _, err := c.Createasset("Alice", "A123456", 0, "ZGC.TEST", "", 1000, 0, "test asset"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Issue("Alice", "A123456", "ZGC.TEST", "", 0); err != nil {
+		t.Fatal(err)
+	}
+	node.Mine(1)
+
+	tx, err := c.Burn("Alice", "A123456", "ZGC.TEST", 400)
+	if err != nil {
+		t.Fatalf("burn: %v", err)
+	}
+	if len(tx.Outputs) != 1 || tx.Outputs[0].Address != address.Blackhole {
+		t.Errorf("burn outputs = %+v", tx.Outputs)
+	}
+	node.Mine(1)
+	assets, err := c.Getaddressasset(alice.DefaultAddress, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(assets) != 1 || assets[0].Quantity != 600 {
+		t.Errorf("Alice holds %+v after burning 400 of 1000", assets)
+	}
+	burnt, err := c.Getaddressasset(address.Blackhole, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(burnt) != 1 || burnt[0].Quantity != 400 {
+		t.Errorf("blackhole holds %+v", burnt)
+	}
+	if _, err := c.Burn("Alice", "A123456", "ZGC.TEST", 1000); !errors.Is(err, mvs_api.ErrInsufficientBalance) {
+		t.Errorf("overburn: err = %v", err)
+	}
+}
+
+func TestNodeDIDSends(t *testing.T) {
+	node, c, alice, bob := newNode(t)
+	node.Fund(bob.DefaultAddress, mvs_api.ETP)
+	node.Mine(1)
+	if _, err := c.Registerdid("Bob", "B123456", bob.DefaultAddress, "BOB", 0); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Createasset("Alice", "A123456", 0, "ZGC.TEST", "", 1000, 0, ""); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Issue("Alice", "A123456", "ZGC.TEST", "", 0); err != nil {
+		t.Fatal(err)
+	}
+	node.Mine(1)
+	bobETP := available(t, c, bob.DefaultAddress)
+
+	if _, err := c.Send("Alice", "A123456", "BOB", 1000, "", 0); err == nil {
+		t.Error("send took a DID")
+	}
+	if _, err := c.Didsend("Alice", "A123456", "BOB", 1000, "", 0); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Didsendmore("Alice", "A123456", mvs_api.Receivers{{To: "BOB", Amount: 2000}, {To: bob.DefaultAddress, Amount: 3000}}, "", 0); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Sendasset("Alice", "A123456", "BOB", "ZGC.TEST", 100, "", 0); err == nil {
+		t.Error("sendasset took a DID")
+	}
+	if _, err := c.Didsendasset("Alice", "A123456", "BOB", "ZGC.TEST", 100, "", 0); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Didsendassetfrom("Alice", "A123456", alice.DefaultAddress, "BOB", "ZGC.TEST", 50, "", 0); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Didsend("Alice", "A123456", "NOBODY", 1000, "", 0); !errors.Is(err, mvs_api.ErrUnknownSymbol) {
+		t.Errorf("didsend to unknown DID: err = %v", err)
+	}
+	node.Mine(1)
+
+	if got, want := available(t, c, bob.DefaultAddress), bobETP+6000; got != want {
+		t.Errorf("Bob has %d ETP, want %d", got, want)
+	}
+	assets, err := c.Getaddressasset(bob.DefaultAddress, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(assets) != 1 || assets[0].Quantity != 150 {
+		t.Errorf("Bob holds %+v, want 150 ZGC.TEST", assets)
+	}
+}
+
+func TestNodeFixtures(t *testing.T) {
+	node := mvstest.NewNode()
+	defer node.Close()
+	// Methods the node does not emulate answer with their fixture.
+	msg, err := node.Client().Popblock(1270000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if msg != "pop block from 1270000 finished." {
+		t.Errorf("popblock = %q", msg)
+	}
+}
@@ -0,0 +1,281 @@
+// Package mvstest runs an in-memory MVS node for tests. It speaks the
+// JSON-RPC 2.0 protocol of mvsd on /rpc/v2, answers every method of
+// mvs_api.RPCClient with a canned fixture and records the calls it gets:
+//
+//	srv := mvstest.NewServer()
+//	defer srv.Close()
+//	srv.Fail("send", &mvs_api.RPCError{Code: 5302, Message: "not enough balance"})
+//	...
+//	srv.LastCall(t, "getbalance").AssertPositional(t, "Alice", "A123456")
+package mvstest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+
+	"mvs_api"
+)
+
+// Path is where the server answers, as mvsd does.
+const Path = "/rpc/v2"
+
+// Handler answers one call. A *mvs_api.RPCError is sent back as the JSON-RPC
+// error of the call; any other error fails the whole HTTP request with
+// status 500, as a broken node would.
+type Handler func(call Call) (result interface{}, err error)
+
+// Call is a request received by the server. The trailing object of the
+// params, which mvs_api always sends, is split off into Optional. Numbers
+// are json.Number.
+type Call struct {
+	Method     string
+	Id         json.RawMessage
+	Positional []interface{}
+	Optional   map[string]interface{}
+}
+
+type Server struct {
+	*httptest.Server
+	mu       sync.Mutex
+	handlers map[string]Handler
+	calls    []Call
+}
+
+// NewServer starts a server answering every method with its fixture, see
+// Fixtures.
+func NewServer() *Server {
+	s := &Server{handlers: fixtureHandlers()}
+	mux := http.NewServeMux()
+	mux.HandleFunc(Path, s.serveHTTP)
+	s.Server = httptest.NewServer(mux)
+	return s
+}
+
+// Client returns a client for the server.
+func (s *Server) Client(opts ...mvs_api.Option) *mvs_api.RPCClient {
+	c, err := mvs_api.New(s.URL+Path, opts...)
+	if err != nil {
+		panic(err)
+	}
+	return c
+}
+
+// Handle answers method with h from now on.
+func (s *Server) Handle(method string, h Handler) {
+	s.mu.Lock()
+	s.handlers[method] = h
+	s.mu.Unlock()
+}
+
+// Respond answers method with result, which is marshalled as is; a
+// json.RawMessage is sent verbatim.
+func (s *Server) Respond(method string, result interface{}) {
+	s.Handle(method, func(Call) (interface{}, error) {
+		return result, nil
+	})
+}
+
+// Fail makes method fail with err, see Handler.
+func (s *Server) Fail(method string, err error) {
+	s.Handle(method, func(Call) (interface{}, error) {
+		return nil, err
+	})
+}
+
+// Calls returns the calls of method received so far, or all of them when
+// method is "".
+func (s *Server) Calls(method string) []Call {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var calls []Call
+	for _, c := range s.calls {
+		if method == "" || c.Method == method {
+			calls = append(calls, c)
+		}
+	}
+	return calls
+}
+
+// LastCall returns the last call of method and fails t if there was none.
+func (s *Server) LastCall(t testing.TB, method string) Call {
+	t.Helper()
+	calls := s.Calls(method)
+	if len(calls) == 0 {
+		t.Fatalf("mvstest: %s was not called", method)
+		return Call{}
+	}
+	return calls[len(calls)-1]
+}
+
+// Reset forgets the calls received so far and restores the fixtures. Calls
+// in flight get either the old or the new handler.
+func (s *Server) Reset() {
+	handlers := fixtureHandlers()
+	s.mu.Lock()
+	s.calls = nil
+	s.handlers = handlers
+	s.mu.Unlock()
+}
+
+func fixtureHandlers() map[string]Handler {
+	handlers := map[string]Handler{}
+	for method, result := range Fixtures {
+		result := json.RawMessage(result)
+		handlers[method] = func(Call) (interface{}, error) {
+			return result, nil
+		}
+	}
+	return handlers
+}
+
+// AssertPositional fails t unless the call had exactly the positional params
+// want. Values are compared by their JSON encoding, so uint64(5) matches the
+// 5 received.
+func (c Call) AssertPositional(t testing.TB, want ...interface{}) {
+	t.Helper()
+	if want == nil {
+		want = []interface{}{}
+	}
+	if !sameJSON(c.Positional, want) {
+		t.Errorf("mvstest: %s positional params = %s, want %s", c.Method, encode(c.Positional), encode(want))
+	}
+}
+
+// AssertOptional fails t unless the call had exactly the optional params
+// want.
+func (c Call) AssertOptional(t testing.TB, want map[string]interface{}) {
+	t.Helper()
+	if want == nil {
+		want = map[string]interface{}{}
+	}
+	if !sameJSON(c.Optional, want) {
+		t.Errorf("mvstest: %s optional params = %s, want %s", c.Method, encode(c.Optional), encode(want))
+	}
+}
+
+type request struct {
+	Id     json.RawMessage `json:"id"`
+	Method string          `json:"method"`
+	Params []interface{}   `json:"params"`
+}
+
+type response struct {
+	Jsonrpc string            `json:"jsonrpc"`
+	Id      json.RawMessage   `json:"id"`
+	Result  interface{}       `json:"result,omitempty"`
+	Error   *mvs_api.RPCError `json:"error,omitempty"`
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		http.Error(w, "POST only", http.StatusMethodNotAllowed)
+		return
+	}
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	batch := len(body) > 0 && body[0] == '['
+	var reqs []request
+	if batch {
+		err = decode(body, &reqs)
+	} else {
+		reqs = make([]request, 1)
+		err = decode(body, &reqs[0])
+	}
+	if err != nil {
+		writeJSON(w, response{Id: json.RawMessage("null"), Error: &mvs_api.RPCError{Code: -32700, Message: "parse error"}})
+		return
+	}
+
+	resps := make([]response, len(reqs))
+	for i, req := range reqs {
+		resp, err := s.serve(req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		resps[i] = resp
+	}
+	if batch {
+		writeJSON(w, resps)
+	} else {
+		writeJSON(w, resps[0])
+	}
+}
+
+func (s *Server) serve(req request) (response, error) {
+	call := Call{Method: req.Method, Id: req.Id, Positional: req.Params}
+	if n := len(req.Params); n > 0 {
+		if optional, ok := req.Params[n-1].(map[string]interface{}); ok {
+			call.Positional = req.Params[:n-1]
+			call.Optional = optional
+		}
+	}
+	if call.Positional == nil {
+		call.Positional = []interface{}{}
+	}
+	if call.Optional == nil {
+		call.Optional = map[string]interface{}{}
+	}
+
+	s.mu.Lock()
+	s.calls = append(s.calls, call)
+	h := s.handlers[req.Method]
+	s.mu.Unlock()
+
+	resp := response{Jsonrpc: "2.0", Id: req.Id}
+	if h == nil {
+		resp.Error = &mvs_api.RPCError{Code: -32601, Message: fmt.Sprintf("method %s not found", req.Method)}
+		return resp, nil
+	}
+	result, err := h(call)
+	if rpcErr, ok := err.(*mvs_api.RPCError); ok {
+		resp.Error = rpcErr
+		return resp, nil
+	}
+	if err != nil {
+		return resp, err
+	}
+	if result == nil {
+		result = json.RawMessage("null")
+	}
+	resp.Result = result
+	return resp, nil
+}
+
+// decode keeps numbers as json.Number, so amounts above 2^53 survive.
+func decode(data []byte, v interface{}) error {
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.UseNumber()
+	return d.Decode(v)
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
+
+func sameJSON(got, want interface{}) bool {
+	var a, b interface{}
+	json.Unmarshal([]byte(encode(got)), &a)
+	json.Unmarshal([]byte(encode(want)), &b)
+	return reflect.DeepEqual(a, b)
+}
+
+func encode(v interface{}) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(data)
+}
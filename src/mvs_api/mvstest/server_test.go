@@ -0,0 +1,118 @@
+package mvstest_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"reflect"
+	"testing"
+
+	"mvs_api"
+	"mvs_api/mvstest"
+	"mvs_api/rawtx"
+)
+
+// TestFixturesDecode calls every method of commands.json against the
+// fixtures and checks that the result decodes into its type.
+func TestFixturesDecode(t *testing.T) {
+	data, err := ioutil.ReadFile("../commands.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var spec struct {
+		Commands []struct {
+			Name   string `json:"name"`
+			Method string `json:"method"`
+		} `json:"commands"`
+	}
+	if err := json.Unmarshal(data, &spec); err != nil {
+		t.Fatal(err)
+	}
+
+	s := mvstest.NewServer()
+	defer s.Close()
+	client := reflect.ValueOf(s.Client())
+	receivers := reflect.ValueOf(mvs_api.Receivers{{To: mvstest.Address, Amount: 1}})
+	for _, c := range spec.Commands {
+		if _, ok := mvstest.Fixtures[c.Name]; !ok {
+			t.Errorf("%s: no fixture", c.Name)
+			continue
+		}
+		method := client.MethodByName(c.Method)
+		if !method.IsValid() {
+			t.Errorf("%s: no method %s", c.Name, c.Method)
+			continue
+		}
+		args := make([]reflect.Value, method.Type().NumIn())
+		for i := range args {
+			if in := method.Type().In(i); in == receivers.Type() {
+				args[i] = receivers
+			} else {
+				args[i] = reflect.Zero(in)
+			}
+		}
+		out := method.Call(args)
+		if err, _ := out[1].Interface().(error); err != nil {
+			t.Errorf("%s: %v", c.Name, err)
+			continue
+		}
+		if out[0].IsZero() {
+			t.Errorf("%s: zero result", c.Name)
+		}
+	}
+}
+
+func TestRawTxFixture(t *testing.T) {
+	s := mvstest.NewServer()
+	defer s.Close()
+	hex, err := s.Client().Createrawtx(0, []string{mvstest.Address}, mvs_api.Receivers{{To: mvstest.Address, Amount: 1}}, "", 0, "", "", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx, err := rawtx.Decode(hex)
+	if err != nil {
+		t.Fatalf("fixture rawtx: %v", err)
+	}
+	if len(tx.Inputs) != 1 || len(tx.Outputs) != 1 || tx.Outputs[0].Value != 100000000 {
+		t.Errorf("fixture rawtx = %+v", tx)
+	}
+}
+
+func TestMultisigFixtureSorted(t *testing.T) {
+	var m mvs_api.Multisig
+	if err := json.Unmarshal([]byte(mvstest.Fixtures["getnewmultisig"]), &m); err != nil {
+		t.Fatal(err)
+	}
+	for i := 1; i < len(m.PublicKeys); i++ {
+		if m.PublicKeys[i-1] >= m.PublicKeys[i] {
+			t.Errorf("public keys not in mvsd order: %v", m.PublicKeys)
+		}
+	}
+}
+
+func TestServerResetWhileServing(t *testing.T) {
+	s := mvstest.NewServer()
+	defer s.Close()
+	r := s.Client()
+
+	done := make(chan struct{})
+	errs := make(chan error, 1)
+	go func() {
+		defer close(done)
+		for i := 0; i < 200; i++ {
+			if _, err := r.Getheight("", ""); err != nil {
+				errs <- err
+				return
+			}
+		}
+	}()
+	for i := 0; i < 200; i++ {
+		s.Reset()
+		s.Respond("getheight", 7)
+	}
+	<-done
+	select {
+	case err := <-errs:
+		t.Errorf("call during Reset: %v", err)
+	default:
+	}
+}
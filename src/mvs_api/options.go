@@ -0,0 +1,210 @@
+package mvs_api
+
+import (
+	"crypto/tls"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+type Option func(o *clientOptions) error
+
+type clientOptions struct {
+	timeout         time.Duration
+	tlsConfig       *tls.Config
+	proxy           *url.URL
+	header          http.Header
+	maxIdleConns    int
+	maxIdlePerHost  int
+	idleConnTimeout time.Duration
+	noKeepAlives    bool
+	httpClient      *http.Client
+	transport       Transport
+	health          *HealthPolicy
+	retry           RetryPolicy
+	logger          *log.Logger
+}
+
+// New creates a client for the node at rawurl, e.g.
+// "http://127.0.0.1:8820/rpc/v2". Unlike NewRPCClient it reports bad input
+// as an error instead of panicking.
+func New(rawurl string, opts ...Option) (*RPCClient, error) {
+	o := &clientOptions{header: http.Header{}}
+	for _, opt := range opts {
+		if err := opt(o); err != nil {
+			return nil, err
+		}
+	}
+	if o.transport == nil {
+		u, err := url.Parse(rawurl)
+		if err != nil {
+			return nil, err
+		}
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return nil, fmt.Errorf("mvs_api: unsupported url scheme %q", u.Scheme)
+		}
+		if u.Host == "" {
+			return nil, fmt.Errorf("mvs_api: no host in url %q", rawurl)
+		}
+	}
+
+	rpcClient := &RPCClient{Url: rawurl, health: DefaultHealthPolicy, retry: o.retry, logger: o.logger}
+	if o.health != nil {
+		rpcClient.SetHealthPolicy(*o.health)
+	}
+	rpcClient.transport = o.transport
+	if rpcClient.transport == nil {
+		rpcClient.transport = &HTTPTransport{Client: o.buildHTTPClient(), Header: o.header}
+	}
+	return rpcClient, nil
+}
+
+func (o *clientOptions) buildHTTPClient() *http.Client {
+	if o.httpClient != nil {
+		return o.httpClient
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if o.tlsConfig != nil {
+		transport.TLSClientConfig = o.tlsConfig
+	}
+	if o.proxy != nil {
+		transport.Proxy = http.ProxyURL(o.proxy)
+	}
+	if o.maxIdleConns != 0 {
+		transport.MaxIdleConns = o.maxIdleConns
+	}
+	if o.maxIdlePerHost != 0 {
+		transport.MaxIdleConnsPerHost = o.maxIdlePerHost
+	}
+	if o.idleConnTimeout != 0 {
+		transport.IdleConnTimeout = o.idleConnTimeout
+	}
+	transport.DisableKeepAlives = o.noKeepAlives
+	return &http.Client{Timeout: o.timeout, Transport: transport}
+}
+
+// WithTimeout limits every request, including reading the answer. Zero means
+// no limit; use a context deadline for per-call limits.
+func WithTimeout(d time.Duration) Option {
+	return func(o *clientOptions) error {
+		if d < 0 {
+			return fmt.Errorf("mvs_api: negative timeout %v", d)
+		}
+		o.timeout = d
+		return nil
+	}
+}
+
+func WithTLSConfig(config *tls.Config) Option {
+	return func(o *clientOptions) error {
+		o.tlsConfig = config
+		return nil
+	}
+}
+
+// WithProxy sends requests through the proxy at rawurl, an http, https or
+// socks5 url.
+func WithProxy(rawurl string) Option {
+	return func(o *clientOptions) error {
+		u, err := url.Parse(rawurl)
+		if err != nil {
+			return fmt.Errorf("mvs_api: bad proxy url: %w", err)
+		}
+		if u.Scheme != "http" && u.Scheme != "https" && u.Scheme != "socks5" {
+			return fmt.Errorf("mvs_api: unsupported proxy url scheme %q", u.Scheme)
+		}
+		if u.Host == "" {
+			return fmt.Errorf("mvs_api: no host in proxy url %q", rawurl)
+		}
+		o.proxy = u
+		return nil
+	}
+}
+
+func WithHeader(key, value string) Option {
+	return func(o *clientOptions) error {
+		o.header.Add(key, value)
+		return nil
+	}
+}
+
+func WithBasicAuth(username, password string) Option {
+	return func(o *clientOptions) error {
+		auth := base64.StdEncoding.EncodeToString([]byte(username + ":" + password))
+		o.header.Set("Authorization", "Basic "+auth)
+		return nil
+	}
+}
+
+func WithUserAgent(userAgent string) Option {
+	return func(o *clientOptions) error {
+		o.header.Set("User-Agent", userAgent)
+		return nil
+	}
+}
+
+// WithKeepAlive sets the idle connection limits: maxIdle in total, maxIdlePerHost
+// for the node and how long an idle connection is kept.
+func WithKeepAlive(maxIdle, maxIdlePerHost int, idleTimeout time.Duration) Option {
+	return func(o *clientOptions) error {
+		if maxIdle < 0 || maxIdlePerHost < 0 || idleTimeout < 0 {
+			return errors.New("mvs_api: negative keep-alive limit")
+		}
+		o.maxIdleConns = maxIdle
+		o.maxIdlePerHost = maxIdlePerHost
+		o.idleConnTimeout = idleTimeout
+		return nil
+	}
+}
+
+// WithoutKeepAlive opens a new connection for every request.
+func WithoutKeepAlive() Option {
+	return func(o *clientOptions) error {
+		o.noKeepAlives = true
+		return nil
+	}
+}
+
+// WithHTTPClient uses client as is; the timeout, TLS, proxy and keep-alive
+// options are then ignored.
+func WithHTTPClient(client *http.Client) Option {
+	return func(o *clientOptions) error {
+		o.httpClient = client
+		return nil
+	}
+}
+
+// WithTransport replaces HTTP altogether; all HTTP options are then ignored.
+func WithTransport(t Transport) Option {
+	return func(o *clientOptions) error {
+		o.transport = t
+		return nil
+	}
+}
+
+func WithHealthPolicy(p HealthPolicy) Option {
+	return func(o *clientOptions) error {
+		o.health = &p
+		return nil
+	}
+}
+
+// WithRetryPolicy retries failed read-only calls, see RetryPolicy. Retries
+// are off by default.
+func WithRetryPolicy(p RetryPolicy) Option {
+	return func(o *clientOptions) error {
+		o.retry = p
+		return nil
+	}
+}
+
+func WithLogger(l *log.Logger) Option {
+	return func(o *clientOptions) error {
+		o.logger = l
+		return nil
+	}
+}
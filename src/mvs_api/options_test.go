@@ -0,0 +1,109 @@
+package mvs_api_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"mvs_api"
+)
+
+func TestNewErrors(t *testing.T) {
+	tests := []struct {
+		url  string
+		opts []mvs_api.Option
+		err  string
+	}{
+		{"http://127.0.0.1:8820/rpc/v2", nil, ""},
+		{"https://node.example/rpc/v2", nil, ""},
+		{"127.0.0.1:8820", nil, "first path segment"},
+		{"ftp://127.0.0.1/rpc/v2", nil, "unsupported url scheme"},
+		{"http:", nil, "no host"},
+		{"http:///rpc/v2", nil, "no host"},
+		{"http://%zz/", nil, "invalid URL escape"},
+		{"http://127.0.0.1:8820/rpc/v2", []mvs_api.Option{mvs_api.WithTimeout(-time.Second)}, "negative timeout"},
+		{"http://127.0.0.1:8820/rpc/v2", []mvs_api.Option{mvs_api.WithTimeout(0)}, ""},
+		{"http://127.0.0.1:8820/rpc/v2", []mvs_api.Option{mvs_api.WithKeepAlive(-1, 0, 0)}, "negative keep-alive"},
+		{"http://127.0.0.1:8820/rpc/v2", []mvs_api.Option{mvs_api.WithKeepAlive(0, -1, 0)}, "negative keep-alive"},
+		{"http://127.0.0.1:8820/rpc/v2", []mvs_api.Option{mvs_api.WithKeepAlive(0, 0, -time.Second)}, "negative keep-alive"},
+		{"http://127.0.0.1:8820/rpc/v2", []mvs_api.Option{mvs_api.WithKeepAlive(10, 2, time.Minute)}, ""},
+		{"http://127.0.0.1:8820/rpc/v2", []mvs_api.Option{mvs_api.WithProxy("http://proxy:3128")}, ""},
+		{"http://127.0.0.1:8820/rpc/v2", []mvs_api.Option{mvs_api.WithProxy("socks5://127.0.0.1:1080")}, ""},
+		{"http://127.0.0.1:8820/rpc/v2", []mvs_api.Option{mvs_api.WithProxy("proxy:3128")}, "unsupported proxy url scheme"},
+		{"http://127.0.0.1:8820/rpc/v2", []mvs_api.Option{mvs_api.WithProxy("http://")}, "no host in proxy url"},
+		{"http://127.0.0.1:8820/rpc/v2", []mvs_api.Option{mvs_api.WithProxy("http://a b")}, "bad proxy url"},
+		{"http://127.0.0.1:8820/rpc/v2", []mvs_api.Option{mvs_api.WithProxy("")}, "unsupported proxy url scheme"},
+		// Another transport does not need an HTTP url.
+		{"unix:///var/run/mvsd.sock", []mvs_api.Option{mvs_api.WithTransport(mvs_api.NewUnixSocketTransport("/var/run/mvsd.sock", 0))}, ""},
+	}
+	for _, tt := range tests {
+		r, err := mvs_api.New(tt.url, tt.opts...)
+		if tt.err == "" {
+			if err != nil || r == nil {
+				t.Errorf("New(%q): %v", tt.url, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tt.err) {
+			t.Errorf("New(%q): err = %v, want %q", tt.url, err, tt.err)
+		}
+	}
+}
+
+func TestOptionsHeaders(t *testing.T) {
+	var got http.Header
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Clone()
+		w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":5}`))
+	}))
+	defer srv.Close()
+
+	r, err := mvs_api.New(srv.URL+"/rpc/v2",
+		mvs_api.WithHeader("X-Trace", "a"),
+		mvs_api.WithHeader("X-Trace", "b"),
+		mvs_api.WithBasicAuth("Alice", "A123456"),
+		mvs_api.WithUserAgent("wallet/1.0"),
+		mvs_api.WithTimeout(time.Second),
+		mvs_api.WithoutKeepAlive(),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if height, err := r.Getheight("", ""); err != nil || height != 5 {
+		t.Fatalf("getheight = %d, %v", height, err)
+	}
+	if v := got["X-Trace"]; len(v) != 2 || v[0] != "a" || v[1] != "b" {
+		t.Errorf("X-Trace = %v", v)
+	}
+	if got.Get("Authorization") != "Basic QWxpY2U6QTEyMzQ1Ng==" || got.Get("User-Agent") != "wallet/1.0" {
+		t.Errorf("headers = %v", got)
+	}
+	if got.Get("Content-Type") != "application/json" {
+		t.Errorf("Content-Type = %q", got.Get("Content-Type"))
+	}
+}
+
+func TestOptionsHTTPClient(t *testing.T) {
+	calls := 0
+	client := &http.Client{Transport: roundTripper(func(req *http.Request) (*http.Response, error) {
+		calls++
+		rec := httptest.NewRecorder()
+		rec.WriteString(`{"jsonrpc":"2.0","id":1,"result":7}`)
+		return rec.Result(), nil
+	})}
+	r, err := mvs_api.New("http://node/rpc/v2", mvs_api.WithHTTPClient(client), mvs_api.WithProxy("http://ignored:1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if height, err := r.Getheight("", ""); err != nil || height != 7 || calls != 1 {
+		t.Errorf("getheight = %d, %v after %d calls", height, err, calls)
+	}
+}
+
+type roundTripper func(*http.Request) (*http.Response, error)
+
+func (f roundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
@@ -0,0 +1,384 @@
+package mvs_api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+var ErrNoHealthyNode = errors.New("mvs_api: no healthy node in pool")
+
+type Strategy int
+
+const (
+	RoundRobin Strategy = iota
+	// LeastLatency prefers the node that answered fastest so far.
+	LeastLatency
+	// HighestHeight prefers the node with the highest block, as last seen
+	// by Probe or Watch. Heights are not read on calls, so without either
+	// the nodes are simply taken in turn.
+	HighestHeight
+)
+
+// readOnlyMethods never change node or wallet state, so they may be sent
+// to any node and repeated on another one after a transport failure. Calls
+// that take an account, such as fetchheaderext, are not among them even when
+// they only read: they must reach the node holding the wallet.
+var readOnlyMethods = map[string]bool{
+	"decoderawtx":     true,
+	"getaddressasset": true,
+	"getaddressetp":   true,
+	"getasset":        true,
+	"getblock":        true,
+	"getblockheader":  true,
+	"getdid":          true,
+	"getheight":       true,
+	"getinfo":         true,
+	"getmemorypool":   true,
+	"getmininginfo":   true,
+	"getmit":          true,
+	"getpeerinfo":     true,
+	"gettx":           true,
+	"validateaddress": true,
+}
+
+// nodeMethods act on one particular node, so the pool sends them to its
+// primary node only.
+var nodeMethods = map[string]bool{
+	"addnode":    true,
+	"getwork":    true,
+	"popblock":   true,
+	"shutdown":   true,
+	"stopmining": true,
+	"submitwork": true,
+}
+
+type poolNode struct {
+	client  *RPCClient
+	latency time.Duration
+	height  uint64
+}
+
+// RPCPool spreads calls over several nodes. It embeds an RPCClient, so every
+// method of RPCClient is available on the pool:
+//
+//   - read-only calls go to a healthy node picked by the strategy and fail
+//     over to the next one on transport and server errors;
+//   - calls taking an account go to the node holding that wallet, see
+//     PinAccount, and are never repeated;
+//   - node administration goes to the primary node, the first one given;
+//   - both fail with ErrUnhealthy while their node is sick.
+type RPCPool struct {
+	*RPCClient
+	mu       sync.RWMutex
+	nodes    []*poolNode
+	wallets  map[string]*poolNode
+	strategy Strategy
+	next     uint32
+}
+
+func NewRPCPool(strategy Strategy, clients ...*RPCClient) *RPCPool {
+	p := &RPCPool{
+		strategy: strategy,
+		wallets:  map[string]*poolNode{},
+	}
+	for _, c := range clients {
+		p.nodes = append(p.nodes, &poolNode{client: c})
+	}
+	p.RPCClient = &RPCClient{pool: p}
+	if len(clients) > 0 {
+		p.RPCClient.Url = clients[0].Url
+	}
+	return p
+}
+
+// PinAccount routes every call for account to client, which must be one of
+// the pool nodes and hold the account wallet.
+func (p *RPCPool) PinAccount(account string, client *RPCClient) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, n := range p.nodes {
+		if n.client == client {
+			p.wallets[account] = n
+			return nil
+		}
+	}
+	return errors.New("mvs_api: client is not a member of the pool")
+}
+
+func (p *RPCPool) Nodes() []*RPCClient {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	clients := make([]*RPCClient, len(p.nodes))
+	for i, n := range p.nodes {
+		clients[i] = n.client
+	}
+	return clients
+}
+
+// Healthy reports whether at least one node is healthy.
+func (p *RPCPool) Healthy() bool {
+	for _, c := range p.Nodes() {
+		if c.Healthy() {
+			return true
+		}
+	}
+	return false
+}
+
+// State is the best state of the pool nodes, Sick if there are none.
+func (p *RPCPool) State() HealthState {
+	return p.Stats().State
+}
+
+// Stats adds up the counters of all nodes. State is the best node state.
+func (p *RPCPool) Stats() HealthStats {
+	stats := HealthStats{State: Sick}
+	for _, c := range p.Nodes() {
+		s := c.Stats()
+		if s.State == Healthy || (s.State == HalfOpen && stats.State == Sick) {
+			stats.State = s.State
+		}
+		stats.Requests += s.Requests
+		stats.Failures += s.Failures
+		if s.LastFailure.After(stats.LastFailure) {
+			stats.LastFailure = s.LastFailure
+			stats.LastError = s.LastError
+		}
+	}
+	return stats
+}
+
+func (p *RPCPool) SetHealthPolicy(policy HealthPolicy) {
+	for _, c := range p.Nodes() {
+		c.SetHealthPolicy(policy)
+	}
+}
+
+// Probe sends Getheight to every node that is not waiting out its
+// ProbeInterval. Half-open nodes are brought back to health this way, and
+// the heights used by the HighestHeight strategy are refreshed.
+func (p *RPCPool) Probe(ctx context.Context) error {
+	p.mu.RLock()
+	nodes := append([]*poolNode(nil), p.nodes...)
+	p.mu.RUnlock()
+
+	var wg sync.WaitGroup
+	for _, n := range nodes {
+		wg.Add(1)
+		go func(n *poolNode) {
+			defer wg.Done()
+			c := n.client
+			if c.State() == Sick {
+				return
+			}
+			height, err := c.probe(ctx)
+			if err != nil {
+				return
+			}
+			p.mu.Lock()
+			n.height = height
+			p.mu.Unlock()
+		}(n)
+	}
+	wg.Wait()
+	return ctx.Err()
+}
+
+// Watch probes the pool every interval until ctx is done.
+func (p *RPCPool) Watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			p.Probe(ctx)
+		}
+	}
+}
+
+func (p *RPCPool) doPost(ctx context.Context, method string, params interface{}) (*JSONRpcResp, error) {
+	if account := accountOf(method, params); account != "" {
+		return p.sendPinned(ctx, p.walletNode(account), method, params)
+	}
+	if nodeMethods[method] {
+		return p.sendPinned(ctx, p.primary(), method, params)
+	}
+
+	candidates := p.candidates()
+	if len(candidates) == 0 {
+		return nil, ErrNoHealthyNode
+	}
+	if !readOnlyMethods[method] {
+		return p.send(ctx, candidates[0], method, params)
+	}
+	var err error
+	for _, n := range candidates {
+		var resp *JSONRpcResp
+		resp, err = p.send(ctx, n, method, params)
+		class := Classify(err)
+		if class != ErrorClassTransport && class != ErrorClassServer && !errors.Is(err, ErrUnhealthy) {
+			return resp, err
+		}
+	}
+	return nil, err
+}
+
+// sendPinned sends a call that only n can take. A sick node is not tried;
+// a half-open one is, as the probe it is waiting for.
+func (p *RPCPool) sendPinned(ctx context.Context, n *poolNode, method string, params interface{}) (*JSONRpcResp, error) {
+	if n != nil && n.client.State() == Sick {
+		return nil, fmt.Errorf("%w: %s", ErrUnhealthy, n.client.Url)
+	}
+	return p.send(ctx, n, method, params)
+}
+
+func (p *RPCPool) send(ctx context.Context, n *poolNode, method string, params interface{}) (*JSONRpcResp, error) {
+	if n == nil {
+		return nil, ErrNoHealthyNode
+	}
+	start := time.Now()
+	resp, err := n.client.attempt(ctx, n.client.Url, method, params)
+	class := Classify(err)
+	if class == ErrorClassNone || class == ErrorClassApplication {
+		elapsed := time.Since(start)
+		p.mu.Lock()
+		if n.latency == 0 {
+			n.latency = elapsed
+		} else {
+			n.latency = (n.latency*7 + elapsed) / 8
+		}
+		p.mu.Unlock()
+	}
+	return resp, err
+}
+
+func (p *RPCPool) primary() *poolNode {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if len(p.nodes) == 0 {
+		return nil
+	}
+	return p.nodes[0]
+}
+
+func (p *RPCPool) walletNode(account string) *poolNode {
+	p.mu.RLock()
+	n, ok := p.wallets[account]
+	p.mu.RUnlock()
+	if ok {
+		return n
+	}
+	return p.primary()
+}
+
+// candidates returns the nodes worth trying, best first: the healthy ones
+// in strategy order, then the half-open ones, which serve as probes.
+func (p *RPCPool) candidates() []*poolNode {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	var healthy, halfOpen []*poolNode
+	for _, n := range p.nodes {
+		switch n.client.State() {
+		case Healthy:
+			healthy = append(healthy, n)
+		case HalfOpen:
+			halfOpen = append(halfOpen, n)
+		}
+	}
+	if len(healthy) > 0 {
+		start := int(atomic.AddUint32(&p.next, 1)-1) % len(healthy)
+		rotated := make([]*poolNode, 0, len(healthy))
+		healthy = append(append(rotated, healthy[start:]...), healthy[:start]...)
+	}
+	switch p.strategy {
+	case LeastLatency:
+		sort.SliceStable(healthy, func(i, j int) bool {
+			return healthy[i].latency < healthy[j].latency
+		})
+	case HighestHeight:
+		sort.SliceStable(healthy, func(i, j int) bool {
+			return healthy[i].height > healthy[j].height
+		})
+	}
+	return append(healthy, halfOpen...)
+}
+
+// accountOf returns the account a call works on, or "" for calls that do
+// not need a wallet.
+func accountOf(method string, params interface{}) string {
+	args, ok := params.([]interface{})
+	if !ok || len(args) == 0 {
+		return ""
+	}
+	if method == "importaccount" {
+		if optional, ok := args[len(args)-1].(map[string]interface{}); ok {
+			account, _ := optional["accountname"].(string)
+			return account
+		}
+		return ""
+	}
+	if !accountMethods[method] {
+		return ""
+	}
+	account, _ := args[0].(string)
+	return account
+}
+
+// accountMethods take ACCOUNTNAME as their first positional parameter.
+var accountMethods = map[string]bool{
+	"burn":             true,
+	"changepasswd":     true,
+	"createasset":      true,
+	"createmultisigtx": true,
+	"deleteaccount":    true,
+	"deletelocalasset": true,
+	"deletemultisig":   true,
+	"deposit":          true,
+	"didchangeaddress": true,
+	"didsend":          true,
+	"didsendasset":     true,
+	"didsendassetfrom": true,
+	"didsendfrom":      true,
+	"didsendmore":      true,
+	"dumpkeyfile":      true,
+	"fetchheaderext":   true,
+	"getaccount":       true,
+	"getaccountasset":  true,
+	"getbalance":       true,
+	"getnewaccount":    true,
+	"getnewaddress":    true,
+	"getnewmultisig":   true,
+	"getpublickey":     true,
+	"importkeyfile":    true,
+	"issue":            true,
+	"issuecert":        true,
+	"listaddresses":    true,
+	"listassets":       true,
+	"listbalances":     true,
+	"listdids":         true,
+	"listmits":         true,
+	"listmultisig":     true,
+	"listtxs":          true,
+	"registerdid":      true,
+	"registermit":      true,
+	"secondaryissue":   true,
+	"send":             true,
+	"sendasset":        true,
+	"sendassetfrom":    true,
+	"sendfrom":         true,
+	"sendmore":         true,
+	"setminingaccount": true,
+	"signmultisigtx":   true,
+	"signrawtx":        true,
+	"startmining":      true,
+	"transfercert":     true,
+	"transfermit":      true,
+}
@@ -0,0 +1,137 @@
+package mvs_api_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"mvs_api"
+	"mvs_api/mvstest"
+)
+
+// sicken makes c sick by calling a server that is gone.
+func sicken(t *testing.T, c *mvs_api.RPCClient, s *mvstest.Server) {
+	t.Helper()
+	c.SetHealthPolicy(mvs_api.HealthPolicy{SickThreshold: 1, ProbeInterval: time.Hour})
+	s.Close()
+	c.Getbalance("Alice", "A123456")
+	if c.State() != mvs_api.Sick {
+		t.Fatalf("state = %v, want sick", c.State())
+	}
+}
+
+func TestPoolWalletNodeUnhealthy(t *testing.T) {
+	primary, other := mvstest.NewServer(), mvstest.NewServer()
+	defer other.Close()
+	a, b := primary.Client(), other.Client()
+	p := mvs_api.NewRPCPool(mvs_api.RoundRobin, a, b)
+	sicken(t, a, primary)
+
+	if _, err := p.Getbalance("Alice", "A123456"); !errors.Is(err, mvs_api.ErrUnhealthy) {
+		t.Errorf("wallet call on sick primary: err = %v, want ErrUnhealthy", err)
+	}
+	if _, err := p.Shutdown("", ""); !errors.Is(err, mvs_api.ErrUnhealthy) {
+		t.Errorf("node call on sick primary: err = %v, want ErrUnhealthy", err)
+	}
+	if len(other.Calls("getbalance")) != 0 {
+		t.Error("wallet call went to another node")
+	}
+
+	if err := p.PinAccount("Alice", b); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := p.Getbalance("Alice", "A123456"); err != nil {
+		t.Errorf("wallet call on healthy pinned node: %v", err)
+	}
+	if _, err := p.Getheight("", ""); err != nil {
+		t.Errorf("read-only call: %v", err)
+	}
+}
+
+func TestPoolState(t *testing.T) {
+	s1, s2 := mvstest.NewServer(), mvstest.NewServer()
+	a, b := s1.Client(), s2.Client()
+	p := mvs_api.NewRPCPool(mvs_api.RoundRobin, a, b)
+	if p.State() != mvs_api.Healthy {
+		t.Fatalf("state = %v, want healthy", p.State())
+	}
+	sicken(t, a, s1)
+	if p.State() != mvs_api.Healthy {
+		t.Errorf("state with one healthy node = %v, want healthy", p.State())
+	}
+	sicken(t, b, s2)
+	if p.State() != mvs_api.Sick || p.Healthy() {
+		t.Errorf("state with no healthy node = %v, want sick", p.State())
+	}
+}
+
+func TestPoolFailover(t *testing.T) {
+	s1, s2 := mvstest.NewServer(), mvstest.NewServer()
+	defer s1.Close()
+	defer s2.Close()
+	p := mvs_api.NewRPCPool(mvs_api.RoundRobin, s1.Client(), s2.Client())
+	s1.Fail("getheight", errBroken)
+	s1.Fail("sendrawtx", errBroken)
+	s2.Respond("getheight", 42)
+
+	for i := 0; i < 4; i++ {
+		height, err := p.Getheight("", "")
+		if err != nil || height != 42 {
+			t.Fatalf("getheight = %d, %v; want it from the healthy node", height, err)
+		}
+	}
+	if len(s1.Calls("getheight")) == 0 {
+		t.Error("the broken node was never tried")
+	}
+
+	// Calls that may have acted are not repeated on another node.
+	s1.Reset()
+	s1.Fail("sendrawtx", errBroken)
+	sent := 0
+	for i := 0; i < 4; i++ {
+		if _, err := p.Sendrawtx("0400", 0); err == nil {
+			sent++
+		}
+	}
+	if n := len(s1.Calls("sendrawtx")) + len(s2.Calls("sendrawtx")); n != 4 || sent != len(s2.Calls("sendrawtx")) {
+		t.Errorf("4 sendrawtx made %d calls, %d succeeded", n, sent)
+	}
+
+	// Account calls stay on the wallet node.
+	s1.Fail("getbalance", errBroken)
+	if _, err := p.Getbalance("Alice", "A123456"); err == nil {
+		t.Error("account call failed over")
+	}
+	if len(s2.Calls("getbalance")) != 0 || len(s2.Calls("fetchheaderext")) != 0 {
+		t.Error("account call reached another node")
+	}
+	p.Fetchheaderext("Alice", "A123456", "latest")
+	if len(s2.Calls("fetchheaderext")) != 0 {
+		t.Error("fetchheaderext went to a node without the wallet")
+	}
+}
+
+func TestPoolHighestHeight(t *testing.T) {
+	servers := []*mvstest.Server{mvstest.NewServer(), mvstest.NewServer(), mvstest.NewServer()}
+	var clients []*mvs_api.RPCClient
+	for i, s := range servers {
+		defer s.Close()
+		s.Respond("getheight", 100+i*10)
+		clients = append(clients, s.Client())
+	}
+	servers[1].Respond("getheight", 500)
+	p := mvs_api.NewRPCPool(mvs_api.HighestHeight, clients...)
+	if err := p.Probe(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range servers {
+		s.Reset()
+	}
+	for i := 0; i < 3; i++ {
+		p.Getblock("1", true, false)
+	}
+	if n := len(servers[1].Calls("getblock")); n != 3 {
+		t.Errorf("the highest node got %d of 3 calls", n)
+	}
+}
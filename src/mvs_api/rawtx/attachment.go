@@ -0,0 +1,218 @@
+package rawtx
+
+import (
+	"fmt"
+)
+
+// Attachment types.
+const (
+	TypeETP      uint32 = 0
+	TypeETPAward uint32 = 1
+	TypeAsset    uint32 = 2
+	TypeMessage  uint32 = 3
+	TypeDID      uint32 = 4
+	TypeCert     uint32 = 5
+	TypeMIT      uint32 = 6
+)
+
+// DIDVersion is the attachment version that carries ToDID and FromDID.
+const DIDVersion uint32 = 207
+
+// Statuses of asset and DID attachments.
+const (
+	StatusDetail   uint32 = 1 // asset issue, DID register
+	StatusTransfer uint32 = 2
+)
+
+// Statuses of MIT attachments.
+const (
+	MITRegister byte = 1
+	MITTransfer byte = 2
+)
+
+// Attachment is what an output carries besides its ETP value. The field
+// for Type is set; the others are nil.
+type Attachment struct {
+	Version uint32
+	Type    uint32
+	ToDID   string // DIDVersion only
+	FromDID string // DIDVersion only
+
+	ETP     *ETP
+	Award   *ETPAward
+	Asset   *Asset
+	Message *Message
+	DID     *DID
+	Cert    *Cert
+	MIT     *MIT
+}
+
+type ETP struct {
+	Value uint64
+}
+
+// ETPAward is the reward of a coinbase output.
+type ETPAward struct {
+	Height uint64
+}
+
+// Asset is an issue when Status is StatusDetail and a transfer when it is
+// StatusTransfer.
+type Asset struct {
+	Status   uint32
+	Detail   *AssetDetail
+	Transfer *AssetTransfer
+}
+
+type AssetDetail struct {
+	Symbol        string
+	MaximumSupply uint64
+	DecimalNumber uint8
+	// SecondaryIssueThreshold is followed by two unused bytes, kept so the
+	// output round-trips.
+	SecondaryIssueThreshold uint8
+	Unused                  uint16
+	Issuer                  string
+	Address                 string
+	Description             string
+}
+
+type AssetTransfer struct {
+	Symbol   string
+	Quantity uint64
+}
+
+type Message struct {
+	Content string
+}
+
+type DID struct {
+	Status  uint32
+	Symbol  string
+	Address string
+}
+
+type Cert struct {
+	Symbol   string
+	Owner    string
+	Address  string
+	CertType uint32
+	Status   uint8
+}
+
+// MIT has Content only when it is registered.
+type MIT struct {
+	Status  uint8
+	Symbol  string
+	Address string
+	Content string
+}
+
+func (r *reader) attachment() Attachment {
+	a := Attachment{Version: r.uint32(), Type: r.uint32()}
+	if a.Version == DIDVersion {
+		a.ToDID = r.string()
+		a.FromDID = r.string()
+	}
+	switch a.Type {
+	case TypeETP:
+		a.ETP = &ETP{Value: r.uint64()}
+	case TypeETPAward:
+		a.Award = &ETPAward{Height: r.uint64()}
+	case TypeAsset:
+		a.Asset = &Asset{Status: r.uint32()}
+		switch a.Asset.Status {
+		case StatusDetail:
+			a.Asset.Detail = &AssetDetail{
+				Symbol:                  r.string(),
+				MaximumSupply:           r.uint64(),
+				DecimalNumber:           r.byte(),
+				SecondaryIssueThreshold: r.byte(),
+				Unused:                  r.uint16(),
+				Issuer:                  r.string(),
+				Address:                 r.string(),
+				Description:             r.string(),
+			}
+		case StatusTransfer:
+			a.Asset.Transfer = &AssetTransfer{Symbol: r.string(), Quantity: r.uint64()}
+		default:
+			r.fail("unknown asset status %d", a.Asset.Status)
+		}
+	case TypeMessage:
+		a.Message = &Message{Content: r.string()}
+	case TypeDID:
+		a.DID = &DID{Status: r.uint32(), Symbol: r.string(), Address: r.string()}
+	case TypeCert:
+		a.Cert = &Cert{Symbol: r.string(), Owner: r.string(), Address: r.string(), CertType: r.uint32(), Status: r.byte()}
+	case TypeMIT:
+		a.MIT = &MIT{Status: r.byte(), Symbol: r.string(), Address: r.string()}
+		if a.MIT.Status != MITTransfer {
+			a.MIT.Content = r.string()
+		}
+	default:
+		r.fail("unknown attachment type %d", a.Type)
+	}
+	return a
+}
+
+func (r *reader) fail(format string, args ...interface{}) {
+	if r.err == nil {
+		r.err = fmt.Errorf("rawtx: "+format, args...)
+	}
+}
+
+func (w *writer) attachment(a Attachment) error {
+	w.uint32(a.Version)
+	w.uint32(a.Type)
+	if a.Version == DIDVersion {
+		w.string(a.ToDID)
+		w.string(a.FromDID)
+	}
+	switch {
+	case a.Type == TypeETP && a.ETP != nil:
+		w.uint64(a.ETP.Value)
+	case a.Type == TypeETPAward && a.Award != nil:
+		w.uint64(a.Award.Height)
+	case a.Type == TypeAsset && a.Asset != nil:
+		w.uint32(a.Asset.Status)
+		switch {
+		case a.Asset.Status == StatusDetail && a.Asset.Detail != nil:
+			d := a.Asset.Detail
+			w.string(d.Symbol)
+			w.uint64(d.MaximumSupply)
+			w.buf.WriteByte(d.DecimalNumber)
+			w.buf.WriteByte(d.SecondaryIssueThreshold)
+			w.uint16(d.Unused)
+			w.string(d.Issuer)
+			w.string(d.Address)
+			w.string(d.Description)
+		case a.Asset.Status == StatusTransfer && a.Asset.Transfer != nil:
+			w.string(a.Asset.Transfer.Symbol)
+			w.uint64(a.Asset.Transfer.Quantity)
+		default:
+			return fmt.Errorf("asset status %d without its detail or transfer", a.Asset.Status)
+		}
+	case a.Type == TypeMessage && a.Message != nil:
+		w.string(a.Message.Content)
+	case a.Type == TypeDID && a.DID != nil:
+		w.uint32(a.DID.Status)
+		w.string(a.DID.Symbol)
+		w.string(a.DID.Address)
+	case a.Type == TypeCert && a.Cert != nil:
+		w.string(a.Cert.Symbol)
+		w.string(a.Cert.Owner)
+		w.string(a.Cert.Address)
+		w.uint32(a.Cert.CertType)
+		w.buf.WriteByte(a.Cert.Status)
+	case a.Type == TypeMIT && a.MIT != nil:
+		w.buf.WriteByte(a.MIT.Status)
+		w.string(a.MIT.Symbol)
+		w.string(a.MIT.Address)
+		if a.MIT.Status != MITTransfer {
+			w.string(a.MIT.Content)
+		}
+	default:
+		return fmt.Errorf("attachment type %d without its value", a.Type)
+	}
+	return nil
+}
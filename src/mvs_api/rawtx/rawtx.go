@@ -0,0 +1,304 @@
+// Package rawtx decodes and encodes MVS transactions in the serialization
+// Createrawtx, Signrawtx and Createmultisigtx return and Sendrawtx takes, so
+// a transaction can be inspected before it is sent:
+//
+//	tx, err := rawtx.Decode(hexTx)
+//	...
+//	for _, out := range tx.Outputs {
+//		fmt.Println(out.Address(false), out.Value, out.Attachment.Type)
+//	}
+//
+// Encoding a decoded transaction gives back the same bytes. Inputs can be
+// signed offline too, with keys that mvsd does not hold:
+//
+//	err := tx.SignP2PKH(prevScripts, key)
+//	signed, err := tx.Hex()
+//	hash, err := r.Sendrawtx(signed, 0)
+package rawtx
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"mvs_api/address"
+)
+
+var (
+	ErrTruncated    = errors.New("rawtx: truncated transaction")
+	ErrTrailingData = errors.New("rawtx: data after lock time")
+	ErrNonCanonical = errors.New("rawtx: non-canonical length")
+)
+
+// maxCount bounds the number of inputs, outputs and script bytes read, so
+// that a bad length cannot make Decode allocate gigabytes.
+const maxCount = 1 << 22
+
+type Tx struct {
+	Version  uint32
+	Inputs   []Input
+	Outputs  []Output
+	LockTime uint32
+}
+
+type Input struct {
+	// PrevHash is the hash of the spent transaction in the byte order of
+	// the serialization, the reverse of how the node prints it.
+	PrevHash  [32]byte
+	PrevIndex uint32
+	Script    []byte
+	Sequence  uint32
+}
+
+type Output struct {
+	Value      uint64
+	Script     []byte
+	Attachment Attachment
+}
+
+// Decode parses a hex encoded transaction.
+func Decode(s string) (*Tx, error) {
+	data, err := hex.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("rawtx: %v", err)
+	}
+	return DecodeBytes(data)
+}
+
+// DecodeBytes parses a serialized transaction. All of data must be used.
+func DecodeBytes(data []byte) (*Tx, error) {
+	r := &reader{data: data}
+	tx := &Tx{Version: r.uint32()}
+	n := r.count()
+	for i := uint64(0); i < n && r.err == nil; i++ {
+		var in Input
+		r.read(in.PrevHash[:])
+		in.PrevIndex = r.uint32()
+		in.Script = r.bytes()
+		in.Sequence = r.uint32()
+		tx.Inputs = append(tx.Inputs, in)
+	}
+	n = r.count()
+	for i := uint64(0); i < n && r.err == nil; i++ {
+		var out Output
+		out.Value = r.uint64()
+		out.Script = r.bytes()
+		out.Attachment = r.attachment()
+		tx.Outputs = append(tx.Outputs, out)
+	}
+	tx.LockTime = r.uint32()
+	if r.err != nil {
+		return nil, r.err
+	}
+	if len(r.data) != 0 {
+		return nil, ErrTrailingData
+	}
+	return tx, nil
+}
+
+// Encode serializes tx.
+func (tx *Tx) Encode() ([]byte, error) {
+	w := &writer{}
+	w.uint32(tx.Version)
+	w.count(uint64(len(tx.Inputs)))
+	for _, in := range tx.Inputs {
+		w.buf.Write(in.PrevHash[:])
+		w.uint32(in.PrevIndex)
+		w.bytes(in.Script)
+		w.uint32(in.Sequence)
+	}
+	w.count(uint64(len(tx.Outputs)))
+	for i, out := range tx.Outputs {
+		w.uint64(out.Value)
+		w.bytes(out.Script)
+		if err := w.attachment(out.Attachment); err != nil {
+			return nil, fmt.Errorf("rawtx: output %d: %v", i, err)
+		}
+	}
+	w.uint32(tx.LockTime)
+	return w.buf.Bytes(), nil
+}
+
+// Hex serializes tx as Sendrawtx takes it.
+func (tx *Tx) Hex() (string, error) {
+	data, err := tx.Encode()
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(data), nil
+}
+
+// Hash is the transaction hash as the node prints it.
+func (tx *Tx) Hash() (string, error) {
+	data, err := tx.Encode()
+	if err != nil {
+		return "", err
+	}
+	first := sha256.Sum256(data)
+	hash := sha256.Sum256(first[:])
+	return hex.EncodeToString(reverse(hash[:])), nil
+}
+
+// PrevTx is the hash of the spent transaction as the node prints it.
+func (in *Input) PrevTx() string {
+	return hex.EncodeToString(reverse(in.PrevHash[:]))
+}
+
+// Address is the address a standard pay-to-public-key-hash or
+// pay-to-script-hash output pays to, or "" for other scripts.
+func (out *Output) Address(testnet bool) string {
+	s := out.Script
+	var hash [20]byte
+	switch {
+	case isP2PKH(s):
+		copy(hash[:], s[3:23])
+		return address.New(address.PubKeyHash, testnet, hash).String()
+	case len(s) == 23 && s[0] == 0xa9 && s[1] == 0x14 && s[22] == 0x87:
+		copy(hash[:], s[2:22])
+		return address.New(address.ScriptHash, testnet, hash).String()
+	}
+	return ""
+}
+
+func reverse(b []byte) []byte {
+	r := make([]byte, len(b))
+	for i := range b {
+		r[len(b)-1-i] = b[i]
+	}
+	return r
+}
+
+// reader consumes data, keeping the first error; reads after an error
+// return zero values.
+type reader struct {
+	data []byte
+	err  error
+}
+
+func (r *reader) read(p []byte) {
+	if r.err != nil {
+		return
+	}
+	if len(r.data) < len(p) {
+		r.err = ErrTruncated
+		r.data = nil
+		return
+	}
+	copy(p, r.data)
+	r.data = r.data[len(p):]
+}
+
+func (r *reader) byte() byte {
+	var b [1]byte
+	r.read(b[:])
+	return b[0]
+}
+
+func (r *reader) uint16() uint16 {
+	var b [2]byte
+	r.read(b[:])
+	return binary.LittleEndian.Uint16(b[:])
+}
+
+func (r *reader) uint32() uint32 {
+	var b [4]byte
+	r.read(b[:])
+	return binary.LittleEndian.Uint32(b[:])
+}
+
+func (r *reader) uint64() uint64 {
+	var b [8]byte
+	r.read(b[:])
+	return binary.LittleEndian.Uint64(b[:])
+}
+
+// varint reads a Bitcoin compact size, refusing encodings longer than
+// needed since they would not round-trip.
+func (r *reader) varint() uint64 {
+	var v, min uint64
+	switch b := r.byte(); b {
+	case 0xfd:
+		v, min = uint64(r.uint16()), 0xfd
+	case 0xfe:
+		v, min = uint64(r.uint32()), 0x10000
+	case 0xff:
+		v, min = r.uint64(), 0x100000000
+	default:
+		return uint64(b)
+	}
+	if v < min && r.err == nil {
+		r.err = ErrNonCanonical
+	}
+	return v
+}
+
+func (r *reader) count() uint64 {
+	n := r.varint()
+	if n > maxCount || n > uint64(len(r.data)) {
+		if r.err == nil {
+			r.err = ErrTruncated
+		}
+		return 0
+	}
+	return n
+}
+
+func (r *reader) bytes() []byte {
+	b := make([]byte, r.count())
+	r.read(b)
+	return b
+}
+
+func (r *reader) string() string {
+	return string(r.bytes())
+}
+
+type writer struct {
+	buf bytes.Buffer
+}
+
+func (w *writer) uint16(v uint16) {
+	var b [2]byte
+	binary.LittleEndian.PutUint16(b[:], v)
+	w.buf.Write(b[:])
+}
+
+func (w *writer) uint32(v uint32) {
+	var b [4]byte
+	binary.LittleEndian.PutUint32(b[:], v)
+	w.buf.Write(b[:])
+}
+
+func (w *writer) uint64(v uint64) {
+	var b [8]byte
+	binary.LittleEndian.PutUint64(b[:], v)
+	w.buf.Write(b[:])
+}
+
+func (w *writer) count(n uint64) {
+	switch {
+	case n < 0xfd:
+		w.buf.WriteByte(byte(n))
+	case n <= 0xffff:
+		w.buf.WriteByte(0xfd)
+		w.uint16(uint16(n))
+	case n <= 0xffffffff:
+		w.buf.WriteByte(0xfe)
+		w.uint32(uint32(n))
+	default:
+		w.buf.WriteByte(0xff)
+		w.uint64(n)
+	}
+}
+
+func (w *writer) bytes(b []byte) {
+	w.count(uint64(len(b)))
+	w.buf.Write(b)
+}
+
+func (w *writer) string(s string) {
+	w.bytes([]byte(s))
+}
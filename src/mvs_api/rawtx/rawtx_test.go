@@ -0,0 +1,283 @@
+package rawtx
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+// The transactions below spend from the first three addresses of the robot
+// account of test_api.go, MLasJF..., M9L3ip... and MP5FoY..., whose keys are
+// robotKeys. They were built and signed outside this package, by a separate
+// implementation of the mvsd serialization and of RFC 6979, so they check the
+// decoder and SignatureHash against something they did not produce
+// themselves.
+var robotKeys = []string{
+	"0380990a7312b87abda80e5857ee6ebf798a2bf62041b07111287d19926c429d11",
+	"035f572a164ec0e7d8f1935e357d86c6441152bed7251389a1c66174ae890dad90",
+	"028d6413e29808640ed5727546e62aec9a66f39465293db31eeaf2810314aa3ab2",
+}
+
+const (
+	// etpTransfer pays 1.5 ETP from MLasJF... to M9L3ip... out of 5 ETP
+	// in output 1 of 2a6fb3..., with the change back to MLasJF....
+	etpTransfer = "04000000015f6e7d8c9b0a1f2e3d4c5b6a7f8e9d0c1b2a3f4e5d6c7b8f9ea4d0c1e5b36f2a010000006a4730440220208dfe99e8714ff4c8ec7d8ea664dd0d7cfab4ee1de6960bd131fa8189d1423e022062970640c6c62d0a4aa63c1f90bfececbe8ad04c9285fc8bc9f0bd2830e6da6601210380990a7312b87abda80e5857ee6ebf798a2bf62041b07111287d19926c429d11ffffffff0280d1f008000000001976a9140fad171975f1100309022c83c7b9dca0a8f8e6b888ac010000000000000080d1f00800000000706cdc14000000001976a9148b24031888c2896cedb764012677868b5c64ef3b88ac0100000000000000706cdc140000000000000000"
+
+	// assetTransfer sends 25 of the 100 MVS.ZGC that M9L3ip... holds in
+	// output 0 of 5e0f3c... to MP5FoY..., with a message attachment,
+	// and pays the fee from 0.2 ETP in output 2 of c1d2e3....
+	assetTransfer = "04000000023a1b0d8e6c4a2f9b7d5e3c1a0f8b6d4e2c0a9f7b5d3e1c8a6f4e2d9b7a3c0f5e000000006b483045022100897213769a0c92f16e02c93fa9d5062a82f3680d9dd8af1f5fb89746543ba64d02202ab92e9d5617d550c108ce3e80f5f1b8304966304ac3a8fefefa272c566dd2550121035f572a164ec0e7d8f1935e357d86c6441152bed7251389a1c66174ae890dad90ffffffffb0af9e8d7c6b5a4938271605f4e3d2c1b0af9e8d7c6b5a4938271605f4e3d2c1020000006a47304402203e1c70ed95e5101e1e9bd7a6678af4ca4c122e149bdcd4ad366595b8868d24890220120db7ff844a41565aa91c130dcd1f6f959e1f1d3d91a067a29a8c98a468cbf30121035f572a164ec0e7d8f1935e357d86c6441152bed7251389a1c66174ae890dad90ffffffff0400000000000000001976a914a672b25c96da4a80a1f0f78d44515716e776d52a88ac010000000200000002000000074d56532e5a4743190000000000000000000000000000001976a9140fad171975f1100309022c83c7b9dca0a8f8e6b888ac010000000200000002000000074d56532e5a47434b0000000000000000000000000000001976a914a672b25c96da4a80a1f0f78d44515716e776d52a88ac010000000300000010696e766f69636520323031382d313137f0053101000000001976a9140fad171975f1100309022c83c7b9dca0a8f8e6b888ac0100000000000000f00531010000000000000000"
+
+	// multisigSpend pays 3 ETP, less the fee, from the 2 of 3 address of
+	// robotKeys, 3JCS8S..., to MLasJF..., signed by MLasJF... and
+	// MP5FoY....
+	multisigSpend = "0400000001a0b1c2d3e4f5061728394a5b6c7d8e9fa0b1c2d3e4f5061728394a5b6c7d8e9f00000000fdfe0000483045022100c3ba2364df04347270b0123c34bf2d473319cd6051c7e0a3b82698eede02e38902206f22887b35b31da93acc3525f25870584e2e5138efce829c2a032e7cebdff7a301483045022100f991a8287889212e3582016a575e1db6d3dcb8c1ea2180b66ea14e7445a574bf02207965aac2d9d0518322d1c4b59b2e8984f2bab8e351a5d3f23b7844dbe7111dfc014c695221028d6413e29808640ed5727546e62aec9a66f39465293db31eeaf2810314aa3ab221035f572a164ec0e7d8f1935e357d86c6441152bed7251389a1c66174ae890dad90210380990a7312b87abda80e5857ee6ebf798a2bf62041b07111287d19926c429d1153aeffffffff01f07be111000000001976a9148b24031888c2896cedb764012677868b5c64ef3b88ac0100000000000000f07be1110000000000000000"
+)
+
+// decode decodes s and checks that it encodes back to s.
+func decode(t *testing.T, s string) *Tx {
+	t.Helper()
+	tx, err := Decode(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := tx.Hex(); err != nil || got != s {
+		t.Fatalf("Hex = %s, %v; want %s", got, err, s)
+	}
+	if tx.Version != 4 || tx.LockTime != 0 {
+		t.Errorf("version %d, lock time %d", tx.Version, tx.LockTime)
+	}
+	return tx
+}
+
+// checkP2PKHInput checks that in spends output index of prev with a
+// signature and robotKeys[k].
+func checkP2PKHInput(t *testing.T, in Input, prev string, index uint32, k int) {
+	t.Helper()
+	if got := in.PrevTx(); got != prev || in.PrevIndex != index || in.Sequence != 0xffffffff {
+		t.Errorf("input spends %s:%d, sequence %x; want %s:%d", got, in.PrevIndex, in.Sequence, prev, index)
+	}
+	pushes, err := parsePushes(in.Script)
+	if err != nil || len(pushes) != 2 {
+		t.Fatalf("input script %x: %v", in.Script, err)
+	}
+	if sig := pushes[0]; sig[len(sig)-1] != SigHashAll {
+		t.Errorf("signature %x has no SigHashAll", sig)
+	}
+	if got := hex.EncodeToString(pushes[1]); got != robotKeys[k] {
+		t.Errorf("input key %s, want %s", got, robotKeys[k])
+	}
+}
+
+func checkOutput(t *testing.T, out Output, value uint64, addr string) {
+	t.Helper()
+	if out.Value != value || out.Address(false) != addr {
+		t.Errorf("output pays %d to %s, want %d to %s", out.Value, out.Address(false), value, addr)
+	}
+}
+
+func TestDecodeETPTransfer(t *testing.T) {
+	tx := decode(t, etpTransfer)
+	if len(tx.Inputs) != 1 || len(tx.Outputs) != 2 {
+		t.Fatalf("%d inputs, %d outputs", len(tx.Inputs), len(tx.Outputs))
+	}
+	checkP2PKHInput(t, tx.Inputs[0], "2a6fb3e5c1d0a49e8f7b6c5d4e3f2a1b0c9d8e7f6a5b4c3d2e1f0a9b8c7d6e5f", 1, 0)
+	checkOutput(t, tx.Outputs[0], 150000000, "M9L3ipy3Hcf6kdvknU3mH7mwH9ER3uCziu")
+	checkOutput(t, tx.Outputs[1], 349990000, "MLasJFxZQnA49XEvhTHmRKi2qstkj9ppjo")
+	for i, out := range tx.Outputs {
+		a := out.Attachment
+		if a.Version != 1 || a.Type != TypeETP || a.ETP == nil || a.ETP.Value != out.Value {
+			t.Errorf("output %d attachment %+v", i, a)
+		}
+	}
+	if got := tx.Outputs[0].Address(true); got != "t8MVYBvDxWLZj1fRfmhJcmjWjz53knmYaB" {
+		t.Errorf("testnet Address = %s", got)
+	}
+	if got, err := tx.Hash(); err != nil || got != "a56ee6949bddec8c3aeb051e97f5bb7654dd38907b123a75c87f021f467fbce9" {
+		t.Errorf("Hash = %s, %v", got, err)
+	}
+}
+
+func TestDecodeAssetTransfer(t *testing.T) {
+	tx := decode(t, assetTransfer)
+	if len(tx.Inputs) != 2 || len(tx.Outputs) != 4 {
+		t.Fatalf("%d inputs, %d outputs", len(tx.Inputs), len(tx.Outputs))
+	}
+	checkP2PKHInput(t, tx.Inputs[0], "5e0f3c7a9b2d4e6f8a1c3e5d7b9f0a2c4e6d8b0f1a3c5e7d9b2f4a6c8e0d1b3a", 0, 1)
+	checkP2PKHInput(t, tx.Inputs[1], "c1d2e3f405162738495a6b7c8d9eafb0c1d2e3f405162738495a6b7c8d9eafb0", 2, 1)
+	checkOutput(t, tx.Outputs[0], 0, "MP5FoYQHiEQ52pcEURkaYmuqZMnYHNAZ83")
+	checkOutput(t, tx.Outputs[1], 0, "M9L3ipy3Hcf6kdvknU3mH7mwH9ER3uCziu")
+	checkOutput(t, tx.Outputs[2], 0, "MP5FoYQHiEQ52pcEURkaYmuqZMnYHNAZ83")
+	checkOutput(t, tx.Outputs[3], 19990000, "M9L3ipy3Hcf6kdvknU3mH7mwH9ER3uCziu")
+	for i, quantity := range []uint64{25, 75} {
+		a := tx.Outputs[i].Attachment
+		if a.Version != 1 || a.Type != TypeAsset || a.Asset == nil || a.Asset.Status != StatusTransfer || a.Asset.Detail != nil {
+			t.Fatalf("output %d attachment %+v", i, a)
+		}
+		if got := *a.Asset.Transfer; got != (AssetTransfer{Symbol: "MVS.ZGC", Quantity: quantity}) {
+			t.Errorf("output %d transfers %+v", i, got)
+		}
+	}
+	if a := tx.Outputs[2].Attachment; a.Type != TypeMessage || a.Message == nil || a.Message.Content != "invoice 2018-117" {
+		t.Errorf("message attachment %+v", a)
+	}
+	if a := tx.Outputs[3].Attachment; a.Type != TypeETP || a.ETP == nil || a.ETP.Value != 19990000 {
+		t.Errorf("change attachment %+v", a)
+	}
+	if got, err := tx.Hash(); err != nil || got != "7ed72838a2647eec44dacbadf60082d44a839ecae64e84a6c5b915c9233a0254" {
+		t.Errorf("Hash = %s, %v", got, err)
+	}
+}
+
+func TestDecodeMultisigSpend(t *testing.T) {
+	tx := decode(t, multisigSpend)
+	if len(tx.Inputs) != 1 || len(tx.Outputs) != 1 {
+		t.Fatalf("%d inputs, %d outputs", len(tx.Inputs), len(tx.Outputs))
+	}
+	in := tx.Inputs[0]
+	if got := in.PrevTx(); got != "9f8e7d6c5b4a39281706f5e4d3c2b1a09f8e7d6c5b4a39281706f5e4d3c2b1a0" || in.PrevIndex != 0 {
+		t.Errorf("input spends %s:%d", got, in.PrevIndex)
+	}
+	pushes, err := parsePushes(in.Script)
+	if err != nil || len(pushes) != 4 || len(pushes[0]) != 0 {
+		t.Fatalf("input script %x: %v", in.Script, err)
+	}
+	redeem := pushes[3]
+	m, pubs, err := ParseMultisig(redeem)
+	if err != nil || m != 2 || len(pubs) != 3 {
+		t.Fatalf("redeem script %x: %d of %v, %v", redeem, m, pubs, err)
+	}
+	if addr, err := MultisigAddress(2, robotKeys, false); err != nil || addr != "3JCS8SE5PDJmjVrG9Em4cyMmyGjsKE2QDK" {
+		t.Errorf("MultisigAddress = %s, %v", addr, err)
+	}
+	signers, err := tx.MultisigSigners(0, redeem)
+	if err != nil || len(signers) != 2 || signers[0].String() != robotKeys[2] || signers[1].String() != robotKeys[0] {
+		t.Errorf("signers %v, %v; want MP5FoY... then MLasJF...", signers, err)
+	}
+	checkOutput(t, tx.Outputs[0], 299990000, "MLasJFxZQnA49XEvhTHmRKi2qstkj9ppjo")
+	if got, err := tx.Hash(); err != nil || got != "7a1b22785256f944b56d3da4893ee8453380adfa6e5293ecf766872320402d02" {
+		t.Errorf("Hash = %s, %v", got, err)
+	}
+}
+
+// allAttachments is a transaction with an output of every attachment kind.
+func allAttachments() *Tx {
+	tx := &Tx{Version: 4, LockTime: 7, Inputs: []Input{{PrevIndex: 1, Script: bytes.Repeat([]byte{1}, 300), Sequence: 5}}}
+	attachments := []Attachment{
+		{Version: 1, Type: TypeETP, ETP: &ETP{Value: 3}},
+		{Version: 1, Type: TypeETPAward, Award: &ETPAward{Height: 9}},
+		{Version: DIDVersion, ToDID: "BOB", FromDID: "ALICE", Type: TypeAsset, Asset: &Asset{Status: StatusDetail, Detail: &AssetDetail{Symbol: "ZGC", MaximumSupply: 5, DecimalNumber: 2, SecondaryIssueThreshold: 3, Unused: 0x0102, Issuer: "Alice", Address: "M", Description: "d"}}},
+		{Version: 1, Type: TypeAsset, Asset: &Asset{Status: StatusTransfer, Transfer: &AssetTransfer{Symbol: "ZGC", Quantity: 4}}},
+		{Version: 1, Type: TypeMessage, Message: &Message{Content: "hi"}},
+		{Version: 1, Type: TypeDID, DID: &DID{Status: StatusDetail, Symbol: "BIAM", Address: "M"}},
+		{Version: 1, Type: TypeCert, Cert: &Cert{Symbol: "ZGC", Owner: "Alice", Address: "M", CertType: 1, Status: 2}},
+		{Version: 1, Type: TypeMIT, MIT: &MIT{Status: MITRegister, Symbol: "MIT", Address: "M", Content: "c"}},
+		{Version: 1, Type: TypeMIT, MIT: &MIT{Status: MITTransfer, Symbol: "MIT", Address: "M"}},
+	}
+	p2sh := append(append([]byte{0xa9, 0x14}, make([]byte, 20)...), 0x87)
+	for _, a := range attachments {
+		tx.Outputs = append(tx.Outputs, Output{Value: 1, Script: p2sh, Attachment: a})
+	}
+	return tx
+}
+
+func TestRoundTrip(t *testing.T) {
+	tx := allAttachments()
+	data, err := tx.Encode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	back, err := DecodeBytes(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(back, tx) {
+		t.Errorf("decoded %+v, want %+v", back, tx)
+	}
+	// A script of 300 bytes takes a 3 byte length.
+	if i := 4 + 1 + 32 + 4; !bytes.Equal(data[i:i+3], []byte{0xfd, 0x2c, 0x01}) {
+		t.Errorf("script length encoded as %x", data[i:i+3])
+	}
+	if got := back.Outputs[0].Address(false); got != "31h1vYVSYuKP6AhS86fbRdMw9XHieotbST" {
+		t.Errorf("P2SH Address = %s", got)
+	}
+	if got := (&Output{Script: []byte{0x6a}}).Address(false); got != "" {
+		t.Errorf("Address of a non-standard script = %q", got)
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	data, err := allAttachments().Encode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for n := 0; n < len(data); n++ {
+		if _, err := DecodeBytes(data[:n]); err == nil {
+			t.Fatalf("decoded %d of %d bytes", n, len(data))
+		}
+	}
+	if _, err := DecodeBytes(data[:len(data)-1]); err != ErrTruncated {
+		t.Errorf("truncated: err = %v", err)
+	}
+	if _, err := DecodeBytes(append(data, 0)); err != ErrTrailingData {
+		t.Errorf("trailing byte: err = %v", err)
+	}
+	if _, err := Decode("04zz"); err == nil {
+		t.Error("decoded bad hex")
+	}
+
+	fixture, _ := hex.DecodeString(etpTransfer)
+	// One input, its count written in three bytes.
+	long := append(append(append([]byte(nil), fixture[:4]...), 0xfd, 0x01, 0x00), fixture[5:]...)
+	if _, err := DecodeBytes(long); err != ErrNonCanonical {
+		t.Errorf("non-canonical count: err = %v", err)
+	}
+	// A count of 2^32 inputs must fail without allocating them.
+	huge := append(append(append([]byte(nil), fixture[:4]...), 0xff, 0, 0, 0, 0, 1, 0, 0, 0), fixture[5:]...)
+	if _, err := DecodeBytes(huge); err != ErrTruncated {
+		t.Errorf("huge count: err = %v", err)
+	}
+	unknown := append([]byte(nil), fixture...)
+	unknown[len(unknown)-16] = 9 // attachment type
+	if _, err := DecodeBytes(unknown); err == nil {
+		t.Error("decoded an unknown attachment type")
+	}
+}
+
+func TestEncodeErrors(t *testing.T) {
+	bad := []Attachment{
+		{Type: TypeETP},
+		{Type: TypeAsset, Asset: &Asset{Status: StatusTransfer}},
+		{Type: TypeAsset, Asset: &Asset{Status: 3}},
+		{Type: 9},
+	}
+	for _, a := range bad {
+		tx := &Tx{Outputs: []Output{{Attachment: a}}}
+		if _, err := tx.Encode(); err == nil {
+			t.Errorf("encoded %+v", a)
+		}
+	}
+}
+
+// Whatever a corrupted transaction decodes to must encode to the same
+// bytes.
+func TestCorrupted(t *testing.T) {
+	data, err := allAttachments().Encode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 10000; i++ {
+		b := append([]byte(nil), data...)
+		b[rnd.Intn(len(b))] ^= byte(rnd.Intn(255) + 1)
+		tx, err := DecodeBytes(b)
+		if err != nil {
+			continue
+		}
+		if e, err := tx.Encode(); err != nil || !bytes.Equal(e, b) {
+			t.Fatalf("%x decodes but encodes to %x, %v", b, e, err)
+		}
+	}
+}
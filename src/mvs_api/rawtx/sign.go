@@ -0,0 +1,371 @@
+package rawtx
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"mvs_api/address"
+	"mvs_api/keys"
+)
+
+// SigHashAll is the only signature hash type the MVS wallet uses; it is
+// appended to every signature in a script.
+const SigHashAll = 1
+
+// Script opcodes used by the standard scripts.
+const (
+	op0             = 0x00
+	opPushData1     = 0x4c
+	opPushData2     = 0x4d
+	opPushData4     = 0x4e
+	op1             = 0x51
+	op16            = 0x60
+	opCheckMultisig = 0xae
+)
+
+var ErrNotMultisig = errors.New("rawtx: not a multisig redeem script")
+
+// SignatureHash is the hash input i signs with SigHashAll, where script is
+// the output script it spends, or the redeem script of a multisig input.
+func (tx *Tx) SignatureHash(i int, script []byte) ([]byte, error) {
+	if i < 0 || i >= len(tx.Inputs) {
+		return nil, fmt.Errorf("rawtx: no input %d", i)
+	}
+	signing := *tx
+	signing.Inputs = make([]Input, len(tx.Inputs))
+	for j, in := range tx.Inputs {
+		in.Script = nil
+		if j == i {
+			in.Script = script
+		}
+		signing.Inputs[j] = in
+	}
+	data, err := signing.Encode()
+	if err != nil {
+		return nil, err
+	}
+	var hashType [4]byte
+	binary.LittleEndian.PutUint32(hashType[:], SigHashAll)
+	first := sha256.Sum256(append(data, hashType[:]...))
+	hash := sha256.Sum256(first[:])
+	return hash[:], nil
+}
+
+// Sign signs input i with key, returning the signature with its hash type
+// byte as it goes into a script.
+func (tx *Tx) Sign(i int, script []byte, key *keys.PrivateKey) ([]byte, error) {
+	hash, err := tx.SignatureHash(i, script)
+	if err != nil {
+		return nil, err
+	}
+	sig, err := key.Sign(hash)
+	if err != nil {
+		return nil, err
+	}
+	return append(sig, SigHashAll), nil
+}
+
+// SignP2PKH signs the inputs spending a pay-to-public-key-hash output of one
+// of keys and sets their scripts. prevScripts holds the output script each
+// input spends, in input order. It fails if no input could be signed, and
+// leaves inputs of other keys or scripts alone.
+func (tx *Tx) SignP2PKH(prevScripts [][]byte, keyring ...*keys.PrivateKey) error {
+	if len(prevScripts) != len(tx.Inputs) {
+		return fmt.Errorf("rawtx: %d previous scripts for %d inputs", len(prevScripts), len(tx.Inputs))
+	}
+	owners := map[[20]byte]*keys.PrivateKey{}
+	for _, key := range keyring {
+		owners[keys.Hash160(key.PublicKey().Bytes())] = key
+	}
+	signed := 0
+	for i, script := range prevScripts {
+		if !isP2PKH(script) {
+			continue
+		}
+		var hash [20]byte
+		copy(hash[:], script[3:23])
+		key, ok := owners[hash]
+		if !ok {
+			continue
+		}
+		sig, err := tx.Sign(i, script, key)
+		if err != nil {
+			return err
+		}
+		tx.Inputs[i].Script = append(pushData(sig), pushData(key.PublicKey().Bytes())...)
+		signed++
+	}
+	if signed == 0 {
+		return errors.New("rawtx: no input spends an output of the given keys")
+	}
+	return nil
+}
+
+// SetMultisigScript sets the script of input i, which spends the
+// pay-to-script-hash address of redeemScript, from signatures made with
+// Sign. The signatures may come in any order; they are checked and put in
+// the order of the keys in redeemScript, as OP_CHECKMULTISIG needs.
+func (tx *Tx) SetMultisigScript(i int, redeemScript []byte, sigs [][]byte) error {
+	return tx.setMultisigScript(i, redeemScript, sigs, false)
+}
+
+// SetPartialMultisigScript is SetMultisigScript for fewer signatures than
+// the script needs, the form in which Signmultisigtx passes a transaction
+// from one cosigner to the next.
+func (tx *Tx) SetPartialMultisigScript(i int, redeemScript []byte, sigs [][]byte) error {
+	return tx.setMultisigScript(i, redeemScript, sigs, true)
+}
+
+func (tx *Tx) setMultisigScript(i int, redeemScript []byte, sigs [][]byte, partial bool) error {
+	m, pubs, err := ParseMultisig(redeemScript)
+	if err != nil {
+		return err
+	}
+	hash, err := tx.SignatureHash(i, redeemScript)
+	if err != nil {
+		return err
+	}
+	ordered := make([][]byte, len(pubs))
+	count := 0
+	for _, sig := range sigs {
+		found := false
+		for j, pub := range pubs {
+			if ordered[j] == nil && pub.Verify(hash, sig) {
+				ordered[j], found = sig, true
+				count++
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("rawtx: input %d: signature matches no key of the redeem script", i)
+		}
+	}
+	if count < m && !partial {
+		return fmt.Errorf("rawtx: input %d: %d of %d signatures", i, count, m)
+	}
+
+	script := []byte{op0}
+	for _, sig := range ordered {
+		if sig != nil && m > 0 {
+			script = append(script, pushData(sig)...)
+			m--
+		}
+	}
+	tx.Inputs[i].Script = append(script, pushData(redeemScript)...)
+	return nil
+}
+
+// MultisigSigners returns the keys of redeemScript with a valid signature
+// in the script of input i.
+func (tx *Tx) MultisigSigners(i int, redeemScript []byte) ([]*keys.PublicKey, error) {
+	_, pubs, err := ParseMultisig(redeemScript)
+	if err != nil {
+		return nil, err
+	}
+	sigs, err := tx.MultisigSignatures(i, redeemScript)
+	if err != nil {
+		return nil, err
+	}
+	var signers []*keys.PublicKey
+	for _, pub := range pubs {
+		if sigs[pub.String()] != nil {
+			signers = append(signers, pub)
+		}
+	}
+	return signers, nil
+}
+
+// MultisigSignatures returns the valid signatures in the script of input i
+// by the hex public key of redeemScript that made them. An input script
+// that is not made of pushes, as in an unsigned transaction, has none.
+func (tx *Tx) MultisigSignatures(i int, redeemScript []byte) (map[string][]byte, error) {
+	_, pubs, err := ParseMultisig(redeemScript)
+	if err != nil {
+		return nil, err
+	}
+	hash, err := tx.SignatureHash(i, redeemScript)
+	if err != nil {
+		return nil, err
+	}
+	sigs := map[string][]byte{}
+	pushes, err := parsePushes(tx.Inputs[i].Script)
+	if err != nil {
+		return sigs, nil
+	}
+	for _, pub := range pubs {
+		for _, push := range pushes {
+			if len(push) > 0 && pub.Verify(hash, push) {
+				sigs[pub.String()] = push
+				break
+			}
+		}
+	}
+	return sigs, nil
+}
+
+// parsePushes returns the data pushed by a script made only of pushes.
+func parsePushes(script []byte) ([][]byte, error) {
+	var pushes [][]byte
+	for len(script) > 0 {
+		op := script[0]
+		script = script[1:]
+		n := int(op)
+		switch {
+		case op == op0:
+			pushes = append(pushes, nil)
+			continue
+		case op < opPushData1:
+		case op == opPushData1 && len(script) >= 1:
+			n, script = int(script[0]), script[1:]
+		case op == opPushData2 && len(script) >= 2:
+			n, script = int(binary.LittleEndian.Uint16(script)), script[2:]
+		case op == opPushData4 && len(script) >= 4:
+			n, script = int(binary.LittleEndian.Uint32(script)), script[4:]
+		default:
+			return nil, errors.New("rawtx: input script is not only pushes")
+		}
+		if n < 0 || n > len(script) {
+			return nil, ErrTruncated
+		}
+		pushes = append(pushes, script[:n])
+		script = script[n:]
+	}
+	return pushes, nil
+}
+
+// MultisigScript builds the m-of-n redeem script of the hex publicKeys, the
+// cosigners' and the account's own as Getnewmultisig takes them. Like mvsd,
+// it sorts the keys by their hex form first, so every cosigner gets the same
+// script whatever order they list the keys in.
+func MultisigScript(m int, publicKeys []string) ([]byte, error) {
+	sorted := make([]string, len(publicKeys))
+	for i, k := range publicKeys {
+		sorted[i] = strings.ToLower(k)
+	}
+	sort.Strings(sorted)
+	if len(sorted) < 1 || len(sorted) > 16 || m < 1 || m > len(sorted) {
+		return nil, fmt.Errorf("rawtx: %d of %d multisig", m, len(sorted))
+	}
+	script := []byte{byte(op1 + m - 1)}
+	for i, k := range sorted {
+		if i > 0 && k == sorted[i-1] {
+			return nil, fmt.Errorf("rawtx: public key %s given twice", k)
+		}
+		b, err := hex.DecodeString(k)
+		if err != nil {
+			return nil, keys.ErrBadPublicKey
+		}
+		if _, err := keys.ParsePublicKey(b); err != nil {
+			return nil, err
+		}
+		script = append(script, pushData(b)...)
+	}
+	return append(script, byte(op1+len(sorted)-1), opCheckMultisig), nil
+}
+
+// MultisigAddress is the pay-to-script-hash address of MultisigScript(m,
+// publicKeys), which Getnewmultisig should return for the same keys.
+func MultisigAddress(m int, publicKeys []string, testnet bool) (string, error) {
+	script, err := MultisigScript(m, publicKeys)
+	if err != nil {
+		return "", err
+	}
+	return address.New(address.ScriptHash, testnet, keys.Hash160(script)).String(), nil
+}
+
+// ParseMultisig reads an "m <keys> n OP_CHECKMULTISIG" redeem script.
+func ParseMultisig(script []byte) (m int, pubs []*keys.PublicKey, err error) {
+	if len(script) < 3 || script[len(script)-1] != opCheckMultisig {
+		return 0, nil, ErrNotMultisig
+	}
+	m, n := smallInt(script[0]), smallInt(script[len(script)-2])
+	rest := script[1 : len(script)-2]
+	for len(rest) > 0 {
+		size := int(rest[0])
+		if size != 33 && size != 65 || len(rest) < 1+size {
+			return 0, nil, ErrNotMultisig
+		}
+		pub, err := keys.ParsePublicKey(rest[1 : 1+size])
+		if err != nil {
+			return 0, nil, err
+		}
+		pubs = append(pubs, pub)
+		rest = rest[1+size:]
+	}
+	if m < 1 || n != len(pubs) || m > n {
+		return 0, nil, ErrNotMultisig
+	}
+	return m, pubs, nil
+}
+
+// ParseMultisigText reads the multisig-script of Getnewmultisig and
+// Listmultisig, the redeem script in the node's text form such as
+// "2 [ 02... ] [ 03... ] 2 checkmultisig", and returns it serialized.
+func ParseMultisigText(s string) ([]byte, error) {
+	s = strings.NewReplacer("[", " [ ", "]", " ] ").Replace(s)
+	fields := strings.Fields(s)
+	var script []byte
+	for i := 0; i < len(fields); i++ {
+		switch f := strings.ToLower(fields[i]); f {
+		case "[":
+			if i+2 >= len(fields) || fields[i+2] != "]" {
+				return nil, ErrNotMultisig
+			}
+			b, err := hex.DecodeString(fields[i+1])
+			if err != nil {
+				return nil, ErrNotMultisig
+			}
+			script = append(script, pushData(b)...)
+			i += 2
+		case "checkmultisig":
+			script = append(script, opCheckMultisig)
+		default:
+			n, err := strconv.Atoi(f)
+			if err != nil || n < 1 || n > 16 {
+				return nil, ErrNotMultisig
+			}
+			script = append(script, byte(op1+n-1))
+		}
+	}
+	if _, _, err := ParseMultisig(script); err != nil {
+		return nil, err
+	}
+	return script, nil
+}
+
+func smallInt(op byte) int {
+	if op < op1 || op > op16 {
+		return -1
+	}
+	return int(op-op1) + 1
+}
+
+func isP2PKH(s []byte) bool {
+	return len(s) == 25 && s[0] == 0x76 && s[1] == 0xa9 && s[2] == 0x14 && s[23] == 0x88 && s[24] == 0xac
+}
+
+// pushData is the shortest script pushing b.
+func pushData(b []byte) []byte {
+	var buf bytes.Buffer
+	switch n := len(b); {
+	case n < opPushData1:
+		buf.WriteByte(byte(n))
+	case n <= 0xff:
+		buf.Write([]byte{opPushData1, byte(n)})
+	case n <= 0xffff:
+		buf.WriteByte(opPushData2)
+		binary.Write(&buf, binary.LittleEndian, uint16(n))
+	default:
+		buf.WriteByte(opPushData4)
+		binary.Write(&buf, binary.LittleEndian, uint32(n))
+	}
+	buf.Write(b)
+	return buf.Bytes()
+}
@@ -0,0 +1,307 @@
+package rawtx
+
+import (
+	"bytes"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"mvs_api/keys"
+)
+
+// key is the private key with secret b.
+func key(b byte) *keys.PrivateKey {
+	k, err := keys.NewPrivateKey(append(make([]byte, 31), b))
+	if err != nil {
+		panic(err)
+	}
+	return k
+}
+
+func p2pkh(k *keys.PrivateKey) []byte {
+	hash := keys.Hash160(k.PublicKey().Bytes())
+	return append(append([]byte{0x76, 0xa9, 0x14}, hash[:]...), 0x88, 0xac)
+}
+
+// redeem is the 2 of 3 script of keys 1, 2 and 3, in that order.
+func redeem() []byte {
+	script := []byte{op1 + 1}
+	for _, b := range []byte{1, 2, 3} {
+		script = append(script, pushData(key(b).PublicKey().Bytes())...)
+	}
+	return append(script, op1+2, opCheckMultisig)
+}
+
+// twoInputs is etpTransfer, unsigned, spending a second output.
+func twoInputs(t *testing.T) *Tx {
+	tx, err := Decode(etpTransfer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx.Inputs[0].Script = nil
+	tx.Inputs = append(tx.Inputs, tx.Inputs[0])
+	tx.Inputs[1].PrevIndex = 1
+	return tx
+}
+
+func TestSignP2PKH(t *testing.T) {
+	tx := twoInputs(t)
+	prev := [][]byte{p2pkh(key(1)), p2pkh(key(2))}
+	if err := tx.SignP2PKH(prev, key(3)); err == nil {
+		t.Error("signed with a key of no input")
+	}
+	if err := tx.SignP2PKH(prev[:1], key(1)); err == nil {
+		t.Error("signed with too few previous scripts")
+	}
+	if err := tx.SignP2PKH(prev, key(1)); err != nil {
+		t.Fatal(err)
+	}
+	if len(tx.Inputs[1].Script) != 0 {
+		t.Error("signed the input of another key")
+	}
+	if err := tx.SignP2PKH(prev, key(2)); err != nil {
+		t.Fatal(err)
+	}
+	for i, k := range []*keys.PrivateKey{key(1), key(2)} {
+		pushes, err := parsePushes(tx.Inputs[i].Script)
+		if err != nil || len(pushes) != 2 {
+			t.Fatalf("input %d script %x: %v", i, tx.Inputs[i].Script, err)
+		}
+		sig, pub := pushes[0], pushes[1]
+		if sig[len(sig)-1] != SigHashAll || !bytes.Equal(pub, k.PublicKey().Bytes()) {
+			t.Errorf("input %d script %x", i, tx.Inputs[i].Script)
+		}
+		hash, err := tx.SignatureHash(i, prev[i])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !k.PublicKey().Verify(hash, sig) {
+			t.Errorf("input %d: signature does not verify", i)
+		}
+	}
+	// The hash of one input does not depend on the scripts of the others.
+	signed, _ := tx.SignatureHash(0, prev[0])
+	unsigned, _ := twoInputs(t).SignatureHash(0, prev[0])
+	if !bytes.Equal(signed, unsigned) {
+		t.Error("signature hash changed as other inputs were signed")
+	}
+	if _, err := tx.SignatureHash(2, prev[0]); err == nil {
+		t.Error("hashed a missing input")
+	}
+}
+
+func TestSetMultisigScript(t *testing.T) {
+	tx := twoInputs(t)
+	script := redeem()
+	sig1, _ := tx.Sign(1, script, key(1))
+	sig3, _ := tx.Sign(1, script, key(3))
+	other, _ := tx.Sign(0, script, key(2))
+
+	if err := tx.SetMultisigScript(1, script, [][]byte{sig3}); err == nil {
+		t.Error("set a script with 1 of 2 signatures")
+	}
+	if err := tx.SetMultisigScript(1, script, [][]byte{sig3, sig3}); err == nil {
+		t.Error("set a script with one signature twice")
+	}
+	if err := tx.SetMultisigScript(1, script, [][]byte{sig3, other}); err == nil {
+		t.Error("set a script with the signature of another input")
+	}
+	if err := tx.SetPartialMultisigScript(1, script, [][]byte{sig3}); err != nil {
+		t.Fatal(err)
+	}
+	signers, err := tx.MultisigSigners(1, script)
+	if err != nil || len(signers) != 1 || !signers[0].Equal(key(3).PublicKey()) {
+		t.Errorf("partial signers = %v, %v", signers, err)
+	}
+
+	// Signatures given in any order go in the order of the keys.
+	if err := tx.SetMultisigScript(1, script, [][]byte{sig3, sig1}); err != nil {
+		t.Fatal(err)
+	}
+	want := append(append(append([]byte{op0}, pushData(sig1)...), pushData(sig3)...), pushData(script)...)
+	if !bytes.Equal(tx.Inputs[1].Script, want) {
+		t.Errorf("script = %x, want %x", tx.Inputs[1].Script, want)
+	}
+	sigs, err := tx.MultisigSignatures(1, script)
+	if err != nil || len(sigs) != 2 || !bytes.Equal(sigs[key(1).PublicKey().String()], sig1) {
+		t.Errorf("signatures = %x, %v", sigs, err)
+	}
+	if sigs, err := tx.MultisigSignatures(0, script); err != nil || len(sigs) != 0 {
+		t.Errorf("signatures of the unsigned input = %x, %v", sigs, err)
+	}
+}
+
+// robotSecrets are the private keys of robotKeys.
+var robotSecrets = []string{
+	"cf118f817ca7f45fcead37cfce07b4200b4480c0f2fb344255226b7f8674f98c",
+	"008f059c235f838bc13dbb521e922407409f82ed56a396774f1a93de5632aa47",
+	"862c436daafc92d8c847cca227c5701cc8efe9a1335a35ea9a8cfaa503c1345b",
+}
+
+func robotKey(t *testing.T, i int) *keys.PrivateKey {
+	t.Helper()
+	k, err := keys.ParsePrivateKey(robotSecrets[i])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return k
+}
+
+// unsigned is the transaction s with its input scripts taken out, and
+// those scripts.
+func unsigned(t *testing.T, s string) (*Tx, [][]byte) {
+	t.Helper()
+	tx, err := Decode(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var scripts [][]byte
+	for i := range tx.Inputs {
+		scripts = append(scripts, tx.Inputs[i].Script)
+		tx.Inputs[i].Script = nil
+	}
+	return tx, scripts
+}
+
+// Signing the unsigned form of the transactions of rawtx_test.go must give
+// their scripts byte for byte: the same signature hash and, as signatures
+// are deterministic, the same DER signature.
+func TestSignKnownAnswer(t *testing.T) {
+	p2pkhOf := func(i int) []byte { return p2pkh(robotKey(t, i)) }
+	tests := []struct {
+		name   string
+		tx     string
+		key    int
+		hashes []string
+	}{
+		{"etpTransfer", etpTransfer, 0, []string{"2bac4d5861933583747efca6531c1d4eee68b747982cd666e1b1c8b8e25d62ab"}},
+		{"assetTransfer", assetTransfer, 1, []string{
+			"f992c52eefbba1248a692c293475c300f4138e2895d470f7dd551af65aba4eff",
+			"6b1c86af849737b9af32de4d2f03286c556854c31a633ca0cb831ae8de50ca2c",
+		}},
+	}
+	for _, tt := range tests {
+		tx, want := unsigned(t, tt.tx)
+		prev := make([][]byte, len(tx.Inputs))
+		for i := range prev {
+			prev[i] = p2pkhOf(tt.key)
+			hash, err := tx.SignatureHash(i, prev[i])
+			if err != nil || hex.EncodeToString(hash) != tt.hashes[i] {
+				t.Errorf("%s: SignatureHash(%d) = %x, %v; want %s", tt.name, i, hash, err, tt.hashes[i])
+			}
+		}
+		if err := tx.SignP2PKH(prev, robotKey(t, tt.key)); err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		for i := range tx.Inputs {
+			if !bytes.Equal(tx.Inputs[i].Script, want[i]) {
+				t.Errorf("%s: input %d script\n%x, want\n%x", tt.name, i, tx.Inputs[i].Script, want[i])
+			}
+		}
+		if got, err := tx.Hex(); err != nil || got != tt.tx {
+			t.Errorf("%s: signed tx %s, %v", tt.name, got, err)
+		}
+	}
+
+	tx, want := unsigned(t, multisigSpend)
+	script, err := MultisigScript(2, robotKeys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hash, err := tx.SignatureHash(0, script)
+	if err != nil || hex.EncodeToString(hash) != "93335067e34b1f2db1e0b9efa8e846f24f3a60ae15a4e1f06b0dd6a6f39c5b0b" {
+		t.Errorf("multisig SignatureHash = %x, %v", hash, err)
+	}
+	sig0, _ := tx.Sign(0, script, robotKey(t, 0))
+	sig2, _ := tx.Sign(0, script, robotKey(t, 2))
+	if got := hex.EncodeToString(sig0); got != "3045022100f991a8287889212e3582016a575e1db6d3dcb8c1ea2180b66ea14e7445a574bf02207965aac2d9d0518322d1c4b59b2e8984f2bab8e351a5d3f23b7844dbe7111dfc01" {
+		t.Errorf("signature of MLasJF... = %s", got)
+	}
+	if err := tx.SetMultisigScript(0, script, [][]byte{sig0, sig2}); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(tx.Inputs[0].Script, want[0]) {
+		t.Errorf("multisig script\n%x, want\n%x", tx.Inputs[0].Script, want[0])
+	}
+}
+
+// The multisig fixture of mvstest, as mvsd reports it.
+var (
+	fixtureKeys = []string{
+		"02578ad340083e85c739f379bbe6c6937c5da2ced52e09ac1eec43dc4c64846573",
+		"0380990a7312b87abda80e5857ee6ebf798a2bf62041b07111287d19926c429d11",
+		"03af3a99f1c3279dbe1c22fd767fb98b5dbd138f6e0511c2fc11128e44c0373cad",
+	}
+	fixtureText = "2 [ 02578ad340083e85c739f379bbe6c6937c5da2ced52e09ac1eec43dc4c64846573 ] [ 0380990a7312b87abda80e5857ee6ebf798a2bf62041b07111287d19926c429d11 ] [ 03af3a99f1c3279dbe1c22fd767fb98b5dbd138f6e0511c2fc11128e44c0373cad ] 3 checkmultisig"
+)
+
+func TestMultisigAddress(t *testing.T) {
+	// mvsd sorts the keys, so their order does not matter.
+	shuffled := []string{fixtureKeys[2], strings.ToUpper(fixtureKeys[0]), fixtureKeys[1]}
+	for _, pubs := range [][]string{fixtureKeys, shuffled} {
+		addr, err := MultisigAddress(2, pubs, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if addr != "359mjCL3V8PaxLUzU9mJSNtLSEXHFJmzfA" {
+			t.Errorf("MultisigAddress(2, %v) = %s", pubs, addr)
+		}
+	}
+	script, err := MultisigScript(2, fixtureKeys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, pubs, err := ParseMultisig(script)
+	if err != nil || m != 2 || len(pubs) != 3 || pubs[1].String() != fixtureKeys[1] {
+		t.Errorf("ParseMultisig = %d, %v, %v", m, pubs, err)
+	}
+
+	bad := []struct {
+		m    int
+		pubs []string
+	}{
+		{0, fixtureKeys},
+		{4, fixtureKeys},
+		{1, nil},
+		{2, []string{fixtureKeys[0], fixtureKeys[0]}},
+		{1, []string{"02zz"}},
+		{1, []string{"0200"}},
+	}
+	for _, tt := range bad {
+		if _, err := MultisigScript(tt.m, tt.pubs); err == nil {
+			t.Errorf("MultisigScript(%d, %v) took it", tt.m, tt.pubs)
+		}
+	}
+}
+
+func TestParseMultisigText(t *testing.T) {
+	want, err := MultisigScript(2, fixtureKeys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range []string{
+		fixtureText,
+		strings.ToUpper(fixtureText),
+		strings.NewReplacer("[ ", "[", " ]", "]").Replace(fixtureText),
+	} {
+		got, err := ParseMultisigText(s)
+		if err != nil {
+			t.Errorf("ParseMultisigText(%q): %v", s, err)
+		} else if !bytes.Equal(got, want) {
+			t.Errorf("ParseMultisigText(%q) = %x, want %x", s, got, want)
+		}
+	}
+	for _, s := range []string{
+		"",
+		hex.EncodeToString(want),
+		"2 [ " + fixtureKeys[0] + " ] 3 checkmultisig",
+		"2 [ " + fixtureKeys[0] + " [ " + fixtureKeys[1] + " ] 2 checkmultisig",
+		"2 [ " + fixtureKeys[0] + " ] [ " + fixtureKeys[1] + " ] 2",
+		"2 [ " + fixtureKeys[0] + " ] [ " + fixtureKeys[1] + " ] 2 checksig",
+		"17 [ " + fixtureKeys[0] + " ] 1 checkmultisig",
+	} {
+		if _, err := ParseMultisigText(s); err == nil {
+			t.Errorf("ParseMultisigText(%q) took it", s)
+		}
+	}
+}
@@ -0,0 +1,224 @@
+package mvs_api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"mvs_api/address"
+)
+
+var ErrBadReceiver = errors.New("mvs_api: bad receiver")
+
+// didReceiverMethods take a DID as well as an address for a receiver;
+// the others take addresses only.
+var didReceiverMethods = map[string]bool{
+	"didsendmore": true,
+}
+
+// Receiver is one output of Sendmore, Didsendmore or Createrawtx. To is an
+// address, or for Didsendmore also a DID, which the other two refuse;
+// Amount is in ETP bits, or in asset units when Createrawtx is given a
+// symbol.
+type Receiver struct {
+	To     string
+	Amount Amount
+}
+
+// Receivers is sent as the "to:amount" list the node expects, checked and
+// with duplicates merged:
+//
+//	tx, err := r.Didsendmore("Alice", "A123456", mvs_api.Receivers{
+//		{To: "MLasJFxZQnA49XEvhTHmRKi2qstkj9ppjo", Amount: 2 * mvs_api.ETP},
+//		{To: "BIAM", Amount: 50000},
+//	}, "", 0)
+type Receivers []Receiver
+
+// ParseReceiver reads the wire form "to:amount".
+func ParseReceiver(s string) (Receiver, error) {
+	i := strings.LastIndexByte(s, ':')
+	if i < 0 {
+		return Receiver{}, fmt.Errorf("%w: %q is not to:amount", ErrBadReceiver, s)
+	}
+	v, err := strconv.ParseUint(s[i+1:], 10, 64)
+	if err != nil {
+		return Receiver{}, fmt.Errorf("%w: bad amount in %q", ErrBadReceiver, s)
+	}
+	rc := Receiver{To: s[:i], Amount: Amount(v)}
+	return rc, rc.Validate()
+}
+
+// Validate checks that To is a valid address or DID symbol and Amount is
+// not zero. A string that looks like an address but fails its checksum is
+// taken as a mistyped address, not as a DID.
+func (rc Receiver) Validate() error {
+	return rc.validate(true)
+}
+
+func (rc Receiver) validate(allowDID bool) error {
+	if rc.Amount == 0 {
+		return fmt.Errorf("%w: zero amount to %q", ErrBadReceiver, rc.To)
+	}
+	if address.Valid(rc.To) {
+		return nil
+	}
+	if looksLikeAddress(rc.To) {
+		return fmt.Errorf("%w: invalid address %q", ErrBadReceiver, rc.To)
+	}
+	if !validDID(rc.To) {
+		return fmt.Errorf("%w: %q is neither an address nor a DID", ErrBadReceiver, rc.To)
+	}
+	if !allowDID {
+		return fmt.Errorf("%w: %q is a DID, use an address", ErrBadReceiver, rc.To)
+	}
+	return nil
+}
+
+func (rc Receiver) String() string {
+	return rc.To + ":" + strconv.FormatUint(uint64(rc.Amount), 10)
+}
+
+// Validate checks every receiver and that there is at least one.
+func (rs Receivers) Validate() error {
+	return rs.validate(true)
+}
+
+func (rs Receivers) validate(allowDID bool) error {
+	if len(rs) == 0 {
+		return fmt.Errorf("%w: no receivers", ErrBadReceiver)
+	}
+	for _, rc := range rs {
+		if err := rc.validate(allowDID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkReceivers rejects the DID receivers in the params of method unless
+// the node resolves them for it. MarshalJSON checks the rest.
+func checkReceivers(method string, params interface{}) error {
+	if didReceiverMethods[method] {
+		return nil
+	}
+	args, _ := params.([]interface{})
+	for _, arg := range args {
+		optional, ok := arg.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for _, v := range optional {
+			if rs, ok := v.(Receivers); ok {
+				if err := rs.validate(false); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// Merge adds up the amounts of receivers with the same To, keeping the
+// order in which each first appears.
+func (rs Receivers) Merge() (Receivers, error) {
+	merged := make(Receivers, 0, len(rs))
+	index := map[string]int{}
+	for _, rc := range rs {
+		i, ok := index[rc.To]
+		if !ok {
+			index[rc.To] = len(merged)
+			merged = append(merged, rc)
+			continue
+		}
+		sum, err := merged[i].Amount.Add(rc.Amount)
+		if err != nil {
+			return nil, err
+		}
+		merged[i].Amount = sum
+	}
+	return merged, nil
+}
+
+// Total is the sum of the amounts, fee not included.
+func (rs Receivers) Total() (Amount, error) {
+	amounts := make([]Amount, len(rs))
+	for i, rc := range rs {
+		amounts[i] = rc.Amount
+	}
+	return SumAmounts(amounts...)
+}
+
+// Strings validates and merges rs and writes it in the wire form.
+func (rs Receivers) Strings() ([]string, error) {
+	if err := rs.Validate(); err != nil {
+		return nil, err
+	}
+	merged, err := rs.Merge()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := merged.Total(); err != nil {
+		return nil, err
+	}
+	s := make([]string, len(merged))
+	for i, rc := range merged {
+		s[i] = rc.String()
+	}
+	return s, nil
+}
+
+func (rs Receivers) MarshalJSON() ([]byte, error) {
+	s, err := rs.Strings()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(s)
+}
+
+func (rs *Receivers) UnmarshalJSON(data []byte) error {
+	var s []string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	*rs = make(Receivers, len(s))
+	for i, v := range s {
+		rc, err := ParseReceiver(v)
+		if err != nil {
+			return err
+		}
+		(*rs)[i] = rc
+	}
+	return nil
+}
+
+// looksLikeAddress reports whether s has the length, alphabet and leading
+// character of an address.
+func looksLikeAddress(s string) bool {
+	if len(s) < 33 || len(s) > 35 || !strings.ContainsAny(s[:1], "Mt32") {
+		return false
+	}
+	for _, c := range s {
+		if !strings.ContainsRune("123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz", c) {
+			return false
+		}
+	}
+	return true
+}
+
+// validDID applies the node's rules for DID symbols: 3 to 64 letters,
+// digits and ".@_-".
+func validDID(s string) bool {
+	if len(s) < 3 || len(s) > 64 {
+		return false
+	}
+	for _, c := range s {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9', strings.ContainsRune(".@_-", c):
+		default:
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,89 @@
+package mvs_api_test
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"mvs_api"
+	"mvs_api/mvstest"
+)
+
+func TestReceiverValidate(t *testing.T) {
+	tests := []struct {
+		rc mvs_api.Receiver
+		ok bool
+	}{
+		{mvs_api.Receiver{To: mvstest.Address, Amount: 1}, true},
+		{mvs_api.Receiver{To: "BIAM", Amount: 1}, true},
+		{mvs_api.Receiver{To: "alice.mvs@home_1-2", Amount: 1}, true},
+		{mvs_api.Receiver{To: mvstest.Address, Amount: 0}, false},
+		// A mistyped address is not taken for a DID.
+		{mvs_api.Receiver{To: "MLasJFxZQnA49XEvhTHmRKi2qstkj9ppjp", Amount: 1}, false},
+		{mvs_api.Receiver{To: "AB", Amount: 1}, false},
+		{mvs_api.Receiver{To: "bad did", Amount: 1}, false},
+	}
+	for _, tt := range tests {
+		if err := tt.rc.Validate(); (err == nil) != tt.ok {
+			t.Errorf("%v.Validate() = %v", tt.rc, err)
+		} else if err != nil && !errors.Is(err, mvs_api.ErrBadReceiver) {
+			t.Errorf("%v.Validate() = %v, want ErrBadReceiver", tt.rc, err)
+		}
+	}
+	if err := (mvs_api.Receivers{}).Validate(); !errors.Is(err, mvs_api.ErrBadReceiver) {
+		t.Errorf("no receivers: err = %v", err)
+	}
+}
+
+func TestReceiversWire(t *testing.T) {
+	rs := mvs_api.Receivers{{To: mvstest.Address, Amount: 1}, {To: "BIAM", Amount: 2}, {To: mvstest.Address, Amount: 3}}
+	data, err := json.Marshal(rs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `["` + mvstest.Address + `:4","BIAM:2"]`; string(data) != want {
+		t.Errorf("marshalled %s, want %s", data, want)
+	}
+	var back mvs_api.Receivers
+	if err := json.Unmarshal(data, &back); err != nil {
+		t.Fatal(err)
+	}
+	if want := (mvs_api.Receivers{{To: mvstest.Address, Amount: 4}, {To: "BIAM", Amount: 2}}); !reflect.DeepEqual(back, want) {
+		t.Errorf("unmarshalled %v", back)
+	}
+	if total, err := rs.Total(); err != nil || total != 6 {
+		t.Errorf("Total = %d, %v", total, err)
+	}
+	if _, err := (mvs_api.Receivers{{To: "BIAM", Amount: ^mvs_api.Amount(0)}, {To: "BIAM", Amount: 1}}).Strings(); !errors.Is(err, mvs_api.ErrAmountOverflow) {
+		t.Errorf("overflowing merge: err = %v", err)
+	}
+	for _, s := range []string{"BIAM", "BIAM:x", "BIAM:0", ":5"} {
+		if _, err := mvs_api.ParseReceiver(s); err == nil {
+			t.Errorf("ParseReceiver(%q) took it", s)
+		}
+	}
+}
+
+func TestReceiversDIDPerMethod(t *testing.T) {
+	s := mvstest.NewServer()
+	defer s.Close()
+	c := s.Client()
+	did := mvs_api.Receivers{{To: mvstest.Address, Amount: 1}, {To: "BIAM", Amount: 2}}
+
+	if _, err := c.Didsendmore("Alice", "A123456", did, "", 0); err != nil {
+		t.Errorf("didsendmore: %v", err)
+	}
+	if _, err := c.Sendmore("Alice", "A123456", did, "", 0); !errors.Is(err, mvs_api.ErrBadReceiver) {
+		t.Errorf("sendmore to a DID: err = %v", err)
+	}
+	if _, err := c.Createrawtx(0, []string{mvstest.Address}, did, "", 0, "", "", 0); !errors.Is(err, mvs_api.ErrBadReceiver) {
+		t.Errorf("createrawtx to a DID: err = %v", err)
+	}
+	if n := len(s.Calls("sendmore")) + len(s.Calls("createrawtx")); n != 0 {
+		t.Errorf("%d calls with DID receivers reached the node", n)
+	}
+	if _, err := c.Sendmore("Alice", "A123456", did[:1], "", 0); err != nil {
+		t.Errorf("sendmore to an address: %v", err)
+	}
+}
@@ -0,0 +1,64 @@
+package mvs_api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync/atomic"
+	"time"
+)
+
+var ErrIdMismatch = errors.New("mvs_api: response id does not match request id")
+
+type requestIdKey struct{}
+
+// WithRequestId makes calls made with ctx use id as their JSON-RPC request
+// id instead of the next value of the client counter, so that they can be
+// found in the node logs. Batched calls always use the counter.
+func WithRequestId(ctx context.Context, id uint64) context.Context {
+	return context.WithValue(ctx, requestIdKey{}, id)
+}
+
+func (r *RPCClient) nextId(ctx context.Context) uint64 {
+	if id, ok := ctx.Value(requestIdKey{}).(uint64); ok {
+		return id
+	}
+	return atomic.AddUint64(&r.lastId, 1)
+}
+
+// checkId accepts a null id only on errors, which is how a node answers a
+// request it could not parse.
+func checkId(rpcResp *JSONRpcResp, id uint64) error {
+	got := idKey(rpcResp.Id)
+	if got == fmt.Sprint(id) {
+		return nil
+	}
+	if (got == "" || got == "null") && rpcResp.Error != nil {
+		return nil
+	}
+	return fmt.Errorf("%w: sent %d, got %s", ErrIdMismatch, id, got)
+}
+
+// SetLogger makes the client log one line per request with its id, method,
+// duration and outcome. Parameters are never logged, they carry passwords.
+func (r *RPCClient) SetLogger(l *log.Logger) {
+	r.Lock()
+	r.logger = l
+	r.Unlock()
+}
+
+func (r *RPCClient) logCall(id uint64, method string, start time.Time, err error) {
+	r.RLock()
+	logger := r.logger
+	r.RUnlock()
+	if logger == nil {
+		return
+	}
+	elapsed := time.Since(start).Round(time.Millisecond)
+	if err != nil {
+		logger.Printf("mvs_api: %s id=%d %s %v: %v", r.Url, id, method, elapsed, err)
+		return
+	}
+	logger.Printf("mvs_api: %s id=%d %s %v ok", r.Url, id, method, elapsed)
+}
@@ -0,0 +1,94 @@
+package mvs_api_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log"
+	"strings"
+	"testing"
+
+	"mvs_api"
+	"mvs_api/mvstest"
+)
+
+func TestRequestIds(t *testing.T) {
+	s := mvstest.NewServer()
+	defer s.Close()
+	r := s.Client()
+
+	r.Getheight("", "")
+	r.Getheight("", "")
+	r.GetheightContext(mvs_api.WithRequestId(context.Background(), 4242), "", "")
+	calls := s.Calls("getheight")
+	var ids []string
+	for _, c := range calls {
+		ids = append(ids, string(c.Id))
+	}
+	if strings.Join(ids, ",") != "1,2,4242" {
+		t.Errorf("ids = %v, want 1,2,4242", ids)
+	}
+}
+
+// answer makes a client whose node answers every call with body.
+func answer(body string) *mvs_api.RPCClient {
+	r, err := mvs_api.New("http://node/rpc/v2", mvs_api.WithTransport(mvs_api.TransportFunc(
+		func(ctx context.Context, url string, req []byte) (int, []byte, error) {
+			return 200, []byte(body), nil
+		})))
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+func TestCheckId(t *testing.T) {
+	ctx := mvs_api.WithRequestId(context.Background(), 7)
+	tests := []struct {
+		body     string
+		mismatch bool
+		rpcError bool
+	}{
+		{`{"jsonrpc":"2.0","id":7,"result":1}`, false, false},
+		{`{"jsonrpc":"2.0","id":"7","result":1}`, false, false},
+		{`{"jsonrpc":"2.0","id":8,"result":1}`, true, false},
+		{`{"jsonrpc":"2.0","id":8,"error":{"code":1002,"message":"no"}}`, true, false},
+		// A node that could not read the request does not know its id.
+		{`{"jsonrpc":"2.0","id":null,"error":{"code":-32700,"message":"parse error"}}`, false, true},
+		{`{"jsonrpc":"2.0","error":{"code":-32700,"message":"parse error"}}`, false, true},
+		// A result is always for a known request.
+		{`{"jsonrpc":"2.0","id":null,"result":1}`, true, false},
+		{`{"jsonrpc":"2.0","result":1}`, true, false},
+	}
+	for _, tt := range tests {
+		_, err := answer(tt.body).GetheightContext(ctx, "", "")
+		if got := errors.Is(err, mvs_api.ErrIdMismatch); got != tt.mismatch {
+			t.Errorf("%s: err = %v, mismatch = %v", tt.body, err, got)
+		}
+		var rpcErr *mvs_api.RPCError
+		if got := errors.As(err, &rpcErr); got != tt.rpcError {
+			t.Errorf("%s: err = %v, RPCError = %v", tt.body, err, got)
+		}
+		if tt.mismatch && mvs_api.Classify(err) != mvs_api.ErrorClassServer {
+			t.Errorf("%s: class = %v, want server", tt.body, mvs_api.Classify(err))
+		}
+	}
+}
+
+func TestLoggerHidesParams(t *testing.T) {
+	s := mvstest.NewServer()
+	defer s.Close()
+	var buf bytes.Buffer
+	r := s.Client(mvs_api.WithLogger(log.New(&buf, "", 0)))
+	r.GetbalanceContext(mvs_api.WithRequestId(context.Background(), 99), "Alice", "A123456")
+	line := buf.String()
+	if !strings.Contains(line, "id=99 getbalance") || !strings.Contains(line, " ok") {
+		t.Errorf("log = %q", line)
+	}
+	if strings.Contains(line, "A123456") {
+		t.Errorf("log shows the password: %q", line)
+	}
+	if id := string(s.LastCall(t, "getbalance").Id); id != "99" {
+		t.Errorf("id = %s, want 99", id)
+	}
+}
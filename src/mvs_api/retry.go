@@ -0,0 +1,91 @@
+package mvs_api
+
+import (
+	"context"
+	"math/rand"
+	"time"
+)
+
+// RetryPolicy repeats failed read-only calls, see readOnlyMethods. Calls that
+// move funds or change the wallet, such as Send, Sendrawtx or Issue, are
+// never retried whatever the policy says: a lost answer does not mean the
+// node did not act on them.
+type RetryPolicy struct {
+	// MaxAttempts counts the first attempt; 0 or 1 disables retries.
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	Multiplier     float64
+	// Jitter spreads each backoff randomly by up to this fraction of it.
+	Jitter float64
+	// Retryable decides which errors are worth another attempt. It defaults
+	// to transport and server errors.
+	Retryable func(err error) bool
+}
+
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:    3,
+	InitialBackoff: 100 * time.Millisecond,
+	MaxBackoff:     2 * time.Second,
+	Multiplier:     2,
+	Jitter:         0.2,
+}
+
+func (r *RPCClient) SetRetryPolicy(p RetryPolicy) {
+	r.Lock()
+	r.retry = p
+	r.Unlock()
+}
+
+func (r *RPCClient) retryPolicy() RetryPolicy {
+	r.RLock()
+	defer r.RUnlock()
+	return r.retry
+}
+
+func (p RetryPolicy) retryable(err error) bool {
+	if p.Retryable != nil {
+		return p.Retryable(err)
+	}
+	class := Classify(err)
+	return class == ErrorClassTransport || class == ErrorClassServer
+}
+
+// backoff returns the wait before attempt n+1.
+func (p RetryPolicy) backoff(n int) time.Duration {
+	d := float64(p.InitialBackoff)
+	multiplier := p.Multiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+	for i := 1; i < n; i++ {
+		d *= multiplier
+		if p.MaxBackoff > 0 && d > float64(p.MaxBackoff) {
+			break
+		}
+	}
+	if p.MaxBackoff > 0 && d > float64(p.MaxBackoff) {
+		d = float64(p.MaxBackoff)
+	}
+	if p.Jitter > 0 {
+		d += d * p.Jitter * (2*rand.Float64() - 1)
+	}
+	return time.Duration(d)
+}
+
+func (r *RPCClient) doPostRetry(ctx context.Context, url string, method string, params interface{}) (*JSONRpcResp, error) {
+	policy := r.retryPolicy()
+	for attempt := 1; ; attempt++ {
+		rpcResp, err := r.attempt(ctx, url, method, params)
+		if err == nil || attempt >= policy.MaxAttempts || !policy.retryable(err) {
+			return rpcResp, err
+		}
+		timer := time.NewTimer(policy.backoff(attempt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, err
+		case <-timer.C:
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package mvs_api_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"mvs_api"
+	"mvs_api/mvstest"
+)
+
+var fastRetry = mvs_api.RetryPolicy{MaxAttempts: 4, InitialBackoff: time.Millisecond, Multiplier: 2}
+
+func TestRetryReadOnly(t *testing.T) {
+	s := mvstest.NewServer()
+	defer s.Close()
+	r := s.Client(mvs_api.WithRetryPolicy(fastRetry))
+
+	// Fails twice, then answers.
+	s.Handle("getheight", func(c mvstest.Call) (interface{}, error) {
+		if len(s.Calls("getheight")) <= 2 {
+			return nil, errBroken
+		}
+		return 1270000, nil
+	})
+	height, err := r.Getheight("", "")
+	if err != nil || height != 1270000 {
+		t.Fatalf("getheight = %d, %v", height, err)
+	}
+	if n := len(s.Calls("getheight")); n != 3 {
+		t.Errorf("%d attempts, want 3", n)
+	}
+
+	// Never answers: MaxAttempts in all.
+	s.Reset()
+	s.Fail("gettx", errBroken)
+	if _, err := r.Gettx(true, "1b40"); mvs_api.Classify(err) != mvs_api.ErrorClassServer {
+		t.Errorf("err = %v", err)
+	}
+	if n := len(s.Calls("gettx")); n != fastRetry.MaxAttempts {
+		t.Errorf("%d attempts, want %d", n, fastRetry.MaxAttempts)
+	}
+
+	// Rejections are answers and are not repeated.
+	s.Fail("getdid", &mvs_api.RPCError{Code: 7001, Message: "did symbol already exist"})
+	r.Getdid("BIAM")
+	if n := len(s.Calls("getdid")); n != 1 {
+		t.Errorf("rejected call made %d attempts", n)
+	}
+}
+
+func TestRetryNeverRepeatsFundMoves(t *testing.T) {
+	s := mvstest.NewServer()
+	defer s.Close()
+	r := s.Client(mvs_api.WithRetryPolicy(mvs_api.RetryPolicy{
+		MaxAttempts:    10,
+		InitialBackoff: time.Millisecond,
+		// Even a policy that would retry anything.
+		Retryable: func(error) bool { return true },
+	}))
+	calls := []struct {
+		method string
+		call   func() error
+	}{
+		{"send", func() error {
+			_, err := r.Send("Alice", "A123456", mvstest.Address, 1, "", 0)
+			return err
+		}},
+		{"sendfrom", func() error {
+			_, err := r.Sendfrom("Alice", "A123456", mvstest.Address, mvstest.Address, 1, "", 0)
+			return err
+		}},
+		{"signmultisigtx", func() error {
+			_, err := r.Signmultisigtx("Alice", "A123456", "0400", "", true)
+			return err
+		}},
+		{"sendrawtx", func() error {
+			_, err := r.Sendrawtx("0400", 0)
+			return err
+		}},
+		{"issue", func() error {
+			_, err := r.Issue("Alice", "A123456", "MVS.ZGC", "", 0)
+			return err
+		}},
+	}
+	for _, c := range calls {
+		s.Fail(c.method, errBroken)
+		if err := c.call(); err == nil {
+			t.Errorf("%s succeeded on a broken node", c.method)
+		}
+		if n := len(s.Calls(c.method)); n != 1 {
+			t.Errorf("%s was sent %d times", c.method, n)
+		}
+	}
+}
+
+func TestRetryCancelBackoff(t *testing.T) {
+	s := mvstest.NewServer()
+	defer s.Close()
+	r := s.Client(mvs_api.WithRetryPolicy(mvs_api.RetryPolicy{MaxAttempts: 5, InitialBackoff: time.Hour}))
+	s.Fail("getheight", errBroken)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errc := make(chan error, 1)
+	go func() {
+		_, err := r.GetheightContext(ctx, "", "")
+		errc <- err
+	}()
+	for len(s.Calls("getheight")) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+	select {
+	case err := <-errc:
+		if err == nil {
+			t.Error("the call succeeded")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("cancel did not stop the backoff")
+	}
+	if n := len(s.Calls("getheight")); n != 1 {
+		t.Errorf("%d attempts after cancel, want 1", n)
+	}
+}
+
+func TestRetryBackoffGrows(t *testing.T) {
+	s := mvstest.NewServer()
+	defer s.Close()
+	var times []time.Time
+	s.Handle("getheight", func(mvstest.Call) (interface{}, error) {
+		times = append(times, time.Now())
+		return nil, errBroken
+	})
+	r := s.Client(mvs_api.WithRetryPolicy(mvs_api.RetryPolicy{
+		MaxAttempts: 4, InitialBackoff: 10 * time.Millisecond, MaxBackoff: 30 * time.Millisecond, Multiplier: 2,
+	}))
+	r.Getheight("", "")
+	if len(times) != 4 {
+		t.Fatalf("%d attempts, want 4", len(times))
+	}
+	// 10ms, 20ms, then capped at 30ms.
+	for i, min := range []time.Duration{10, 20, 30} {
+		if d := times[i+1].Sub(times[i]); d < min*time.Millisecond {
+			t.Errorf("wait %d = %v, want at least %v", i, d, min*time.Millisecond)
+		}
+	}
+}
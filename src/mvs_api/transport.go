@@ -0,0 +1,132 @@
+package mvs_api
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"time"
+)
+
+// Transport carries an encoded JSON-RPC request, a single object or a batch
+// array, to the node at url and returns the status and body of the answer.
+// Transports that are not HTTP based report status 200 for any answer.
+type Transport interface {
+	Post(ctx context.Context, url string, body []byte) (status int, resp []byte, err error)
+}
+
+// TransportFunc adapts a function to the Transport interface, which is
+// handy for test doubles.
+type TransportFunc func(ctx context.Context, url string, body []byte) (int, []byte, error)
+
+func (f TransportFunc) Post(ctx context.Context, url string, body []byte) (int, []byte, error) {
+	return f(ctx, url, body)
+}
+
+// HTTPTransport is the default transport: a POST to url.
+type HTTPTransport struct {
+	// Client defaults to http.DefaultClient.
+	Client *http.Client
+	// Header is added to every request.
+	Header http.Header
+}
+
+func (t *HTTPTransport) Post(ctx context.Context, url string, body []byte) (int, []byte, error) {
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(body))
+	if err != nil {
+		return 0, nil, err
+	}
+	for key, values := range t.Header {
+		req.Header[key] = append([]string(nil), values...)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	client := t.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, nil, err
+	}
+	return resp.StatusCode, data, nil
+}
+
+// NewUnixSocketTransport talks HTTP over the unix socket at path. The host
+// part of the client Url is then ignored, only its path is used.
+func NewUnixSocketTransport(path string, timeout time.Duration) *HTTPTransport {
+	var dialer net.Dialer
+	return &HTTPTransport{Client: &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				return dialer.DialContext(ctx, "unix", path)
+			},
+		},
+	}}
+}
+
+// HandlerTransport serves requests in process with Handler, without any
+// network round trip.
+type HandlerTransport struct {
+	Handler http.Handler
+}
+
+func (t *HandlerTransport) Post(ctx context.Context, url string, body []byte) (int, []byte, error) {
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(body))
+	if err != nil {
+		return 0, nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	w := &responseBuffer{header: http.Header{}}
+	t.Handler.ServeHTTP(w, req)
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	return w.status, w.body.Bytes(), ctx.Err()
+}
+
+// responseBuffer is the http.ResponseWriter of HandlerTransport.
+type responseBuffer struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func (w *responseBuffer) Header() http.Header {
+	return w.header
+}
+
+func (w *responseBuffer) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+}
+
+func (w *responseBuffer) Write(p []byte) (int, error) {
+	w.WriteHeader(http.StatusOK)
+	return w.body.Write(p)
+}
+
+// SetTransport replaces the way requests reach the node.
+func (r *RPCClient) SetTransport(t Transport) {
+	r.Lock()
+	r.transport = t
+	r.Unlock()
+}
+
+func (r *RPCClient) Transport() Transport {
+	r.RLock()
+	defer r.RUnlock()
+	if r.transport == nil {
+		return &HTTPTransport{}
+	}
+	return r.transport
+}
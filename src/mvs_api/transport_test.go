@@ -0,0 +1,93 @@
+package mvs_api_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"mvs_api"
+	"mvs_api/mvstest"
+)
+
+func TestTransportDefault(t *testing.T) {
+	r, err := mvs_api.New("http://127.0.0.1:8820/rpc/v2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := r.Transport().(*mvs_api.HTTPTransport); !ok {
+		t.Errorf("New: transport = %T", r.Transport())
+	}
+	if _, ok := mvs_api.NewRPCClient("http://127.0.0.1:8820/rpc/v2", "1s").Transport().(*mvs_api.HTTPTransport); !ok {
+		t.Error("NewRPCClient: transport is not HTTP")
+	}
+	if _, ok := (&mvs_api.RPCClient{}).Transport().(*mvs_api.HTTPTransport); !ok {
+		t.Error("zero client: transport is not HTTP")
+	}
+}
+
+func TestTransportCustom(t *testing.T) {
+	var gotUrl, gotBody string
+	var gotCtx context.Context
+	transport := mvs_api.TransportFunc(func(ctx context.Context, url string, body []byte) (int, []byte, error) {
+		gotCtx, gotUrl, gotBody = ctx, url, string(body)
+		return 200, []byte(`{"jsonrpc":"2.0","id":1,"result":1270000}`), nil
+	})
+	r, err := mvs_api.New("unix:///var/run/mvsd.sock", mvs_api.WithTransport(transport))
+	if err != nil {
+		t.Fatal(err)
+	}
+	type key struct{}
+	ctx := context.WithValue(context.Background(), key{}, "v")
+	height, err := r.GetheightContext(ctx, "admin", "pw")
+	if err != nil || height != 1270000 {
+		t.Fatalf("getheight = %d, %v", height, err)
+	}
+	if gotUrl != "unix:///var/run/mvsd.sock" || gotCtx.Value(key{}) != "v" {
+		t.Errorf("url = %s, ctx = %v", gotUrl, gotCtx)
+	}
+	want := `{"id":1,"jsonrpc":"2.0","method":"getheight","params":["admin","pw",{}]}`
+	if gotBody != want {
+		t.Errorf("body = %s, want %s", gotBody, want)
+	}
+
+	failing := mvs_api.TransportFunc(func(ctx context.Context, url string, body []byte) (int, []byte, error) {
+		return 0, nil, io.ErrUnexpectedEOF
+	})
+	r.SetTransport(failing)
+	_, err = r.Getheight("", "")
+	if !errors.Is(err, io.ErrUnexpectedEOF) || mvs_api.Classify(err) != mvs_api.ErrorClassTransport {
+		t.Errorf("err = %v, class %v", err, mvs_api.Classify(err))
+	}
+}
+
+func TestHandlerTransport(t *testing.T) {
+	s := mvstest.NewServer()
+	s.Close()
+	// The handler is called directly, so the closed listener does not matter.
+	r, err := mvs_api.New(s.URL+mvstest.Path, mvs_api.WithTransport(&mvs_api.HandlerTransport{Handler: s.Config.Handler}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	addresses, err := r.Listaddresses("Alice", "A123456")
+	if err != nil || len(addresses) != 1 || addresses[0] != mvstest.Address {
+		t.Errorf("listaddresses = %v, %v", addresses, err)
+	}
+
+	s.Fail("getheight", errors.New("broken node"))
+	_, err = r.Getheight("", "")
+	var transportErr *mvs_api.TransportError
+	if !errors.As(err, &transportErr) || transportErr.StatusCode != 500 {
+		t.Errorf("err = %v, want a status 500 TransportError", err)
+	}
+
+	silent := &mvs_api.HandlerTransport{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, `{"jsonrpc":"2.0","id":1,"result":"ok"}`)
+	})}
+	status, body, err := silent.Post(context.Background(), "http://node/rpc/v2", []byte("{}"))
+	if status != 200 || !strings.Contains(string(body), `"ok"`) || err != nil {
+		t.Errorf("post = %d, %s, %v", status, body, err)
+	}
+}
@@ -0,0 +1,377 @@
+package mvs_api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"reflect"
+)
+
+// RawResult keeps the undecoded JSON result of a call, so fields that are
+// not modelled by the typed structs are still reachable. Only struct results
+// embed it; WithRawResult gets the raw result of any call.
+type RawResult struct {
+	raw json.RawMessage
+}
+
+// RawJSON returns the result exactly as the node sent it.
+func (r *RawResult) RawJSON() json.RawMessage {
+	return r.raw
+}
+
+func (r *RawResult) setRaw(raw json.RawMessage) {
+	r.raw = raw
+}
+
+type rawResultKey struct{}
+
+// WithRawResult makes calls made with ctx store their undecoded result in
+// *dst, whatever the type of the decoded one, e.g. for a list or string
+// result that has no RawResult.
+func WithRawResult(ctx context.Context, dst *json.RawMessage) context.Context {
+	return context.WithValue(ctx, rawResultKey{}, dst)
+}
+
+func storeRawResult(ctx context.Context, raw *json.RawMessage) {
+	if dst, ok := ctx.Value(rawResultKey{}).(*json.RawMessage); ok && dst != nil && raw != nil {
+		*dst = append(json.RawMessage(nil), *raw...)
+	}
+}
+
+type rawSetter interface {
+	setRaw(raw json.RawMessage)
+}
+
+type OutputPoint struct {
+	Hash  string `json:"hash"`
+	Index uint32 `json:"index"`
+}
+
+type TxInput struct {
+	Address        string      `json:"address"`
+	PreviousOutput OutputPoint `json:"previous_output"`
+	Script         string      `json:"script"`
+	Sequence       uint32      `json:"sequence"`
+}
+
+type Attachment struct {
+	Type          string `json:"type"`
+	Symbol        string `json:"symbol,omitempty"`
+	Quantity      uint64 `json:"quantity,omitempty"`
+	DecimalNumber uint32 `json:"decimal_number,omitempty"`
+	MaximumSupply uint64 `json:"maximum_supply,omitempty"`
+	Issuer        string `json:"issuer,omitempty"`
+	Address       string `json:"address,omitempty"`
+	Description   string `json:"description,omitempty"`
+	Content       string `json:"content,omitempty"`
+	Cert          string `json:"cert,omitempty"`
+	Owner         string `json:"owner,omitempty"`
+	FromDid       string `json:"from_did,omitempty"`
+	ToDid         string `json:"to_did,omitempty"`
+	Status        string `json:"status,omitempty"`
+}
+
+type TxOutput struct {
+	Index             uint32     `json:"index"`
+	Address           string     `json:"address"`
+	Script            string     `json:"script"`
+	Value             uint64     `json:"value"`
+	LockedHeightRange uint64     `json:"locked_height_range"`
+	Attachment        Attachment `json:"attachment"`
+}
+
+type Transaction struct {
+	RawResult
+	Hash      string     `json:"hash"`
+	Height    uint64     `json:"height"`
+	Timestamp uint64     `json:"timestamp"`
+	Direction string     `json:"direction"`
+	Memo      string     `json:"memo"`
+	Version   uint32     `json:"version"`
+	LockTime  uint32     `json:"lock_time"`
+	Inputs    []TxInput  `json:"inputs"`
+	Outputs   []TxOutput `json:"outputs"`
+	// Hex is set instead of the other fields when the node answers with
+	// the raw (non json) encoding of the transaction.
+	Hex string `json:"-"`
+}
+
+func (t *Transaction) UnmarshalJSON(data []byte) error {
+	if isJSONString(data) {
+		return json.Unmarshal(data, &t.Hex)
+	}
+	type plain Transaction
+	return json.Unmarshal(data, (*plain)(t))
+}
+
+type TxPage struct {
+	RawResult
+	CurrentPage  uint64        `json:"current_page"`
+	TotalPage    uint64        `json:"total_page"`
+	Transactions []Transaction `json:"transactions"`
+}
+
+type BlockHeader struct {
+	RawResult
+	Hash              string `json:"hash"`
+	Bits              string `json:"bits"`
+	MerkleTreeHash    string `json:"merkle_tree_hash"`
+	Mixhash           string `json:"mixhash"`
+	Nonce             string `json:"nonce"`
+	Number            uint64 `json:"number"`
+	PreviousBlockHash string `json:"previous_block_hash"`
+	TimeStamp         uint64 `json:"time_stamp"`
+	TransactionCount  uint64 `json:"transaction_count"`
+	Version           uint32 `json:"version"`
+}
+
+type Block struct {
+	BlockHeader
+	Transactions []Transaction `json:"transactions"`
+	// Hex is set instead of the other fields when the block is requested
+	// with json=false.
+	Hex string `json:"-"`
+}
+
+func (b *Block) UnmarshalJSON(data []byte) error {
+	if isJSONString(data) {
+		return json.Unmarshal(data, &b.Hex)
+	}
+	type plain Block
+	return json.Unmarshal(data, (*plain)(b))
+}
+
+type Asset struct {
+	RawResult
+	Symbol                  string `json:"symbol"`
+	Address                 string `json:"address"`
+	Issuer                  string `json:"issuer"`
+	Description             string `json:"description"`
+	DecimalNumber           uint32 `json:"decimal_number"`
+	MaximumSupply           uint64 `json:"maximum_supply"`
+	SecondaryissueThreshold int32  `json:"secondaryissue_threshold"`
+	IsSecondaryissue        bool   `json:"is_secondaryissue"`
+	Status                  string `json:"status"`
+}
+
+// UnmarshalJSON also accepts a bare symbol, which is what the node lists
+// when no symbol is given to Getasset.
+func (a *Asset) UnmarshalJSON(data []byte) error {
+	if isJSONString(data) {
+		return json.Unmarshal(data, &a.Symbol)
+	}
+	type plain Asset
+	return json.Unmarshal(data, (*plain)(a))
+}
+
+type AssetBalance struct {
+	Symbol         string `json:"symbol"`
+	Address        string `json:"address"`
+	Issuer         string `json:"issuer"`
+	Quantity       uint64 `json:"quantity"`
+	LockedQuantity uint64 `json:"locked_quantity"`
+	DecimalNumber  uint32 `json:"decimal_number"`
+	Cert           string `json:"cert,omitempty"`
+	Owner          string `json:"owner,omitempty"`
+	Status         string `json:"status"`
+}
+
+type AddressBalance struct {
+	RawResult
+	Address   string `json:"address"`
+	Confirmed uint64 `json:"confirmed"`
+	Received  uint64 `json:"received"`
+	Unspent   uint64 `json:"unspent"`
+	Available uint64 `json:"available"`
+	Frozen    uint64 `json:"frozen"`
+}
+
+// UnmarshalJSON also accepts the {"balance": {...}} wrapper used by
+// listbalances.
+func (b *AddressBalance) UnmarshalJSON(data []byte) error {
+	type plain AddressBalance
+	var wrapper struct {
+		Balance *plain `json:"balance"`
+	}
+	if err := json.Unmarshal(data, &wrapper); err == nil && wrapper.Balance != nil {
+		*b = AddressBalance(*wrapper.Balance)
+		return nil
+	}
+	return json.Unmarshal(data, (*plain)(b))
+}
+
+type Balance struct {
+	RawResult
+	TotalConfirmed uint64 `json:"total_confirmed"`
+	TotalReceived  uint64 `json:"total_received"`
+	TotalUnspent   uint64 `json:"total_unspent"`
+	TotalAvailable uint64 `json:"total_available"`
+	TotalFrozen    uint64 `json:"total_frozen"`
+}
+
+type Account struct {
+	RawResult
+	Name           string   `json:"name"`
+	Mnemonic       string   `json:"mnemonic"`
+	HdIndex        uint32   `json:"hd_index"`
+	DefaultAddress string   `json:"default-address"`
+	Addresses      []string `json:"addresses"`
+}
+
+type AccountStatus struct {
+	RawResult
+	Name   string `json:"name"`
+	Status string `json:"status"`
+}
+
+type DIDAddress struct {
+	Address   string `json:"address"`
+	Status    string `json:"status"`
+	Height    uint64 `json:"height"`
+	Timestamp uint64 `json:"timestamp"`
+}
+
+type DID struct {
+	RawResult
+	Symbol    string       `json:"symbol"`
+	Address   string       `json:"address"`
+	Status    string       `json:"status"`
+	Addresses []DIDAddress `json:"addresses"`
+}
+
+type MIT struct {
+	Symbol    string `json:"symbol"`
+	Content   string `json:"content"`
+	Address   string `json:"address"`
+	Status    string `json:"status"`
+	Height    uint64 `json:"height"`
+	TimeStamp uint64 `json:"time_stamp"`
+	FromDid   string `json:"from_did,omitempty"`
+	ToDid     string `json:"to_did,omitempty"`
+}
+
+type Multisig struct {
+	RawResult
+	Address        string   `json:"address"`
+	Description    string   `json:"description"`
+	Index          uint32   `json:"index"`
+	M              uint16   `json:"m"`
+	N              uint16   `json:"n"`
+	PublicKeys     []string `json:"public-keys"`
+	SelfPublicKey  string   `json:"self-publickey"`
+	MultisigScript string   `json:"multisig-script"`
+}
+
+type AddressValidation struct {
+	RawResult
+	Address     string `json:"address"`
+	AddressType string `json:"address-type"`
+	IsValid     bool   `json:"is-valid"`
+	Testnet     bool   `json:"testnet"`
+	Message     string `json:"message"`
+}
+
+type PublicKey struct {
+	RawResult
+	Address   string `json:"address"`
+	PublicKey string `json:"public-key"`
+}
+
+type SignedTx struct {
+	RawResult
+	Hash  string `json:"hash"`
+	Rawtx string `json:"rawtx"`
+}
+
+type Info struct {
+	RawResult
+	ProtocolVersion    uint32 `json:"protocol-version"`
+	WalletVersion      string `json:"wallet-version"`
+	DatabaseVersion    string `json:"database-version"`
+	Testnet            bool   `json:"testnet"`
+	Peers              uint32 `json:"peers"`
+	NetworkAssetsCount uint32 `json:"network-assets-count"`
+	WalletAccountCount uint32 `json:"wallet-account-count"`
+	Height             uint64 `json:"height"`
+	Difficulty         string `json:"difficulty"`
+	IsMining           bool   `json:"is-mining"`
+	HashRate           uint64 `json:"hash-rate"`
+}
+
+type MiningInfo struct {
+	RawResult
+	IsMining   bool   `json:"is-mining"`
+	Height     uint64 `json:"height"`
+	Rate       uint64 `json:"rate"`
+	Difficulty string `json:"difficulty"`
+}
+
+type KeyFile struct {
+	RawResult
+	// Path is set when the node wrote the key file to disk.
+	Path string
+	// Content is set when the key file was requested with data=true.
+	Content json.RawMessage
+}
+
+func (k *KeyFile) UnmarshalJSON(data []byte) error {
+	if isJSONString(data) {
+		return json.Unmarshal(data, &k.Path)
+	}
+	k.Content = append(json.RawMessage(nil), data...)
+	return nil
+}
+
+func isJSONString(data []byte) bool {
+	data = bytes.TrimSpace(data)
+	return len(data) > 0 && data[0] == '"'
+}
+
+// decodeResult decodes a call result into v. When key is not empty and
+// the result is an object carrying that key, the value under key is
+// decoded instead, which covers the {"transaction": {...}} style wrappers
+// mvsd puts around many results. A single object is accepted where a list
+// is expected.
+func decodeResult(raw *json.RawMessage, key string, v interface{}) error {
+	if raw == nil {
+		return nil
+	}
+	data := bytes.TrimSpace(*raw)
+	if len(data) == 0 || bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+
+	body := data
+	if key != "" && data[0] == '{' {
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(data, &fields); err == nil {
+			if inner, ok := fields[key]; ok {
+				body = bytes.TrimSpace(inner)
+			}
+		}
+	}
+	if len(body) > 0 && body[0] == '{' && isSliceTarget(v) {
+		body = append(append([]byte{'['}, body...), ']')
+	}
+	if err := json.Unmarshal(body, v); err != nil {
+		return err
+	}
+	setRaw(v, json.RawMessage(data))
+	return nil
+}
+
+func isSliceTarget(v interface{}) bool {
+	t := reflect.TypeOf(v)
+	return t != nil && t.Kind() == reflect.Ptr && t.Elem().Kind() == reflect.Slice
+}
+
+func setRaw(v interface{}, raw json.RawMessage) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		if s, ok := rv.Interface().(rawSetter); ok {
+			s.setRaw(raw)
+			return
+		}
+		rv = rv.Elem()
+	}
+}
@@ -0,0 +1,130 @@
+package mvs_api_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"mvs_api"
+	"mvs_api/mvstest"
+)
+
+func TestTypedResults(t *testing.T) {
+	s := mvstest.NewServer()
+	defer s.Close()
+	r := s.Client()
+
+	s.Respond("getbalance", json.RawMessage(`{"total_confirmed":5,"total_received":7,"total_unspent":5,"total_available":4,"total_frozen":1,"extra":true}`))
+	balance, err := r.Getbalance("Alice", "A123456")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if balance.TotalReceived != 7 || balance.TotalAvailable != 4 || balance.TotalFrozen != 1 {
+		t.Errorf("balance = %+v", balance)
+	}
+	if want := `{"total_confirmed":5,"total_received":7,"total_unspent":5,"total_available":4,"total_frozen":1,"extra":true}`; string(balance.RawJSON()) != want {
+		t.Errorf("raw = %s, want %s", balance.RawJSON(), want)
+	}
+	s.LastCall(t, "getbalance").AssertPositional(t, "Alice", "A123456")
+
+	s.Respond("listaddresses", json.RawMessage(`{"addresses":["`+mvstest.Address+`"]}`))
+	addresses, err := r.Listaddresses("Alice", "A123456")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(addresses) != 1 || addresses[0] != mvstest.Address {
+		t.Errorf("addresses = %v", addresses)
+	}
+
+	s.Respond("getheight", json.RawMessage(`1270000`))
+	height, err := r.Getheight("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if height != 1270000 {
+		t.Errorf("height = %d", height)
+	}
+}
+
+func TestTypedTransaction(t *testing.T) {
+	s := mvstest.NewServer()
+	defer s.Close()
+	r := s.Client()
+
+	s.Respond("gettx", json.RawMessage(`{"transaction":{"hash":"ab12","height":7,"outputs":[{"address":"`+mvstest.Address+`","value":100}]}}`))
+	tx, err := r.Gettx(true, "ab12")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tx.Hash != "ab12" || tx.Height != 7 || len(tx.Outputs) != 1 || tx.Outputs[0].Address != mvstest.Address {
+		t.Errorf("tx = %+v", tx)
+	}
+	s.LastCall(t, "gettx").AssertPositional(t, true, "ab12")
+
+	s.Respond("gettx", json.RawMessage(`"0400000001"`))
+	tx, err = r.Gettx(false, "ab12")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tx.Hex != "0400000001" || tx.Hash != "" {
+		t.Errorf("tx = %+v", tx)
+	}
+
+	s.Respond("gettx", json.RawMessage(`null`))
+	tx, err = r.Gettx(true, "ab12")
+	if err != nil || tx != nil {
+		t.Errorf("tx = %+v, err = %v", tx, err)
+	}
+}
+
+func TestWithRawResult(t *testing.T) {
+	s := mvstest.NewServer()
+	defer s.Close()
+	r := s.Client()
+
+	var raw json.RawMessage
+	ctx := mvs_api.WithRawResult(context.Background(), &raw)
+	addresses, err := r.ListaddressesContext(ctx, "Alice", "A123456")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(addresses) != 1 || addresses[0] != mvstest.Address {
+		t.Fatalf("addresses = %v", addresses)
+	}
+	want := `{"addresses":["` + mvstest.Address + `"]}`
+	if string(raw) != want {
+		t.Errorf("raw = %s, want %s", raw, want)
+	}
+
+	height, err := r.GetheightContext(ctx, "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(raw) != "1270000" || height != 1270000 {
+		t.Errorf("raw = %s, height = %d", raw, height)
+	}
+}
+
+func TestWithOptions(t *testing.T) {
+	s := mvstest.NewServer()
+	defer s.Close()
+	r := s.Client()
+	ctx := context.Background()
+
+	if _, err := r.SendWithOptions(ctx, "Alice", "A123456", mvstest.Address, 100, &mvs_api.SendOptions{Memo: "rent", Fee: 20000}); err != nil {
+		t.Fatal(err)
+	}
+	call := s.LastCall(t, "send")
+	call.AssertPositional(t, "Alice", "A123456", mvstest.Address, 100)
+	call.AssertOptional(t, map[string]interface{}{"memo": "rent", "fee": 20000})
+
+	if _, err := r.SendWithOptions(ctx, "Alice", "A123456", mvstest.Address, 100, nil); err != nil {
+		t.Fatal(err)
+	}
+	s.LastCall(t, "send").AssertOptional(t, nil)
+
+	if _, err := r.SignmultisigtxWithOptions(ctx, "Alice", "A123456", "0400", &mvs_api.SignmultisigtxOptions{Broadcast: true}); err != nil {
+		t.Fatal(err)
+	}
+	s.LastCall(t, "signmultisigtx").AssertPositional(t, "Alice", "A123456", "0400", "--broadcast")
+}
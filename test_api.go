@@ -1,139 +1,155 @@
 package main
 
 import (
-	"encoding/json"
 	"fmt"
 	"mvs_api"
+	"mvs_api/address"
+	"mvs_api/hdwallet"
+	"mvs_api/rawtx"
 	"strings"
+	"time"
 )
 
 func main() {
-	r := mvs_api.NewRPCClient("http://127.0.0.1:8820/rpc/v2", "1s")
-	rpcResp, err := r.Getdid("BIAM")
+	r, err := mvs_api.New("http://127.0.0.1:8820/rpc/v2", mvs_api.WithTimeout(time.Second))
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	for _, s := range []string{"MLasJFxZQnA49XEvhTHmRKi2qstkj9ppjo", "359mjCL3V8PaxLUzU9mJSNtLSEXHFJmzfA", "MLasJFxZQnA49XEvhTHmRKi2qstkj9ppjp"} {
+		a, decodeErr := address.Decode(s)
+		v, err := r.Validateaddress(s)
+		if err != nil {
+			fmt.Println(err)
+		} else if v.IsValid != (decodeErr == nil) {
+			fmt.Println("address mismatch:", s, v.IsValid, decodeErr)
+		} else if a != nil {
+			fmt.Printf("%s 0x%02x %x %s\n", s, a.Version, a.Hash, v.AddressType)
+		}
+	}
+
+	raw, err := r.Createrawtx(0, []string{"MLasJFxZQnA49XEvhTHmRKi2qstkj9ppjo"},
+		mvs_api.Receivers{{To: "MLasJFxZQnA49XEvhTHmRKi2qstkj9ppjo", Amount: mvs_api.ETP}}, "", 0, "", "", 0)
+	if err != nil {
+		fmt.Println(err)
+	} else if tx, err := rawtx.Decode(raw); err != nil {
+		fmt.Println(err)
+	} else {
+		hash, _ := tx.Hash()
+		decoded, err := r.Decoderawtx(raw)
+		if err != nil {
+			fmt.Println(err)
+		} else if decoded.Hash != hash {
+			fmt.Println("rawtx hash mismatch:", hash, decoded.Hash)
+		}
+	}
+
+	did, err := r.Getdid("BIAM")
 	if err != nil {
 		fmt.Println(err)
 	} else {
-		var reply map[string]interface{}
-		json.Unmarshal(*rpcResp.Result, &reply)
-		fmt.Println(reply)
+		fmt.Println(did.Symbol, did.Address, string(did.RawJSON()))
 	}
 
-	rpcResp, err = r.Getdid("")
+	did, err = r.Getdid("")
 	if err != nil {
 		fmt.Println(err)
 	} else {
-		var reply map[string]interface{}
-		json.Unmarshal(*rpcResp.Result, &reply)
-		fmt.Println(reply)
+		fmt.Println(string(did.RawJSON()))
 	}
 
-	rpcResp, err = r.Setminingaccount("Alice", "A123456", "MLasJFxZQnA49XEvhTHmRKi2qstkj9ppjo")
+	status, err := r.Setminingaccount("Alice", "A123456", "MLasJFxZQnA49XEvhTHmRKi2qstkj9ppjo")
 	if err != nil {
 		fmt.Println(err)
 	} else {
-		var reply string
-		json.Unmarshal(*rpcResp.Result, &reply)
-		fmt.Println(reply)
+		fmt.Println(status)
 	}
 
-	rpcResp, err = r.Getwork("", "")
+	work, err := r.Getwork("", "")
 	if err != nil {
 		fmt.Println(err)
 	} else {
-		var reply []string
-		json.Unmarshal(*rpcResp.Result, &reply)
-		fmt.Println(reply)
+		fmt.Println(work)
 	}
 
-	rpcResp, err = r.Getnewmultisig("Alice", "A123456", 2, 3, "0380990a7312b87abda80e5857ee6ebf798a2bf62041b07111287d19926c429d11",
+	multisig, err := r.Getnewmultisig("Alice", "A123456", 2, 3, "0380990a7312b87abda80e5857ee6ebf798a2bf62041b07111287d19926c429d11",
 		[]string{"02578ad340083e85c739f379bbe6c6937c5da2ced52e09ac1eec43dc4c64846573", "03af3a99f1c3279dbe1c22fd767fb98b5dbd138f6e0511c2fc11128e44c0373cad"},
 		"test mvs api")
 	if err != nil {
 		fmt.Println(err)
 	} else {
-		var reply map[string]interface{}
-		json.Unmarshal(*rpcResp.Result, &reply)
-		fmt.Println(reply["address"])
+		fmt.Println(multisig.Address)
+		if err := multisig.Verify(); err != nil {
+			fmt.Println(err)
+		}
 	}
 
-	rpcResp, err = r.Listmultisig("Alice", "A123456")
+	multisigs, err := r.Listmultisig("Alice", "A123456")
 	if err != nil {
 		fmt.Println(err)
 	} else {
-		var reply map[string]interface{}
-		json.Unmarshal(*rpcResp.Result, &reply)
-		fmt.Println(reply)
+		fmt.Println(multisigs)
 	}
 
-	rpcResp, err = r.Deletemultisig("Alice", "A123456", "359mjCL3V8PaxLUzU9mJSNtLSEXHFJmzfA")
+	multisig, err = r.Deletemultisig("Alice", "A123456", "359mjCL3V8PaxLUzU9mJSNtLSEXHFJmzfA")
 	if err != nil {
 		fmt.Println(err)
 	} else {
-		var reply map[string]interface{}
-		json.Unmarshal(*rpcResp.Result, &reply)
-		fmt.Println(reply)
+		fmt.Println(multisig.Address)
 	}
 
-	rpcResp, err = r.Getaccountasset("Alice", "A123456", "", true)
+	assets, err := r.Getaccountasset("Alice", "A123456", "", true)
 	if err != nil {
 		fmt.Println(err)
 	} else {
-		var reply map[string]interface{}
-		json.Unmarshal(*rpcResp.Result, &reply)
-		fmt.Println(reply)
+		fmt.Println(assets)
 	}
 
-	rpcResp, err = r.Getaccountasset("Alice", "A123456", "", false)
+	assets, err = r.Getaccountasset("Alice", "A123456", "", false)
 	if err != nil {
 		fmt.Println(err)
 	} else {
-		var reply map[string]interface{}
-		json.Unmarshal(*rpcResp.Result, &reply)
-		fmt.Println(reply)
+		fmt.Println(assets)
 	}
 
-	rpcResp, err = r.Listtxs("Alice", "A123456", "MLasJFxZQnA49XEvhTHmRKi2qstkj9ppjo", [2]uint64{1000, 1001}, "", 0, 0)
+	txs, err := r.Listtxs("Alice", "A123456", "MLasJFxZQnA49XEvhTHmRKi2qstkj9ppjo", [2]uint64{1000, 1001}, "", 0, 0)
 	if err != nil {
 		fmt.Println(err)
 	} else {
-		var reply map[string]interface{}
-		json.Unmarshal(*rpcResp.Result, &reply)
-		fmt.Println(reply)
+		fmt.Println(txs.CurrentPage, txs.TotalPage, len(txs.Transactions))
 	}
 
-	rpcResp, err = r.Importaccount(strings.Split("notice judge certain company novel quality plunge list blind library ride uncover fold wink biology original aim whale stand coach hire clinic fame robot", " "), "", "robot", "robot123456", 10)
+	words := strings.Split("notice judge certain company novel quality plunge list blind library ride uncover fold wink biology original aim whale stand coach hire clinic fame robot", " ")
+	account, err := r.Importaccount(words, "", "robot", "robot123456", 10)
 	if err != nil {
 		fmt.Println(err)
 	} else {
-		var reply map[string]interface{}
-		json.Unmarshal(*rpcResp.Result, &reply)
-		fmt.Println(reply)
+		fmt.Println(account.Name, account.HdIndex)
+		predicted, err := hdwallet.PredictAddresses(words, 10)
+		addresses, _ := r.Listaddresses("robot", "robot123456")
+		if err != nil || strings.Join(predicted, " ") != strings.Join(addresses, " ") {
+			fmt.Println("hd address mismatch:", err, predicted, addresses)
+		}
 	}
 
-	rpcResp, err = r.Deleteaccount("robot", "robot123456", "robot")
+	accountStatus, err := r.Deleteaccount("robot", "robot123456", "robot")
 	if err != nil {
 		fmt.Println(err)
 	} else {
-		var reply map[string]interface{}
-		json.Unmarshal(*rpcResp.Result, &reply)
-		fmt.Println(reply)
+		fmt.Println(accountStatus.Name, accountStatus.Status)
 	}
 
-	rpcResp, err = r.Dumpkeyfile("Alice", "A123456", "robot", "", true)
+	keyfile, err := r.Dumpkeyfile("Alice", "A123456", "robot", "", true)
 	if err != nil {
 		fmt.Println(err)
 	} else {
-		var reply map[string]interface{}
-		json.Unmarshal(*rpcResp.Result, &reply)
-		fmt.Println(reply)
+		fmt.Println(string(keyfile.Content))
 	}
 
-	rpcResp, err = r.Dumpkeyfile("Alice", "A123456", "robot", "", false)
+	keyfile, err = r.Dumpkeyfile("Alice", "A123456", "robot", "", false)
 	if err != nil {
 		fmt.Println(err)
 	} else {
-		var reply string
-		json.Unmarshal(*rpcResp.Result, &reply)
-		fmt.Println(reply)
+		fmt.Println(keyfile.Path)
 	}
 }